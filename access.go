@@ -0,0 +1,97 @@
+package lru
+
+import "sync/atomic"
+
+// accessBuffer records the items read by Get without taking the cache lock, see WithAccessBuffer.
+// Recording is lossy: accesses made while the buffer is full are dropped.
+type accessBuffer[K comparable, V any] struct {
+	slots []access[K, V] // Recorded accesses, in the order they were made.
+	head  atomic.Int64   // Index of the next free slot.
+}
+
+// access is an access recorded in an access buffer.
+type access[K comparable, V any] struct {
+	item   atomic.Pointer[cache[K, V]] // Item which was read.
+	reuses atomic.Uint32               // Reuses of the arena slot of the item when it was read.
+}
+
+// newAccessBuffer creates an access buffer recording up to size accesses between drains.
+func newAccessBuffer[K comparable, V any](size int) *accessBuffer[K, V] {
+	return &accessBuffer[K, V]{slots: make([]access[K, V], size)}
+}
+
+// record records an access to the provided item, whose arena slot was reused the provided number of times.
+// It reports whether the access filled the buffer, in which case the caller must drain it.
+func (b *accessBuffer[K, V]) record(c *cache[K, V], reuses uint32) bool {
+	i := b.head.Add(1) - 1
+	if i >= int64(len(b.slots)) {
+		return false
+	}
+
+	b.slots[i].reuses.Store(reuses)
+	b.slots[i].item.Store(c)
+
+	return i == int64(len(b.slots))-1
+}
+
+// drain calls fn with every recorded item and the reuses of its slot when it was read,
+// from the least recently accessed to the most recently accessed, and empties the buffer.
+func (b *accessBuffer[K, V]) drain(fn func(c *cache[K, V], reuses uint32)) {
+	n := min(b.head.Load(), int64(len(b.slots)))
+	for i := int64(0); i < n; i++ {
+		if c := b.slots[i].item.Swap(nil); c != nil {
+			fn(c, b.slots[i].reuses.Load())
+		}
+	}
+
+	b.head.Store(0)
+}
+
+// getBuffered retrieves the value of the provided key under the read lock, recording the access in the access buffer
+// instead of moving the item to the head of the list.
+// It reports false when the key is missing or its item needs the exclusive lock, being stale or due for a refresh,
+// in which case the caller falls back to the locked path.
+func (l *lru[K, V]) getBuffered(key K) (V, bool) {
+	l.locker.RLock()
+
+	c, ok := l.cache[key]
+	if !ok || l.stale(c) || l.refreshDue(c) {
+		l.locker.RUnlock()
+
+		var emptyVal V
+		return emptyVal, false
+	}
+
+	value, reuses := c.value, c.reuses
+	l.bufferedHits.Add(1)
+	l.locker.RUnlock()
+
+	if l.accesses.record(c, reuses) {
+		l.locker.Lock()
+		l.drainAccesses()
+		l.locker.Unlock()
+	}
+
+	return value, true
+}
+
+// drainAccesses applies the accesses recorded in the access buffer, moving the items still cached to the head of the list
+// and counting the accesses as made at the time of the drain, which is when sliding TTLs are extended as well.
+// Accesses to items which left the cache since are dropped, even when their arena slot now holds another item,
+// as are accesses to items invalidated by InvalidateAll since, which must stay at the back of the list.
+// It must be called with the lock held.
+func (l *lru[K, V]) drainAccesses() {
+	if l.accesses == nil {
+		return
+	}
+
+	now := l.now().UnixNano()
+	l.accesses.drain(func(c *cache[K, V], reuses uint32) {
+		if c.reuses == reuses && l.cache[c.key] == c && !l.outdated(c) {
+			c.hits++
+			c.accessed = now
+			l.moveToFront(c)
+			l.slide(c, now)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package lru
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestAccessBuffer(t *testing.T) {
+	t.Run("should apply buffered accesses once the buffer is full", func(t *testing.T) {
+		l := New[int, int](3, WithAccessBuffer[int, int](2))
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+
+		l.Get(1)
+		if !reflect.DeepEqual([]int{3, 2, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 2, 1}, l.Keys())
+		}
+
+		l.Get(2)
+		if !reflect.DeepEqual([]int{2, 1, 3}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{2, 1, 3}, l.Keys())
+		}
+	})
+
+	t.Run("should apply buffered accesses before evicting", func(t *testing.T) {
+		l := New[int, int](3, WithAccessBuffer[int, int](8))
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+
+		l.Get(1)
+		l.Set(4, 4)
+
+		if l.Contains(2) || !l.Contains(1) {
+			t.Errorf("Expected key 2 to be evicted; Actual = %v", l.Keys())
+		}
+	})
+
+	t.Run("should apply buffered accesses before returning the oldest item", func(t *testing.T) {
+		l := New[int, int](3, WithAccessBuffer[int, int](8))
+		l.Set(1, 1)
+		l.Set(2, 2)
+
+		l.Get(1)
+
+		key, _, _ := l.GetOldest()
+		if !reflect.DeepEqual(2, key) {
+			t.Errorf("Expected %v; Actual = %v", 2, key)
+		}
+	})
+
+	t.Run("should ignore buffered accesses of removed items", func(t *testing.T) {
+		l := New[int, int](3, WithAccessBuffer[int, int](8))
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+
+		l.Get(1)
+		l.Del(1)
+		l.GetOldest()
+
+		if !reflect.DeepEqual([]int{3, 2}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 2}, l.Keys())
+		}
+	})
+
+	t.Run("should ignore buffered accesses of items whose slot was reused", func(t *testing.T) {
+		l := New[int, int](3, WithAccessBuffer[int, int](8))
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+
+		l.Get(1)
+		l.Del(1)
+		l.Set(4, 4)
+		l.Set(3, 3)
+		l.GetOldest()
+
+		if !reflect.DeepEqual([]int{3, 4, 2}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 4, 2}, l.Keys())
+		}
+	})
+
+	t.Run("should count buffered hits and misses", func(t *testing.T) {
+		l := New[int, int](3, WithAccessBuffer[int, int](8))
+		l.Set(1, 1)
+
+		l.Get(1)
+		l.Get(1)
+		l.Get(2)
+
+		if stats := l.Stats(); stats.Hits != 2 || stats.Misses != 1 {
+			t.Errorf("Expected 2 hits and 1 miss; Actual = %+v", stats)
+		}
+	})
+
+	t.Run("should not race with concurrent gets and sets", func(t *testing.T) {
+		l := New[int, int](16, WithAccessBuffer[int, int](4))
+
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					if i%4 == 0 {
+						l.Set((g+i)%32, i)
+					} else {
+						l.Get((g + i) % 32)
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		if l.Len() > 16 || len(l.Keys()) != l.Len() {
+			t.Errorf("Expected at most 16 consistent keys; Actual = %v", l.Keys())
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package lru
+
+import "math/bits"
+
+// nilIndex is the index of no item, ending the linked list like a nil pointer would.
+// Items are indexed from one, so the zero value of a list is empty.
+const nilIndex int32 = 0
+
+// maxChunkShift bounds the chunks of an arena to 4096 items.
+const maxChunkShift = 12
+
+// arena allocates the items of an LRU cache from preallocated chunks instead of one heap object per item,
+// so items sit next to each other in memory and are linked by int32 indices rather than pointers.
+// Chunks never move once allocated, so pointers to items stay valid until they are released,
+// and released items are reused by the following allocations.
+type arena[K comparable, V any] struct {
+	chunks [][]cache[K, V] // Chunks holding the items, each 1<<shift items long.
+	shift  uint            // Base-2 logarithm of the number of items in a chunk.
+	used   int32           // Number of items ever handed out from the chunks, released ones included.
+	free   int32           // Index of the first released item, the others are chained through next.
+}
+
+// newArena creates an arena sized for a cache holding the provided number of items.
+// Small caches get a single chunk fitting their size, unbounded and large caches grow by chunks of 4096 items.
+func newArena[K comparable, V any](size int) arena[K, V] {
+	shift := uint(maxChunkShift)
+	if size > 0 {
+		shift = min(uint(bits.Len(uint(size-1))), maxChunkShift)
+	}
+
+	return arena[K, V]{shift: shift}
+}
+
+// at returns the item at the provided index, or nil for nilIndex.
+func (a *arena[K, V]) at(i int32) *cache[K, V] {
+	if i == nilIndex {
+		return nil
+	}
+
+	i--
+	return &a.chunks[i>>a.shift][i&(1<<a.shift-1)]
+}
+
+// alloc returns an unlinked item, reusing a released one when there is any.
+func (a *arena[K, V]) alloc() *cache[K, V] {
+	if a.free != nilIndex {
+		c := a.at(a.free)
+		a.free = c.next
+		c.next = nilIndex
+		return c
+	}
+
+	if int(a.used>>a.shift) == len(a.chunks) {
+		a.chunks = append(a.chunks, make([]cache[K, V], 1<<a.shift))
+	}
+
+	a.used++
+	c := a.at(a.used)
+	*c = cache[K, V]{slot: a.used, prev: nilIndex, next: nilIndex, index: -1}
+
+	return c
+}
+
+// release returns the provided item to the arena, clearing it so its key and value can be garbage collected.
+// The reuses of its slot are counted, so stale pointers to the item can be told apart from the next item of the slot.
+func (a *arena[K, V]) release(c *cache[K, V]) {
+	*c = cache[K, V]{slot: c.slot, reuses: c.reuses + 1, prev: nilIndex, next: a.free, index: -1}
+	a.free = c.slot
+}
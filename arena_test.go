@@ -0,0 +1,67 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArena(t *testing.T) {
+	t.Run("should size chunks after the capacity of the cache", func(t *testing.T) {
+		for size, expected := range map[int]uint{0: maxChunkShift, 1: 0, 3: 2, 4: 2, 5: 3, 1 << 20: maxChunkShift} {
+			if actual := newArena[int, int](size).shift; actual != expected {
+				t.Errorf("Expected %v for size %v; Actual = %v", expected, size, actual)
+			}
+		}
+	})
+
+	t.Run("should keep items in place while growing", func(t *testing.T) {
+		a := newArena[int, int](2)
+
+		var items []*cache[int, int]
+		for i := 0; i < 10; i++ {
+			c := a.alloc()
+			c.key = i
+			items = append(items, c)
+		}
+
+		for i, c := range items {
+			if a.at(c.slot) != c || c.key != i {
+				t.Errorf("Expected item %v at slot %v; Actual = %v", i, c.slot, a.at(c.slot))
+			}
+		}
+	})
+
+	t.Run("should reuse released items", func(t *testing.T) {
+		a := newArena[int, string](4)
+		first := a.alloc()
+		first.key, first.value = 1, "one"
+		a.alloc()
+
+		a.release(first)
+		if !reflect.DeepEqual("", first.value) {
+			t.Errorf("Expected %q; Actual = %q", "", first.value)
+		}
+
+		if actual := a.alloc(); actual != first {
+			t.Errorf("Expected released item %v; Actual = %v", first.slot, actual.slot)
+		}
+	})
+
+	t.Run("should keep the order of a cache growing past a chunk", func(t *testing.T) {
+		l := New[int, int](Unbounded)
+		for i := 0; i < 5000; i++ {
+			l.Set(i, i)
+		}
+		for i := 0; i < 5000; i += 2 {
+			l.Del(i)
+		}
+		for i := 5000; i < 7500; i++ {
+			l.Set(i, i)
+		}
+
+		keys := l.Keys()
+		if !reflect.DeepEqual(5000, len(keys)) || keys[0] != 7499 || keys[len(keys)-1] != 1 {
+			t.Errorf("Expected 5000 keys from 7499 to 1; Actual = %v keys from %v to %v", len(keys), keys[0], keys[len(keys)-1])
+		}
+	})
+}
@@ -0,0 +1,187 @@
+package lru
+
+import "time"
+
+// GetMany retrieves the values associated with the provided keys from the LRU cache, promoting them like Get,
+// and returns the ones found in the cache. Missing keys are left out of the result and counted as misses;
+// unlike Get, caches created with NewLoading do not load them.
+// The cache is locked once for all the keys, which spares the lock churn of many calls of Get.
+//
+// Example usage:
+//
+//	users := cache.GetMany([]int{1, 2, 3})
+func (l *lru[K, V]) GetMany(keys []K) map[K]V {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	out := make(map[K]V, len(keys))
+	for _, key := range keys {
+		c, ok := l.lookup(l.canonical(key))
+		if !ok {
+			l.stats.Misses++
+			continue
+		}
+
+		l.hit(c)
+		out[key] = c.value
+	}
+
+	return out
+}
+
+// SetMany adds or updates the provided key-value pairs in the LRU cache like Set,
+// locking the cache once for all of them, e.g. to warm it up.
+// The pairs are stored in the iteration order of the map, so their relative recency is unspecified.
+//
+// Example usage:
+//
+//	cache.SetMany(map[int]User{1: alice, 2: bob})
+func (l *lru[K, V]) SetMany(items map[K]V) {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	var expiry time.Time
+	for key, value := range items {
+		l.set(l.canonical(key), value, expiry)
+	}
+}
+
+// DelMany removes the key-value pairs associated with the provided keys from the LRU cache like Del,
+// locking the cache once for all of them, and returns how many were removed.
+// Like Del, every deletion is broadcast with the invalidator configured with WithInvalidator.
+//
+// Example usage:
+//
+//	removed := cache.DelMany([]int{1, 2, 3})
+func (l *lru[K, V]) DelMany(keys []K) int {
+	if l.keyTransform != nil {
+		canonical := make([]K, len(keys))
+		for i, key := range keys {
+			canonical[i] = l.canonical(key)
+		}
+		keys = canonical
+	}
+
+	l.locker.Lock()
+
+	out := 0
+	for _, key := range keys {
+		if l.del(key) {
+			out++
+		}
+	}
+
+	l.locker.Unlock()
+
+	for _, key := range keys {
+		l.broadcast(key)
+	}
+
+	return out
+}
+
+// GetMany retrieves the values associated with the provided keys from their shards, promoting them like Get.
+// Every shard holding some of the keys is locked once.
+func (s *sharded[K, V]) GetMany(keys []K) map[K]V {
+	out := make(map[K]V, len(keys))
+	for i, keys := range s.split(keys) {
+		if keys == nil {
+			continue
+		}
+		for key, value := range s.shards[i].GetMany(keys) {
+			out[key] = value
+		}
+	}
+
+	return out
+}
+
+// SetMany adds or updates the provided key-value pairs in their shards like Set.
+// Every shard receiving some of the pairs is locked once.
+func (s *sharded[K, V]) SetMany(items map[K]V) {
+	groups := make([]map[K]V, len(s.shards))
+	for key, value := range items {
+		i := s.index(key)
+		if groups[i] == nil {
+			groups[i] = map[K]V{}
+		}
+		groups[i][key] = value
+	}
+
+	for i, items := range groups {
+		if items == nil {
+			continue
+		}
+		s.shards[i].SetMany(items)
+	}
+}
+
+// DelMany removes the key-value pairs associated with the provided keys from their shards like Del,
+// and returns how many were removed. Every shard holding some of the keys is locked once.
+func (s *sharded[K, V]) DelMany(keys []K) int {
+	out := 0
+	for i, keys := range s.split(keys) {
+		if keys == nil {
+			continue
+		}
+		out += s.shards[i].DelMany(keys)
+	}
+
+	return out
+}
+
+// split groups the provided keys by the index of their shard.
+func (s *sharded[K, V]) split(keys []K) [][]K {
+	out := make([][]K, len(s.shards))
+	for _, key := range keys {
+		i := s.index(key)
+		out[i] = append(out[i], key)
+	}
+
+	return out
+}
+
+// GetMany retrieves the values associated with the provided keys from either tier, like Get,
+// and returns the ones found in the cache. The tiered cache is locked once for all the keys.
+func (t *tiered[K, V]) GetMany(keys []K) map[K]V {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	out := make(map[K]V, len(keys))
+	for _, key := range keys {
+		if value, ok := t.get(key); ok {
+			out[key] = value
+		}
+	}
+
+	return out
+}
+
+// SetMany adds or updates the provided key-value pairs in the first tier like Set,
+// locking the tiered cache once for all of them.
+func (t *tiered[K, V]) SetMany(items map[K]V) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	for key, value := range items {
+		t.set(key, value, nil)
+	}
+}
+
+// DelMany removes the key-value pairs associated with the provided keys from both tiers like Del,
+// locking the tiered cache once for all of them, and returns how many were removed.
+func (t *tiered[K, V]) DelMany(keys []K) int {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	out := 0
+	for _, key := range keys {
+		hot := t.hot.Del(key)
+		cold := t.cold.Del(key)
+		if hot || cold {
+			out++
+		}
+	}
+
+	return out
+}
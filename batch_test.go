@@ -0,0 +1,73 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	caches := map[string]func() LRU[int, int]{
+		"lru":     func() LRU[int, int] { return New[int, int](10) },
+		"sharded": func() LRU[int, int] { return NewSharded[int, int](100, 4) },
+		"tiered":  func() LRU[int, int] { return NewTiered[int, int](New[int, int](2), New[int, int](10)) },
+	}
+
+	for name, newCache := range caches {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should set and get many items", func(t *testing.T) {
+				l := newCache()
+				defer l.Close()
+
+				l.SetMany(map[int]int{1: 10, 2: 20, 3: 30})
+
+				expected := map[int]int{1: 10, 3: 30}
+				actual := l.GetMany([]int{1, 3, 4})
+				if !reflect.DeepEqual(expected, actual) {
+					t.Errorf("Expected %v; Actual = %v", expected, actual)
+				}
+
+				stats := l.Stats()
+				if !reflect.DeepEqual(uint64(2), stats.Hits) || !reflect.DeepEqual(uint64(1), stats.Misses) {
+					t.Errorf("Expected 2 hits and 1 miss; Actual = %v", stats)
+				}
+			})
+
+			t.Run("should delete many items", func(t *testing.T) {
+				l := newCache()
+				defer l.Close()
+
+				l.SetMany(map[int]int{1: 10, 2: 20, 3: 30})
+
+				if removed := l.DelMany([]int{1, 2, 4}); !reflect.DeepEqual(2, removed) {
+					t.Errorf("Expected %v; Actual = %v", 2, removed)
+				}
+				if !reflect.DeepEqual([]int{3}, l.Keys()) {
+					t.Errorf("Expected %v; Actual = %v", []int{3}, l.Keys())
+				}
+			})
+		})
+	}
+
+	t.Run("should promote the items read", func(t *testing.T) {
+		l := New[int, int](3)
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+
+		l.GetMany([]int{1, 2})
+		l.Set(4, 4)
+
+		if !reflect.DeepEqual([]int{4, 2, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{4, 2, 1}, l.Keys())
+		}
+	})
+
+	t.Run("should evict when setting more items than the capacity", func(t *testing.T) {
+		l := New[int, int](2)
+		l.SetMany(map[int]int{1: 1, 2: 2, 3: 3})
+
+		if !reflect.DeepEqual(2, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 2, l.Len())
+		}
+	})
+}
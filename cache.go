@@ -1,36 +1,165 @@
 package lru
 
 import (
+	"context"
+	"iter"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // cache represents an item in the cache.
 type cache[K comparable, V any] struct {
-	key   K            // Key associated with the cache item.
-	value V            // Value associated with the cache item.
-	prev  *cache[K, V] // Pointer to the previous cache item.
-	next  *cache[K, V] // Pointer to the next cache item.
-	ttl   *time.Time   // Cache expiry time.
+	key        K         // Key associated with the cache item.
+	value      V         // Value associated with the cache item.
+	slot       int32     // Index of the item in the arena.
+	reuses     uint32    // Number of times the arena slot was released, telling apart the items which held it.
+	prev       int32     // Index of the previous cache item, nilIndex for the head.
+	next       int32     // Index of the next cache item, nilIndex for the tail.
+	ttl        time.Time // Cache expiry time.
+	cost       int64     // Cost of the item counted against the maximum cost of the cache.
+	index      int       // Position of the item in the expiry heap, -1 when it has no TTL.
+	refreshing bool      // Flag set while the value is being refreshed in the background.
+	pinned     bool      // Flag set while the item is protected from eviction and expiry by Pin.
+	lease      *lease[V] // Outstanding leases on the value taken with Acquire, nil when there are none.
+	tags       []string  // Sorted tags attached to the item with SetWithTags.
+	priority   Priority  // Eviction priority set with SetWithPriority.
+	updated    time.Time // When the value was last stored, zero unless the cache has a loader.
+	created    int64     // When the item was added to the cache, in Unix nanoseconds.
+	accessed   int64     // When the item was last read, in Unix nanoseconds, zero if it never was.
+	hits       uint64    // Number of times the item was read.
+	version    uint64    // Version of the value, increasing with every write of the cache.
+	generation uint64    // Generation of the cache the value was written in, see InvalidateAll.
 }
 
 // lru represents a Least Recently Used (LRU) cache.
 type lru[K comparable, V any] struct {
-	cache      map[K]*cache[K, V] // Map storing cached items.
-	size       int                // Maximum number of items the cache can hold.
-	withExpiry bool               // Flag to enable/disable LRU with expiry.
-	head       *cache[K, V]       // Head of the linked list representing the LRU order.
-	tail       *cache[K, V]       // Tail of the linked list representing the LRU order.
-	length     int                // Current number of items in the cache.
-	sync.Mutex                    // Mutex for concurrent access.
+	cache            map[K]*cache[K, V]        // Map storing cached items.
+	size             int                       // Maximum number of items the cache can hold.
+	withExpiry       bool                      // Flag to enable/disable LRU with expiry.
+	nodes            arena[K, V]               // Arena allocating the cache items.
+	head             int32                     // Index of the head of the linked list representing the LRU order.
+	tail             int32                     // Index of the tail of the linked list representing the LRU order.
+	length           int                       // Current number of items in the cache.
+	maxCost          int64                     // Maximum total cost of the items, zero when unbounded.
+	cost             int64                     // Current total cost of the items in the cache.
+	sizer            Sizer[V]                  // Function computing the cost of a value.
+	defaultTTL       time.Duration             // TTL applied to items set without an explicit one.
+	slidingTTL       time.Duration             // Idle time after which items set without an explicit TTL expire, zero when disabled.
+	cleanupInterval  time.Duration             // Interval between runs of the expiry cleaner.
+	expiries         expiryHeap[K, V]          // Min-heap of items with a TTL ordered by expiry time.
+	stats            Stats                     // Usage counters of the cache.
+	bufferedHits     atomic.Uint64             // Hits counted under the read lock by buffered lookups, added to the stats.
+	onEvict          EvictCallback[K, V]       // Callback invoked when an item leaves the cache or is replaced.
+	eventsBuffer     int                       // Buffer size of the events channel, negative when disabled.
+	events           *dispatcher[K, V]         // Dispatcher delivering events, nil when disabled.
+	loader           Loader[K, V]              // Function loading missing values, nil when not a loading cache.
+	flight           flight[K, V]              // De-duplicates concurrent loads of the same key.
+	computes         flight[K, V]              // De-duplicates concurrent computations of the same key by GetOrCompute.
+	keyLocks         keyLocks[K]               // Per-key locks handed out by LockKey.
+	hotKeysSize      int                       // Number of most frequent keys tracked, zero when disabled.
+	hotKeys          *hotKeys[K]               // Tracker of the most frequently looked up keys, nil when disabled.
+	demoteFn         DemoteFunc[K, V]          // Function receiving the items evicted for room, nil when disabled.
+	demoter          *dispatcher[K, V]         // Dispatcher handing evicted items to the demotion worker, nil when disabled.
+	staleWindow      time.Duration             // How long stale values are served while they are refreshed.
+	refreshAfter     time.Duration             // Age after which values are refreshed ahead of expiry, zero when disabled.
+	negativeTTL      time.Duration             // How long loader errors are remembered, zero when disabled.
+	failures         map[K]failure             // Loader errors remembered by negative caching.
+	writeBehind      *WriteBehind              // Write-behind configuration of a backed cache, nil for write-through.
+	persistPath      string                    // File the cache is periodically saved to, empty when persistence is disabled.
+	persistInterval  time.Duration             // How often the cache is saved to the persistence file.
+	persistence      *persistence              // Background goroutine saving the cache, nil when persistence is disabled.
+	invalidator      Invalidator[K]            // Invalidator broadcasting deletions to other instances, nil when disabled.
+	stopInvalidation context.CancelFunc        // Cancels the subscription to the deletions of other instances.
+	accesses         *accessBuffer[K, V]       // Accesses recorded by buffered Gets, nil when disabled.
+	hasher           Hasher[K]                 // Hasher configured with WithHasher, nil for the default one.
+	keyTransform     func(K) K                 // Function normalizing every key, nil when keys are used as is.
+	clock            Clock                     // Clock configured with WithClock, nil for the system clock.
+	tags             map[string]map[K]struct{} // Keys of the items carrying every tag.
+	displaced        func(key K, value V)      // Receives the items evicted to make room while SetEvicted stores an item.
+	highWatermark    float64                   // Share of the size at which batch evictions start, zero when disabled.
+	lowWatermark     float64                   // Share of the size batch evictions evict down to, zero when disabled.
+	priorities       [3]int                    // Number of items of every priority, from PriorityLow to PriorityHigh.
+	disposer         Disposer[V]               // Function releasing the values which left the cache, nil when disabled.
+	disposed         []V                       // Values waiting to be disposed of once the cache is unlocked.
+	memoryPressure   *MemoryPressure           // Eviction under memory pressure configuration, nil when disabled.
+	pressureDone     chan struct{}             // Channel closed to stop the memory pressure watcher, nil when it is not running.
+	frozen           bool                      // Flag set between Freeze and Thaw, while nothing is evicted or expires.
+	rejectWhenFull   bool                      // Flag rejecting new items when the cache is full instead of evicting.
+	versions         uint64                    // Last version given to a stored value.
+	generation       uint64                    // Current generation, items of older generations are treated as missing.
+	invalidated      int                       // Number of items of older generations waiting to be reclaimed.
+	dependencies     *dependencies[K]          // Dependencies declared with SetWithDeps, nil until the first one.
+	cascade          func(keys []K)            // Removes the dependents of removed items, see SetWithDeps.
+	cascading        []K                       // Dependents waiting to be removed once the cache is unlocked.
+	done             chan struct{}             // Channel closed to stop the background cleaner.
+	closeOnce        sync.Once                 // Guards closing of the done channel.
+	locker                                     // Lock for concurrent access, read-locked by buffered Gets and disabled by NewUnlocked.
 }
 
 // Contains checks if the provided key is present in the LRU cache.
 // It returns true if the key is found in the cache, and false otherwise.
-// The function does not affect the cache's state or modify any data.
+// The function does not affect the cache's state or modify any data,
+// so it only takes the read lock and runs alongside other readers.
 func (l *lru[K, V]) Contains(key K) bool {
-	_, ok := l.cache[key]
-	return ok
+	key = l.canonical(key)
+
+	l.locker.RLock()
+	defer l.locker.RUnlock()
+
+	c, ok := l.cache[key]
+	return ok && !l.expired(c)
+}
+
+// stale reports whether the item's TTL has passed.
+// Items stored without a TTL, pinned items and leased items never go stale, nor does anything while the cache is frozen.
+// Items invalidated by InvalidateAll are always stale.
+func (l *lru[K, V]) stale(c *cache[K, V]) bool {
+	return l.outdated(c) || !l.frozen && !c.ttl.IsZero() && !c.held() && c.ttl.Before(l.now())
+}
+
+// now returns the current time of the clock configured with WithClock, or of the system clock.
+func (l *lru[K, V]) now() time.Time {
+	if l.clock == nil {
+		return time.Now()
+	}
+
+	return l.clock.Now()
+}
+
+// expired reports whether the item's TTL and the stale window configured
+// with WithStaleWhileRevalidate have both passed, so the item must no longer be served.
+// Items stored without a TTL, pinned items and leased items never expire, nor does anything while the cache is frozen.
+// Items invalidated by InvalidateAll are always expired.
+func (l *lru[K, V]) expired(c *cache[K, V]) bool {
+	return l.outdated(c) || !l.frozen && !c.ttl.IsZero() && !c.held() && c.ttl.Add(l.staleWindow).Before(l.now())
+}
+
+// canonical returns the form of the provided key the cache stores, as returned by the function configured
+// with WithKeyTransform, or the key itself when no transform is configured.
+func (l *lru[K, V]) canonical(key K) K {
+	if l.keyTransform == nil {
+		return key
+	}
+
+	return l.keyTransform(key)
+}
+
+// lookup returns the item stored for the provided key, treating expired items as missing.
+// Expired items are removed from the cache on the way, so callers never observe expired data
+// regardless of when the cleaner last ran.
+func (l *lru[K, V]) lookup(key K) (*cache[K, V], bool) {
+	c, ok := l.cache[key]
+	if !ok {
+		return nil, false
+	}
+
+	if l.expired(c) {
+		l.expire(key)
+		return nil, false
+	}
+
+	return c, true
 }
 
 // Set adds or updates a key-value pair in the LRU cache with the provided key and value.
@@ -44,13 +173,38 @@ func (l *lru[K, V]) Contains(key K) bool {
 //
 //	cache.Set("myKey", "myValue")
 func (l *lru[K, V]) Set(key K, value V) {
-	l.Mutex.Lock()
+	key = l.canonical(key)
+
+	l.locker.Lock()
 	defer l.Unlock()
 
 	var expiry time.Time
 	l.set(key, value, expiry)
 }
 
+// SetE adds or updates a key-value pair in the LRU cache like Set, reporting an item which was not stored
+// as an error wrapping ErrFull: a new key while a cache created with WithRejectWhenFull is full,
+// or a value costing more than the maximum cost of the cache.
+//
+// Example usage:
+//
+//	if err := cache.SetE(requestID, ticket); errors.Is(err, lru.ErrFull) {
+//		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+//	}
+func (l *lru[K, V]) SetE(key K, value V) error {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.Unlock()
+
+	var expiry time.Time
+	if !l.set(key, value, expiry) {
+		return ErrFull
+	}
+
+	return nil
+}
+
 // SetWithExpiry adds or updates a key-value pair in the LRU cache with the provided key, value, and time-to-live (TTL).
 // If the key already exists in the cache, its corresponding value and TTL will be updated.
 // If the key is new, a new entry will be created with the provided value and TTL.
@@ -64,101 +218,603 @@ func (l *lru[K, V]) Set(key K, value V) {
 //
 //	cache.SetWithExpiry("myKey", "myValue", 5000) // Sets the value with a TTL of 5 seconds
 func (l *lru[K, V]) SetWithExpiry(key K, value V, ttl int) {
-	l.Mutex.Lock()
+	l.SetWithTTL(key, value, time.Duration(ttl)*time.Millisecond)
+}
+
+// SetWithTTL adds or updates a key-value pair in the LRU cache with the provided key, value, and time-to-live (TTL).
+// It behaves like SetWithExpiry but takes the TTL as a time.Duration.
+//
+// Example usage:
+//
+//	cache.SetWithTTL("myKey", "myValue", 5*time.Second)
+func (l *lru[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.Unlock()
+
+	l.set(key, value, l.now().Add(ttl))
+}
+
+// SetWithDeadline adds or updates a key-value pair in the LRU cache which expires at the provided instant,
+// sparing callers to turn absolute deadlines, such as the expiry claim of a token, into TTLs.
+// Deadlines in the past store an already expired item. A zero deadline stores the item like Set.
+//
+// Example usage:
+//
+//	cache.SetWithDeadline(token.ID, token, claims.ExpiresAt.Time)
+func (l *lru[K, V]) SetWithDeadline(key K, value V, deadline time.Time) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.set(key, value, deadline)
+}
+
+// GetTTL returns the remaining lifetime of the item stored for the provided key, without promoting it.
+// Items stored without a TTL report a zero duration, items served stale within the window configured
+// with WithStaleWhileRevalidate report a negative one.
+// If the key is not found in the cache, or its TTL has passed, zero and boolean false are returned.
+//
+// Example usage:
+//
+//	if ttl, ok := cache.GetTTL("lease"); ok && ttl < time.Minute {
+//		cache.UpdateTTL("lease", 5*time.Minute)
+//	}
+func (l *lru[K, V]) GetTTL(key K) (time.Duration, bool) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	c, ok := l.lookup(key)
+	if !ok {
+		return 0, false
+	}
+
+	if c.ttl.IsZero() {
+		return 0, true
+	}
+
+	return c.ttl.Sub(l.now()), true
+}
+
+// UpdateTTL changes the TTL of the item stored for the provided key to expire the provided duration from now,
+// like SetWithTTL but without rewriting its value, promoting it or notifying listeners.
+// It returns false if the key is not found in the cache, or its TTL has passed.
+//
+// Example usage:
+//
+//	if !cache.UpdateTTL("lease", 30*time.Second) {
+//		// the lease was lost
+//	}
+func (l *lru[K, V]) UpdateTTL(key K, ttl time.Duration) bool {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	c, ok := l.lookup(key)
+	if !ok {
+		return false
+	}
+
+	c.ttl = l.now().Add(ttl)
+	l.track(c)
+
+	return true
+}
+
+// set stores the provided key-value pair with the default or sliding TTL when no expiry is provided,
+// and reports whether it was stored.
+func (l *lru[K, V]) set(key K, value V, expiry time.Time) bool {
+	switch {
+	case !expiry.IsZero():
+	case l.slidingTTL > 0:
+		expiry = l.now().Add(l.slidingTTL)
+	case l.defaultTTL > 0:
+		expiry = l.now().Add(l.defaultTTL)
+	}
+
+	return l.setWithCost(key, value, expiry, l.costOf(value))
+}
+
+// costOf returns the cost of the provided value as reported by the configured sizer,
+// or 1 when no sizer is configured.
+func (l *lru[K, V]) costOf(value V) int64 {
+	if l.sizer == nil {
+		return 1
+	}
+
+	return l.sizer(value)
+}
+
+// SetWithCost adds or updates a key-value pair in the LRU cache with the provided key, value, and cost.
+// If the total cost of the cached items exceeds the maximum cost of the cache,
+// the least recently used items are evicted until it fits.
+// An item whose cost alone exceeds the maximum cost is not stored.
+//
+// Example usage:
+//
+//	cache.SetWithCost("myKey", payload, int64(len(payload)))
+func (l *lru[K, V]) SetWithCost(key K, value V, cost int64) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
 	defer l.Unlock()
 
-	l.set(key, value, time.Now().Add(time.Duration(ttl)*time.Millisecond))
+	var expiry time.Time
+	l.setWithCost(key, value, expiry, cost)
 }
 
-func (l *lru[K, V]) set(key K, value V, expiry time.Time) {
+// setWithCost stores the provided key-value pair with the provided expiry and cost, and reports whether it was stored.
+func (l *lru[K, V]) setWithCost(key K, value V, expiry time.Time, cost int64) bool {
+	delete(l.failures, key)
+
+	// an item invalidated by InvalidateAll is reclaimed, not replaced
+	if c, ok := l.cache[key]; ok && l.outdated(c) {
+		l.remove(key, Deleted)
+	}
+
+	// an item which can never fit is dropped
+	// along with any previous value stored for the key
+	if l.maxCost > 0 && cost > l.maxCost {
+		l.remove(key, Evicted)
+		return false
+	}
+
 	// if the key value already present in the lru
 	// Linked list should be re-ordered
 	// Cache value also should be updated in case of change
 	if c, ok := l.cache[key]; ok {
-		if c != l.head {
-			if c.prev == nil {
-				c.next.prev = nil
-				l.head = c.next
-			} else if c.next == nil {
-				c.prev.next = nil
-				l.tail = c.prev
-			} else {
-				c.next.prev = c.prev
-				c.prev.next = c.next
-			}
-		}
-
-		c.prev = nil
-		c.next = l.head
+		if l.rejectWhenFull && l.maxCost > 0 && l.cost+cost-c.cost > l.maxCost {
+			l.stats.Rejections++
+			return false
+		}
+
+		l.notify(c.key, c.value, Replaced)
+		if !same(c.value, value) {
+			l.dispose(c)
+		}
+		l.release(key, Replaced)
+		l.cost += cost - c.cost
 		c.value = value
-		c.ttl = &expiry
+		c.ttl = expiry
+		c.cost = cost
+		l.versions++
+		c.version = l.versions
+		c.refreshing = false
+		c.updated = l.updateTime()
+		l.untag(c)
+		l.prioritize(c, PriorityNormal)
+		l.moveToFront(c)
+		l.track(c)
+		l.evictOverCost()
+		return true
+	}
 
-		l.head = c
-		l.cache[key] = c
-		return
+	// a cache with negative capacity cannot hold anything
+	if l.size < 0 {
+		return false
+	}
+
+	// a cache rejecting writes when full keeps its items,
+	// it only makes room by removing the expired ones
+	if l.rejectWhenFull && l.full(cost) {
+		l.removeExpired()
+		l.reclaim()
+		if l.full(cost) {
+			l.stats.Rejections++
+			return false
+		}
 	}
 
 	// if lru length tries to exceed the capacity
 	// drop last list/ which is least used cache
-	if l.length >= l.size {
-		l.del(l.tail.key)
+	// an unbounded cache never evicts for capacity
+	l.makeRoom()
+
+	c := l.nodes.alloc()
+	c.key, c.value, c.ttl, c.cost, c.updated = key, value, expiry, cost, l.updateTime()
+	c.created = l.now().UnixNano()
+	l.versions++
+	c.version, c.generation = l.versions, l.generation
+	l.priorities[PriorityNormal-PriorityLow]++
+	l.pushFront(c)
+	l.track(c)
+	l.cache[key] = c
+	l.length++
+	l.cost += cost
+	l.evictOverCost()
+
+	_, ok := l.cache[key]
+	return ok
+}
+
+// replace stores the provided value in place of the value of the provided item, reporting the replaced value
+// to listeners. The item keeps its position, expiry, tags, priority, pin and dependencies, while its dependents
+// are queued for removal. The caller evicts items if the new value costs more than the cache can hold.
+func (l *lru[K, V]) replace(c *cache[K, V], value V) {
+	l.notify(c.key, c.value, Replaced)
+	if !same(c.value, value) {
+		l.dispose(c)
 	}
 
-	c := &cache[K, V]{key: key, value: value, ttl: &expiry, next: l.head, prev: nil}
+	cost := l.costOf(value)
+	l.cost += cost - c.cost
+	c.value, c.cost, c.updated = value, cost, l.updateTime()
+	l.versions++
+	c.version = l.versions
+	l.dropDependents(c.key)
+}
 
-	if l.head == nil {
-		l.tail = c
-	} else {
-		l.head.prev = c
+// full reports whether the cache has no room left for a new item of the provided cost.
+func (l *lru[K, V]) full(cost int64) bool {
+	return (l.size != Unbounded && l.length >= l.size) || (l.maxCost > 0 && l.cost+cost > l.maxCost)
+}
+
+// makeRoom evicts least recently used items when the cache is full, before a new item is added.
+// Once the high watermark is reached, items are evicted down to the low watermark,
+// which is the capacity itself unless watermarks are configured with WithWatermarks.
+func (l *lru[K, V]) makeRoom() {
+	if l.size == Unbounded || l.length < l.highMark() {
+		return
 	}
 
-	l.head = c
-	l.cache[key] = c
-	l.length++
+	low := l.lowMark()
+	for l.length >= low && l.evictOldest() {
+	}
+}
+
+// highMark returns the number of items at which the cache starts evicting.
+func (l *lru[K, V]) highMark() int {
+	if l.highWatermark <= 0 {
+		return l.size
+	}
+
+	return min(max(int(float64(l.size)*l.highWatermark), 1), l.size)
+}
+
+// lowMark returns the number of items the cache evicts down to, before adding the new item.
+func (l *lru[K, V]) lowMark() int {
+	high := l.highMark()
+	if l.lowWatermark <= 0 {
+		return high
+	}
+
+	return min(max(int(float64(l.size)*l.lowWatermark), 1), high)
+}
+
+// evictOverCost drops least recently used items while the total cost exceeds the maximum cost.
+// The most recently used item always fits, as items costing more than the maximum are never stored,
+// unless pinned items take up the room, in which case it is evicted itself.
+func (l *lru[K, V]) evictOverCost() {
+	for l.maxCost > 0 && l.cost > l.maxCost && l.evictOldest() {
+	}
+}
+
+// evictOldest removes the least recently used item which is neither pinned nor leased to make room for other items.
+// It returns false if every item is pinned or leased, or if the cache is frozen.
+func (l *lru[K, V]) evictOldest() bool {
+	if l.frozen {
+		return false
+	}
+
+	l.drainAccesses()
+
+	// items invalidated by InvalidateAll are all at the back of the list, and go first
+	if c := l.back(); c != nil && l.outdated(c) {
+		l.remove(c.key, Deleted)
+		return true
+	}
+
+	c := l.victim()
+	if c == nil {
+		return false
+	}
+
+	if l.displaced != nil {
+		l.displaced(c.key, c.value)
+	}
+	l.demote(c)
+
+	l.stats.Evictions++
+	l.remove(c.key, Evicted)
+
+	return true
+}
+
+// expire removes the item stored for the provided key because its TTL passed,
+// or reclaims it when it was invalidated by InvalidateAll.
+func (l *lru[K, V]) expire(key K) {
+	if c, ok := l.cache[key]; ok && l.outdated(c) {
+		l.remove(key, Deleted)
+		return
+	}
+
+	l.stats.Expirations++
+	l.remove(key, Expired)
+}
+
+// Cost returns the total cost of the items currently stored in the LRU cache.
+func (l *lru[K, V]) Cost() int64 {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	return l.cost
+}
+
+// MaxCost returns the maximum total cost of the items the LRU cache can hold.
+// A maximum cost of zero means the cache is only bounded by its size.
+func (l *lru[K, V]) MaxCost() int64 {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	return l.maxCost
+}
+
+// SetNX adds the provided key-value pair to the LRU cache like Set, provided the key is missing,
+// and reports whether it was stored. Unlike GetOrSet, an existing item is left untouched:
+// it is neither promoted nor counted as a hit, so speculative inserts do not disturb the recency order.
+//
+// Example usage:
+//
+//	if cache.SetNX("myKey", prefetched) {
+//		fmt.Println("Prefetched")
+//	}
+func (l *lru[K, V]) SetNX(key K, value V) bool {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	if _, ok := l.lookup(key); ok {
+		return false
+	}
+
+	var expiry time.Time
+	return l.set(key, value, expiry)
+}
+
+// GetOrSet returns the existing value for the key if present, promoting it like Get.
+// Otherwise, it stores the provided value and returns it.
+// The loaded result is true if the value was loaded, false if it was stored.
+//
+// Example usage:
+//
+//	actual, loaded := cache.GetOrSet("myKey", "myValue")
+func (l *lru[K, V]) GetOrSet(key K, value V) (V, bool) {
+	return l.GetOrCompute(key, func() V { return value })
+}
+
+// GetOrCompute returns the existing value for the key if present, promoting it like Get.
+// Otherwise, it calls fn, stores the result and returns it.
+// The loaded result is true if the value was loaded, false if it was computed.
+//
+// fn runs without holding the cache lock, so it may be slow or use the cache itself.
+// Concurrent callers missing the same key share a single call of fn and all receive its result;
+// only the caller which ran fn gets a false loaded result. If a value is stored for the key while fn runs,
+// that value is kept and returned with a true loaded result instead of the computed one.
+// If fn panics, nothing is stored and the callers sharing its call panic as well.
+func (l *lru[K, V]) GetOrCompute(key K, fn func() V) (V, bool) {
+	key = l.canonical(key)
+
+	if value, ok := l.get(key); ok {
+		return value, true
+	}
+
+	// only the caller running fn stores its result, the callers sharing it load it
+	stored := false
+	value, err := l.computes.do(key, func() (V, error) {
+		if value, ok := l.peek(key); ok {
+			return value, nil
+		}
+
+		value := fn()
+
+		l.locker.Lock()
+		defer l.locker.Unlock()
+
+		// a value stored while fn ran wins over the computed one
+		if c, ok := l.lookup(key); ok {
+			return c.value, nil
+		}
+
+		var expiry time.Time
+		l.set(key, value, expiry)
+		stored = true
+
+		return value, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return value, !stored
 }
 
 // Get retrieves the value associated with the provided key from the LRU cache.
 // If the key exists in the cache, its corresponding value is returned along with a boolean true.
-// If the key is not found in the cache, an empty value and boolean false are returned.
+// If the key is not found in the cache, or its TTL has passed, an empty value and boolean false are returned.
 //
 // The Get operation updates the order of items in the cache to reflect the most recently accessed item.
 // If the item exists, it is moved to the head of the cache to prioritize recently accessed items.
+//
+// If the cache was created with NewLoading, a miss loads the value with the loader and stores it;
+// false is returned if the loader fails.
 func (l *lru[K, V]) Get(key K) (V, bool) {
-	l.Mutex.Lock()
-	defer l.Mutex.Unlock()
+	key = l.canonical(key)
 
-	if c, ok := l.cache[key]; ok {
-		// if it was head do nothing just return value
-		if c.prev == nil {
-			return c.value, true
+	value, ok := l.get(key)
+	if ok || l.loader == nil {
+		return value, ok
+	}
+
+	value, err := l.load(context.Background(), key)
+	return value, err == nil
+}
+
+// GetE retrieves the value associated with the provided key from the LRU cache like Get,
+// reporting a miss as an error which composes with errors.Is: ErrExpired if the TTL of the item has passed,
+// or ErrNotFound if the key is not cached.
+//
+// If the cache was created with NewLoading, a miss loads the value with the loader and stores it;
+// the error returned by the loader is returned if it fails.
+//
+// Example usage:
+//
+//	user, err := cache.GetE(userID)
+//	if errors.Is(err, lru.ErrNotFound) {
+//		user, err = db.User(userID)
+//	}
+func (l *lru[K, V]) GetE(key K) (V, error) {
+	key = l.canonical(key)
+
+	value, err := l.getE(key)
+	if err == nil || l.loader == nil {
+		return value, err
+	}
+
+	return l.load(context.Background(), key)
+}
+
+func (l *lru[K, V]) getE(key K) (V, error) {
+	if l.hotKeys != nil {
+		l.hotKeys.record(key)
+	}
+
+	if l.accesses != nil {
+		if value, ok := l.getBuffered(key); ok {
+			return value, nil
 		}
+	}
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	var emptyVal V
+	c, ok := l.cache[key]
+	if !ok {
+		l.stats.Misses++
+		return emptyVal, ErrNotFound
+	}
+
+	if l.expired(c) {
+		l.expire(key)
+		l.stats.Misses++
+		return emptyVal, ErrExpired
+	}
+
+	l.hit(c)
+	return c.value, nil
+}
 
-		// if it was tail assign last item as tail and move it to head and link to previous head node
-		if c.next == nil {
-			c.prev.next = nil
-			l.tail = c.prev
-			c.prev = nil
-			c.next = l.head
-			l.head.prev = c
-			l.head = c
+func (l *lru[K, V]) get(key K) (V, bool) {
+	if l.hotKeys != nil {
+		l.hotKeys.record(key)
+	}
 
-			return c.value, true
+	if l.accesses != nil {
+		if value, ok := l.getBuffered(key); ok {
+			return value, true
 		}
+	}
 
-		// if it was neither head not tail then link its prev node to next node and move found value to head
-		c.prev.next = c.next
-		c.next.prev = c.prev
-		c.next = l.head
-		c.prev = nil
-		l.head = c
+	l.locker.Lock()
+	defer l.locker.Unlock()
 
+	if c, ok := l.lookup(key); ok {
+		l.hit(c)
 		return c.value, true
 	}
 
+	l.stats.Misses++
+
 	var emptyVal V
 	return emptyVal, false
 }
 
+// hit records a read of the provided item, promoting it to the front of the list,
+// extending its sliding TTL and refreshing its value in the background when it is stale or aging.
+func (l *lru[K, V]) hit(c *cache[K, V]) {
+	l.stats.Hits++
+	c.hits++
+	c.accessed = l.now().UnixNano()
+	l.moveToFront(c)
+	l.slide(c, c.accessed)
+	if l.stale(c) || l.refreshDue(c) {
+		l.revalidate(c)
+	}
+}
+
+// Peek retrieves the value associated with the provided key from the LRU cache
+// without updating the order of items in the cache.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (l *lru[K, V]) Peek(key K) (V, bool) {
+	key = l.canonical(key)
+
+	return l.peek(key)
+}
+
+func (l *lru[K, V]) peek(key K) (V, bool) {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	if c, ok := l.lookup(key); ok {
+		return c.value, true
+	}
+
+	var emptyVal V
+	return emptyVal, false
+}
+
+// GetOldest returns the least recently used key-value pair of the LRU cache without removing or promoting it.
+// Expired and pinned items are skipped, and items of lower priorities come first.
+// If the cache is empty, empty values and boolean false are returned.
+func (l *lru[K, V]) GetOldest() (K, V, bool) {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	if c := l.oldest(); c != nil {
+		return c.key, c.value, true
+	}
+
+	var emptyKey K
+	var emptyVal V
+	return emptyKey, emptyVal, false
+}
+
+// RemoveOldest removes the least recently used key-value pair from the LRU cache and returns it.
+// The removal is reported as an eviction. Expired and pinned items are skipped, and items of lower priorities come first.
+// If the cache is empty, empty values and boolean false are returned.
+func (l *lru[K, V]) RemoveOldest() (K, V, bool) {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	if c := l.oldest(); c != nil {
+		key, value := c.key, c.value
+		l.stats.Evictions++
+		l.remove(key, Evicted)
+		return key, value, true
+	}
+
+	var emptyKey K
+	var emptyVal V
+	return emptyKey, emptyVal, false
+}
+
+// oldest returns the least recently used item which is neither pinned nor expired,
+// removing any expired items found on the way.
+func (l *lru[K, V]) oldest() *cache[K, V] {
+	l.drainAccesses()
+	for c := l.victim(); c != nil && l.expired(c); c = l.victim() {
+		l.expire(c.key)
+	}
+
+	return l.victim()
+}
+
 // Del removes the key-value pair associated with the provided key from the LRU cache.
 // If the key is found and the removal is successful, the function returns true.
 // If the key is not found, it returns false.
@@ -167,37 +823,325 @@ func (l *lru[K, V]) Get(key K) (V, bool) {
 // If the removed item was the head or tail of the list, appropriate adjustments are made.
 // The deleted item's memory is released for garbage collection.
 func (l *lru[K, V]) Del(key K) bool {
-	l.Mutex.Lock()
-	defer l.Unlock()
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	ok := l.del(key)
+	l.locker.Unlock()
 
-	return l.del(key)
+	l.broadcast(key)
+
+	return ok
 }
 
 func (l *lru[K, V]) del(key K) bool {
-	if !l.Contains(key) {
+	if c, ok := l.cache[key]; ok && l.outdated(c) {
+		l.remove(key, Deleted)
+		return false
+	}
+
+	return l.remove(key, Deleted)
+}
+
+// remove deletes the item stored for the provided key and notifies listeners with the provided reason.
+func (l *lru[K, V]) remove(key K, reason Reason) bool {
+	delete(l.failures, key)
+
+	c, ok := l.cache[key]
+	if !ok {
 		return false
 	}
 
-	c := l.cache[key]
-	if c.prev == nil && c.next == nil {
-		l.head = nil
-		l.tail = nil
+	if l.outdated(c) {
+		l.invalidated--
 	} else {
-		if c.prev == nil {
-			c.next.prev = nil
-			l.head = c.next
-		} else if c.next == nil {
-			c.prev.next = nil
-			l.tail = c.prev
-		} else {
-			c.next.prev = c.prev
-			c.prev.next = c.next
-		}
+		l.release(key, reason)
 	}
 
+	l.unlink(c)
+	l.untrack(c)
+	l.untag(c)
+
 	delete(l.cache, key)
 	l.length--
-	c = nil
+	l.cost -= c.cost
+	l.priorities[c.priority-PriorityLow]--
+	if c.pinned {
+		l.stats.Pinned--
+	}
+
+	l.notify(c.key, c.value, reason)
+	l.dispose(c)
+	l.nodes.release(c)
 
 	return true
 }
+
+// Purge removes all key-value pairs from the LRU cache, leaving it empty.
+// If an eviction callback is configured, it is invoked for every removed item.
+// The removed items' memory is released for garbage collection.
+func (l *lru[K, V]) Purge() {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.purge()
+}
+
+func (l *lru[K, V]) purge() {
+	if l.onEvict != nil || l.events != nil || l.disposer != nil {
+		for h := l.front(); h != nil; h = l.next(h) {
+			l.notify(h.key, h.value, Deleted)
+			l.dispose(h)
+		}
+	}
+
+	l.cache = map[K]*cache[K, V]{}
+	l.nodes = newArena[K, V](l.size)
+	l.head = nilIndex
+	l.tail = nilIndex
+	l.length = 0
+	l.cost = 0
+	l.expiries = nil
+	l.failures = nil
+	l.stats.Pinned = 0
+	l.tags = nil
+	l.priorities = [3]int{}
+	l.invalidated = 0
+	if l.dependencies != nil {
+		l.dependencies.reset()
+	}
+}
+
+// Resize changes the maximum number of items the LRU cache can hold.
+// If the new size is smaller than the current number of items,
+// the least recently used items are evicted until the cache fits.
+// Growing the cache keeps every existing item and its order.
+// Resizing to Unbounded removes the capacity limit.
+func (l *lru[K, V]) Resize(size int) {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	for size != Unbounded && l.length > size && l.evictOldest() {
+	}
+
+	l.size = size
+}
+
+// Keys returns a snapshot of the keys in the LRU cache,
+// ordered from the most recently used to the least recently used.
+// The snapshot is taken under the lock, so it is consistent at the time of the call.
+func (l *lru[K, V]) Keys() []K {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	out := make([]K, 0, l.length)
+	for h := l.front(); h != nil; h = l.next(h) {
+		if !l.outdated(h) {
+			out = append(out, h.key)
+		}
+	}
+
+	return out
+}
+
+// Values returns a snapshot of the values in the LRU cache,
+// ordered from the most recently used to the least recently used.
+// The snapshot is taken under the lock, so it is consistent at the time of the call.
+func (l *lru[K, V]) Values() []V {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	out := make([]V, 0, l.length)
+	for h := l.front(); h != nil; h = l.next(h) {
+		if !l.outdated(h) {
+			out = append(out, h.value)
+		}
+	}
+
+	return out
+}
+
+// All returns an iterator over the key-value pairs in the LRU cache,
+// ordered from the most recently used to the least recently used.
+//
+// The iterator ranges over a snapshot taken under the lock when iteration starts,
+// so the loop body may freely use and modify the cache; such changes are not observed by the iteration.
+//
+// Example usage:
+//
+//	for key, value := range cache.All() {
+//		fmt.Println(key, value)
+//	}
+func (l *lru[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		forward(l.items())(yield)
+	}
+}
+
+// Backward returns an iterator over the key-value pairs in the LRU cache,
+// ordered from the least recently used to the most recently used.
+// It has the same consistency semantics as All.
+func (l *lru[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		backward(l.items())(yield)
+	}
+}
+
+// items returns a snapshot of the keys and values in the LRU cache,
+// ordered from the most recently used to the least recently used.
+func (l *lru[K, V]) items() ([]K, []V) {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	keys := make([]K, 0, l.length)
+	values := make([]V, 0, l.length)
+	for h := l.front(); h != nil; h = l.next(h) {
+		if !l.outdated(h) {
+			keys = append(keys, h.key)
+			values = append(values, h.value)
+		}
+	}
+
+	return keys, values
+}
+
+// Close stops any background goroutine owned by the LRU cache, such as the expiry cleaner,
+// the events dispatcher or the demotion worker, and closes the events channel.
+// A cache created with WithPersistence is saved one last time.
+// It is safe to call Close more than once; the cache must not be used after Close.
+func (l *lru[K, V]) Close() {
+	l.closeOnce.Do(func() {
+		if l.persistence != nil {
+			l.persistence.stop(l)
+		}
+		if l.stopInvalidation != nil {
+			l.stopInvalidation()
+		}
+		if l.done != nil {
+			close(l.done)
+		}
+		if l.events != nil {
+			l.events.close()
+		}
+		if l.demoter != nil {
+			l.demoter.close()
+		}
+		if l.pressureDone != nil {
+			close(l.pressureDone)
+		}
+	})
+}
+
+// Events returns the channel on which events are delivered when the cache was created with WithEvents.
+// It returns nil otherwise. The channel is closed when the cache is closed.
+func (l *lru[K, V]) Events() <-chan Event[K, V] {
+	if l.events == nil {
+		return nil
+	}
+
+	return l.events.out
+}
+
+// notify reports that the provided key-value pair left the cache or was replaced
+// to the eviction callback and the events channel, whichever are configured.
+func (l *lru[K, V]) notify(key K, value V, reason Reason) {
+	if l.onEvict != nil {
+		l.onEvict(key, value, reason)
+	}
+
+	if l.events != nil {
+		l.events.publish(Event[K, V]{Key: key, Value: value, Reason: reason, Time: l.now()})
+	}
+}
+
+// Stats returns a snapshot of the usage counters of the LRU cache.
+func (l *lru[K, V]) Stats() Stats {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	stats := l.stats
+	stats.Hits += l.bufferedHits.Load()
+
+	return stats
+}
+
+// front returns the head of the linked list, the most recently used item, or nil if the cache is empty.
+func (l *lru[K, V]) front() *cache[K, V] {
+	return l.nodes.at(l.head)
+}
+
+// back returns the tail of the linked list, the least recently used item, or nil if the cache is empty.
+func (l *lru[K, V]) back() *cache[K, V] {
+	return l.nodes.at(l.tail)
+}
+
+// next returns the item following the provided one in the linked list, or nil at the tail.
+func (l *lru[K, V]) next(c *cache[K, V]) *cache[K, V] {
+	return l.nodes.at(c.next)
+}
+
+// prev returns the item preceding the provided one in the linked list, or nil at the head.
+func (l *lru[K, V]) prev(c *cache[K, V]) *cache[K, V] {
+	return l.nodes.at(c.prev)
+}
+
+// pushFront links the provided item in as the head of the linked list.
+func (l *lru[K, V]) pushFront(c *cache[K, V]) {
+	c.prev = nilIndex
+	c.next = l.head
+
+	if l.head == nilIndex {
+		l.tail = c.slot
+	} else {
+		l.front().prev = c.slot
+	}
+
+	l.head = c.slot
+}
+
+// unlink detaches the provided item from the linked list,
+// moving the head or tail when the item was at either end.
+func (l *lru[K, V]) unlink(c *cache[K, V]) {
+	if c.prev == nilIndex {
+		l.head = c.next
+	} else {
+		l.prev(c).next = c.next
+	}
+
+	if c.next == nilIndex {
+		l.tail = c.prev
+	} else {
+		l.next(c).prev = c.prev
+	}
+
+	c.prev = nilIndex
+	c.next = nilIndex
+}
+
+// moveToFront promotes the provided item to the head of the linked list.
+func (l *lru[K, V]) moveToFront(c *cache[K, V]) {
+	if c.slot == l.head {
+		return
+	}
+
+	l.unlink(c)
+	l.pushFront(c)
+}
+
+// Len returns the number of items currently stored in the LRU cache, pinned items included.
+// Stats reports how many of them are pinned.
+func (l *lru[K, V]) Len() int {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	return l.length - l.invalidated
+}
+
+// Cap returns the maximum number of items the LRU cache can hold.
+// It returns Unbounded if the cache has no capacity limit.
+func (l *lru[K, V]) Cap() int {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	return l.size
+}
@@ -1,26 +1,41 @@
 package lru
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
 
-func listAll[K comparable, V any](in *cache[K, V]) map[K]V {
+func listAll[K comparable, V any](l *lru[K, V]) map[K]V {
 	out := map[K]V{}
 
-	h := in
-	for h.next != nil {
+	for h := l.front(); h != nil; h = l.next(h) {
 		out[h.key] = h.value
-		h = h.next
 	}
-	out[h.key] = h.value
 
 	return out
 }
 
+func listKeys[K comparable, V any](l *lru[K, V]) ([]K, []K) {
+	var forward, backward []K
+
+	for h := l.front(); h != nil; h = l.next(h) {
+		forward = append(forward, h.key)
+	}
+
+	for t := l.back(); t != nil; t = l.prev(t) {
+		backward = append(backward, t.key)
+	}
+
+	return forward, backward
+}
+
 func TestLRU(t *testing.T) {
 	t.Run("LRU without expiry", func(t *testing.T) {
 		t.Run("should return list with number of size items", func(t *testing.T) {
@@ -35,7 +50,7 @@ func TestLRU(t *testing.T) {
 			l.Set(4, 4)
 
 			expected := map[int]int{4: 4, 3: 3, 2: 2}
-			actual := listAll[int, int](l.head)
+			actual := listAll[int, int](l)
 
 			if !reflect.DeepEqual(expected, actual) {
 				t.Errorf("Expected %v; Actual = %v", expected, actual)
@@ -90,23 +105,494 @@ func TestLRU(t *testing.T) {
 			l.Set(4, 4)
 
 			expected := map[int]int{4: 4, 3: 3, 1: 1}
-			actual := listAll[int, int](l.head)
+			actual := listAll[int, int](l)
+
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("Expected %v; Actual = %v", expected, actual)
+			}
+		})
+		t.Run("should return value without promoting it on peek", func(t *testing.T) {
+			l := New[int, int](3)
+
+			l.Set(1, 1)
+			l.Set(2, 2)
+			l.Set(3, 3)
+
+			actual, ok := l.Peek(1)
+			if !reflect.DeepEqual(true, ok) {
+				t.Errorf("Expected true; Actual = %v", ok)
+			}
+
+			if !reflect.DeepEqual(1, actual) {
+				t.Errorf("Expected 1; Actual = %v", actual)
+			}
+
+			l.Set(4, 4)
+
+			if l.Contains(1) {
+				t.Errorf("Expected key 1 to be evicted")
+			}
+		})
+
+		t.Run("should keep list links consistent when promoting items", func(t *testing.T) {
+			l := &lru[int, int]{
+				cache: map[int]*cache[int, int]{},
+				size:  4,
+			}
+
+			l.Set(1, 1)
+			l.Set(2, 2)
+			l.Set(3, 3)
+			l.Set(4, 4)
+			l.Get(2)
+			l.Set(4, 4)
+			l.Get(1)
+
+			forward, backward := listKeys(l)
+
+			expected := []int{1, 4, 2, 3}
+			if !reflect.DeepEqual(expected, forward) {
+				t.Errorf("Expected %v; Actual = %v", expected, forward)
+			}
+
+			expected = []int{3, 2, 4, 1}
+			if !reflect.DeepEqual(expected, backward) {
+				t.Errorf("Expected %v; Actual = %v", expected, backward)
+			}
+		})
+
+		t.Run("should remove every item on purge", func(t *testing.T) {
+			l := New[int, int](3)
+
+			l.Set(1, 1)
+			l.Set(2, 2)
+			l.Purge()
+
+			if !reflect.DeepEqual(0, l.Len()) {
+				t.Errorf("Expected 0; Actual = %v", l.Len())
+			}
+
+			if l.Contains(1) || l.Contains(2) {
+				t.Errorf("Expected cache to be empty after purge")
+			}
+
+			l.Set(3, 3)
+			actual, ok := l.Get(3)
+			if !ok || actual != 3 {
+				t.Errorf("Expected 3; Actual = %v", actual)
+			}
+		})
+
+		t.Run("should evict least recently used items when shrinking", func(t *testing.T) {
+			l := &lru[int, int]{
+				cache: map[int]*cache[int, int]{},
+				size:  4,
+			}
+
+			l.Set(1, 1)
+			l.Set(2, 2)
+			l.Set(3, 3)
+			l.Set(4, 4)
+			l.Get(1)
+			l.Resize(2)
+
+			expected := map[int]int{1: 1, 4: 4}
+			actual := listAll[int, int](l)
 
 			if !reflect.DeepEqual(expected, actual) {
 				t.Errorf("Expected %v; Actual = %v", expected, actual)
 			}
+
+			l.Resize(3)
+			l.Set(5, 5)
+
+			expected = map[int]int{1: 1, 4: 4, 5: 5}
+			actual = listAll[int, int](l)
+
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("Expected %v; Actual = %v", expected, actual)
+			}
+		})
+
+		t.Run("should invoke eviction callback with reason", func(t *testing.T) {
+			evicted := map[int]Reason{}
+			l := New[int, int](2, WithOnEvict(func(key int, value int, reason Reason) {
+				evicted[key] = reason
+			}))
+
+			l.Set(1, 1)
+			l.Set(2, 2)
+			l.Set(3, 3)
+			l.Del(2)
+			l.Set(3, 30)
+
+			expected := map[int]Reason{1: Evicted, 2: Deleted, 3: Replaced}
+			if !reflect.DeepEqual(expected, evicted) {
+				t.Errorf("Expected %v; Actual = %v", expected, evicted)
+			}
+
+			l.Set(4, 4)
+			l.Purge()
+
+			expected = map[int]Reason{1: Evicted, 2: Deleted, 3: Deleted, 4: Deleted}
+			if !reflect.DeepEqual(expected, evicted) {
+				t.Errorf("Expected %v; Actual = %v", expected, evicted)
+			}
+		})
+
+		t.Run("should return keys and values in recency order", func(t *testing.T) {
+			l := New[int, string](3)
+
+			if !reflect.DeepEqual([]int{}, l.Keys()) {
+				t.Errorf("Expected []; Actual = %v", l.Keys())
+			}
+
+			l.Set(1, "one")
+			l.Set(2, "two")
+			l.Set(3, "three")
+			l.Get(1)
+
+			expectedKeys := []int{1, 3, 2}
+			if !reflect.DeepEqual(expectedKeys, l.Keys()) {
+				t.Errorf("Expected %v; Actual = %v", expectedKeys, l.Keys())
+			}
+
+			expectedValues := []string{"one", "three", "two"}
+			if !reflect.DeepEqual(expectedValues, l.Values()) {
+				t.Errorf("Expected %v; Actual = %v", expectedValues, l.Values())
+			}
+		})
+
+		t.Run("should only compute missing values once", func(t *testing.T) {
+			l := New[int, int](3)
+
+			actual, loaded := l.GetOrSet(1, 1)
+			if loaded || actual != 1 {
+				t.Errorf("Expected 1, false; Actual = %v, %v", actual, loaded)
+			}
+
+			actual, loaded = l.GetOrSet(1, 10)
+			if !loaded || actual != 1 {
+				t.Errorf("Expected 1, true; Actual = %v, %v", actual, loaded)
+			}
+
+			var calls int32
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					l.GetOrCompute(2, func() int {
+						atomic.AddInt32(&calls, 1)
+						return 2
+					})
+				}()
+			}
+			wg.Wait()
+
+			if !reflect.DeepEqual(int32(1), calls) {
+				t.Errorf("Expected 1; Actual = %v", calls)
+			}
+		})
+
+		t.Run("should keep values stored while computing", func(t *testing.T) {
+			l := New[int, int](3)
+
+			started, release := make(chan struct{}), make(chan struct{})
+			done := make(chan struct{})
+			var actual int
+			var loaded bool
+			go func() {
+				defer close(done)
+				actual, loaded = l.GetOrCompute(1, func() int {
+					close(started)
+					<-release
+					return 10
+				})
+			}()
+
+			<-started
+			l.Set(1, 5)
+			close(release)
+			<-done
+
+			if !loaded || !reflect.DeepEqual(5, actual) {
+				t.Errorf("Expected 5, true; Actual = %v, %v", actual, loaded)
+			}
+			if value, _ := l.Peek(1); !reflect.DeepEqual(5, value) {
+				t.Errorf("Expected %v; Actual = %v", 5, value)
+			}
+		})
+
+		t.Run("should report a single computation as stored", func(t *testing.T) {
+			l := New[int, int](3)
+
+			var stored int32
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, loaded := l.GetOrCompute(1, func() int { return 1 }); !loaded {
+						atomic.AddInt32(&stored, 1)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if !reflect.DeepEqual(int32(1), stored) {
+				t.Errorf("Expected 1; Actual = %v", stored)
+			}
+		})
+
+		t.Run("should panic in the callers sharing a computation which panicked", func(t *testing.T) {
+			l := New[int, int](3)
+
+			started, release := make(chan struct{}), make(chan struct{})
+			panics := make(chan any, 2)
+			compute := func(fn func() int) {
+				defer func() { panics <- recover() }()
+				l.GetOrCompute(1, fn)
+			}
+
+			go compute(func() int {
+				close(started)
+				<-release
+				panic("boom")
+			})
+			<-started
+
+			waiting := make(chan struct{})
+			go func() {
+				close(waiting)
+				compute(func() int { return 1 })
+			}()
+			<-waiting
+			time.Sleep(10 * time.Millisecond)
+			close(release)
+
+			for i := 0; i < 2; i++ {
+				if recovered := <-panics; recovered == nil {
+					t.Errorf("Expected a panic; Actual = %v", recovered)
+				}
+			}
+			if l.Contains(1) {
+				t.Errorf("Expected %v not to be stored", 1)
+			}
+		})
+
+		t.Run("should only store missing keys with SetNX", func(t *testing.T) {
+			for _, l := range []LRU[int, int]{New[int, int](3), NewSharded[int, int](8, 2), NewTiered[int, int](New[int, int](2), New[int, int](2))} {
+				l.Set(1, 1)
+				l.Set(2, 2)
+
+				if l.SetNX(1, 10) {
+					t.Errorf("Expected %v not to be stored", 1)
+				}
+				if !l.SetNX(3, 3) {
+					t.Errorf("Expected %v to be stored", 3)
+				}
+
+				if value, _ := l.Peek(1); !reflect.DeepEqual(1, value) {
+					t.Errorf("Expected %v; Actual = %v", 1, value)
+				}
+				if !reflect.DeepEqual(uint64(0), l.Stats().Hits) {
+					t.Errorf("Expected no hit; Actual = %v", l.Stats().Hits)
+				}
+			}
+
+			l := New[int, int](3)
+			l.Set(1, 1)
+			l.Set(2, 2)
+			l.SetNX(1, 10)
+			if !reflect.DeepEqual([]int{2, 1}, l.Keys()) {
+				t.Errorf("Expected %v; Actual = %v", []int{2, 1}, l.Keys())
+			}
+		})
+
+		t.Run("should reject negative sizes", func(t *testing.T) {
+			l, err := NewE[int, int](-5)
+			if !errors.Is(err, ErrInvalidSize) || l != nil {
+				t.Errorf("Expected ErrInvalidSize; Actual = %v", err)
+			}
+
+			l, err = NewE[int, int](1)
+			if err != nil || l == nil {
+				t.Errorf("Expected a cache; Actual = %v", err)
+			}
+
+			empty := New[int, int](-5)
+			empty.Set(1, 1)
+			if !reflect.DeepEqual(0, empty.Len()) {
+				t.Errorf("Expected 0; Actual = %v", empty.Len())
+			}
+		})
+
+		t.Run("should never evict for capacity when unbounded", func(t *testing.T) {
+			l, err := NewE[int, int](Unbounded)
+			if err != nil {
+				t.Errorf("Expected no error; Actual = %v", err)
+			}
+
+			for i := 0; i < 100; i++ {
+				l.Set(i, i)
+			}
+
+			if !reflect.DeepEqual(100, l.Len()) {
+				t.Errorf("Expected 100; Actual = %v", l.Len())
+			}
+
+			l.Resize(10)
+			if !reflect.DeepEqual(10, l.Len()) {
+				t.Errorf("Expected 10; Actual = %v", l.Len())
+			}
+		})
+
+		t.Run("should count hits, misses and evictions", func(t *testing.T) {
+			l := New[int, int](2)
+
+			l.Set(1, 1)
+			l.Set(2, 2)
+			l.Get(1)
+			l.Get(3)
+			l.Set(3, 3)
+			l.Del(1)
+
+			expected := Stats{Hits: 1, Misses: 1, Evictions: 1}
+			if !reflect.DeepEqual(expected, l.Stats()) {
+				t.Errorf("Expected %v; Actual = %v", expected, l.Stats())
+			}
+		})
+
+		t.Run("should get and remove the oldest item", func(t *testing.T) {
+			l := New[int, int](3)
+
+			_, _, ok := l.GetOldest()
+			if !reflect.DeepEqual(false, ok) {
+				t.Errorf("Expected false; Actual = %v", ok)
+			}
+
+			l.Set(1, 1)
+			l.Set(2, 2)
+			l.Set(3, 3)
+			l.Get(1)
+
+			key, value, ok := l.GetOldest()
+			if !ok || key != 2 || value != 2 {
+				t.Errorf("Expected 2; Actual = %v", key)
+			}
+
+			key, _, _ = l.RemoveOldest()
+			if key != 2 || l.Contains(2) {
+				t.Errorf("Expected key 2 to be removed; Actual = %v", key)
+			}
+
+			key, _, _ = l.GetOldest()
+			if !reflect.DeepEqual(3, key) {
+				t.Errorf("Expected 3; Actual = %v", key)
+			}
+		})
+
+		t.Run("should iterate in both recency orders", func(t *testing.T) {
+			l := New[int, int](3)
+
+			l.Set(1, 1)
+			l.Set(2, 2)
+			l.Set(3, 3)
+
+			forward := []int{}
+			for key, value := range l.All() {
+				forward = append(forward, key)
+				l.Set(value*10, value*10)
+			}
+
+			expected := []int{3, 2, 1}
+			if !reflect.DeepEqual(expected, forward) {
+				t.Errorf("Expected %v; Actual = %v", expected, forward)
+			}
+
+			backward := []int{}
+			for key := range l.Backward() {
+				backward = append(backward, key)
+				if len(backward) == 2 {
+					break
+				}
+			}
+
+			expected = []int{30, 20}
+			if !reflect.DeepEqual(expected, backward) {
+				t.Errorf("Expected %v; Actual = %v", expected, backward)
+			}
+		})
+
+		t.Run("should report length and capacity", func(t *testing.T) {
+			l := New[int, int](3)
+
+			l.Set(1, 1)
+			l.Set(2, 2)
+
+			if !reflect.DeepEqual(2, l.Len()) {
+				t.Errorf("Expected 2; Actual = %v", l.Len())
+			}
+
+			if !reflect.DeepEqual(3, l.Cap()) {
+				t.Errorf("Expected 3; Actual = %v", l.Cap())
+			}
+
+			l.Set(3, 3)
+			l.Set(4, 4)
+
+			if !reflect.DeepEqual(3, l.Len()) {
+				t.Errorf("Expected 3; Actual = %v", l.Len())
+			}
+		})
+	})
+
+	t.Run("LRU without locking", func(t *testing.T) {
+		t.Run("should behave like a locked cache", func(t *testing.T) {
+			l := NewUnlocked[int, int](2)
+			l.Set(1, 1)
+			l.Set(2, 2)
+			l.Get(1)
+			l.Set(3, 3)
+
+			if !reflect.DeepEqual([]int{3, 1}, l.Keys()) {
+				t.Errorf("Expected %v; Actual = %v", []int{3, 1}, l.Keys())
+			}
+		})
+
+		t.Run("should not take the lock", func(t *testing.T) {
+			l := NewUnlocked[int, int](2).(*lru[int, int])
+			l.mu.Lock()
+			defer l.mu.Unlock()
+
+			l.Set(1, 1)
+			if value, ok := l.Get(1); !ok || value != 1 {
+				t.Errorf("Expected 1; Actual = %v", value)
+			}
 		})
 	})
 
 	t.Run("LRU with expiry", func(t *testing.T) {
 		t.Run("should clean up expired items", func(t *testing.T) {
-			l := NewWithExpiry[int, int](3)
+			clock := NewFakeClock(time.Now())
+			l := NewWithExpiry[int, int](3, WithCleanupInterval[int, int](10*time.Millisecond), WithClock[int, int](clock))
+			defer l.Close()
 
 			l.SetWithExpiry(1, 1, 20000)
-			l.SetWithExpiry(2, 2, 2000)
-			l.SetWithExpiry(3, 3, 2000)
+			l.SetWithExpiry(2, 2, 20)
+			l.SetWithTTL(3, 3, 20*time.Millisecond)
+
+			clock.Advance(100 * time.Millisecond)
+
+			// the cleaner runs in the background once the tick is delivered
+			for deadline := time.Now().Add(time.Second); l.Stats().Expirations < 2 && time.Now().Before(deadline); {
+				time.Sleep(time.Millisecond)
+			}
 
-			time.Sleep(10 * time.Second)
+			if !reflect.DeepEqual(1, l.Len()) {
+				t.Errorf("Expected 1; Actual = %v", l.Len())
+			}
 
 			_, ok := l.Get(1)
 			if !reflect.DeepEqual(true, ok) {
@@ -117,7 +603,363 @@ func TestLRU(t *testing.T) {
 			if !reflect.DeepEqual(false, ok) {
 				t.Errorf("Expected false; Actual = %v", ok)
 			}
+
+			_, ok = l.Get(3)
+			if !reflect.DeepEqual(false, ok) {
+				t.Errorf("Expected false; Actual = %v", ok)
+			}
+		})
+	})
+
+	t.Run("LRU with lazy expiry", func(t *testing.T) {
+		t.Run("should treat expired items as misses before the cleaner runs", func(t *testing.T) {
+			clock := NewFakeClock(time.Now())
+			l := NewWithExpiry[int, int](3, WithClock[int, int](clock))
+			defer l.Close()
+
+			l.SetWithTTL(1, 1, 10*time.Millisecond)
+			l.SetWithTTL(2, 2, time.Minute)
+
+			clock.Advance(20 * time.Millisecond)
+
+			if l.Contains(1) {
+				t.Errorf("Expected key 1 to be expired")
+			}
+
+			_, ok := l.Get(1)
+			if !reflect.DeepEqual(false, ok) {
+				t.Errorf("Expected false; Actual = %v", ok)
+			}
+
+			if !reflect.DeepEqual(1, l.Len()) {
+				t.Errorf("Expected 1; Actual = %v", l.Len())
+			}
+
+			_, ok = l.Peek(2)
+			if !reflect.DeepEqual(true, ok) {
+				t.Errorf("Expected true; Actual = %v", ok)
+			}
+		})
+	})
+
+	t.Run("LRU with default TTL", func(t *testing.T) {
+		t.Run("should apply default TTL to plain sets", func(t *testing.T) {
+			l := NewWithExpiry[int, int](3, WithDefaultTTL[int, int](time.Minute)).(*lru[int, int])
+			defer l.Close()
+
+			l.Set(1, 1)
+			l.SetWithTTL(2, 2, time.Hour)
+
+			remaining := time.Until(l.cache[1].ttl)
+			if remaining <= 0 || remaining > time.Minute {
+				t.Errorf("Expected TTL within a minute; Actual = %v", remaining)
+			}
+
+			remaining = time.Until(l.cache[2].ttl)
+			if remaining <= time.Minute {
+				t.Errorf("Expected TTL of an hour; Actual = %v", remaining)
+			}
+		})
+
+		t.Run("should never expire items without TTL", func(t *testing.T) {
+			l := NewWithExpiry[int, int](3).(*lru[int, int])
+			defer l.Close()
+
+			l.Set(1, 1)
+
+			if !l.cache[1].ttl.IsZero() {
+				t.Errorf("Expected no expiry; Actual = %v", l.cache[1].ttl)
+			}
+		})
+	})
+
+	t.Run("LRU with cost", func(t *testing.T) {
+		t.Run("should evict items until total cost fits", func(t *testing.T) {
+			l := NewWithCost[string, []byte](10, 10)
+
+			l.SetWithCost("a", make([]byte, 4), 4)
+			l.SetWithCost("b", make([]byte, 4), 4)
+			l.Get("a")
+			l.SetWithCost("c", make([]byte, 5), 5)
+
+			expected := []string{"c", "a"}
+			if !reflect.DeepEqual(expected, l.Keys()) {
+				t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+			}
+
+			if !reflect.DeepEqual(int64(9), l.Cost()) {
+				t.Errorf("Expected 9; Actual = %v", l.Cost())
+			}
+		})
+
+		t.Run("should account cost changes on update", func(t *testing.T) {
+			l := NewWithCost[string, int](10, 10)
+
+			l.SetWithCost("a", 1, 3)
+			l.SetWithCost("b", 2, 3)
+			l.SetWithCost("b", 2, 8)
+
+			expected := []string{"b"}
+			if !reflect.DeepEqual(expected, l.Keys()) {
+				t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+			}
+
+			l.Del("b")
+			if !reflect.DeepEqual(int64(0), l.Cost()) {
+				t.Errorf("Expected 0; Actual = %v", l.Cost())
+			}
+		})
+
+		t.Run("should weigh values with the configured sizer", func(t *testing.T) {
+			l := NewWithCost[string, string](10, 10, WithSizer[string](func(v string) int64 {
+				return int64(len(v))
+			}))
+
+			l.Set("a", "aaaa")
+			l.Set("b", "bbbb")
+			l.Set("c", "cccc")
+
+			expected := []string{"c", "b"}
+			if !reflect.DeepEqual(expected, l.Keys()) {
+				t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+			}
+
+			if !reflect.DeepEqual(int64(8), l.Cost()) {
+				t.Errorf("Expected 8; Actual = %v", l.Cost())
+			}
 		})
+
+		t.Run("should not store items costing more than the maximum", func(t *testing.T) {
+			l := NewWithCost[string, int](10, 10)
+
+			l.SetWithCost("a", 1, 3)
+			l.SetWithCost("a", 1, 11)
+
+			if l.Contains("a") {
+				t.Errorf("Expected key a to be dropped")
+			}
+
+			if !reflect.DeepEqual(int64(0), l.Cost()) {
+				t.Errorf("Expected 0; Actual = %v", l.Cost())
+			}
+		})
+	})
+
+	t.Run("LRU with watermarks", func(t *testing.T) {
+		t.Run("should evict down to the low watermark", func(t *testing.T) {
+			l := New[int, int](10, WithWatermarks[int, int](1, 0.5))
+			for i := range 10 {
+				l.Set(i, i)
+			}
+
+			l.Set(10, 10)
+			if !reflect.DeepEqual(5, l.Len()) {
+				t.Errorf("Expected %v; Actual = %v", 5, l.Len())
+			}
+			if !reflect.DeepEqual([]int{10, 9, 8, 7, 6}, l.Keys()) {
+				t.Errorf("Expected %v; Actual = %v", []int{10, 9, 8, 7, 6}, l.Keys())
+			}
+			if !reflect.DeepEqual(uint64(6), l.Stats().Evictions) {
+				t.Errorf("Expected %v; Actual = %v", 6, l.Stats().Evictions)
+			}
+
+			for i := 11; i < 16; i++ {
+				l.Set(i, i)
+			}
+			if !reflect.DeepEqual(uint64(6), l.Stats().Evictions) {
+				t.Errorf("Expected no more evictions; Actual = %v", l.Stats().Evictions)
+			}
+		})
+
+		t.Run("should start evicting at the high watermark", func(t *testing.T) {
+			l := New[int, int](10, WithWatermarks[int, int](0.8, 0.6))
+			for i := range 9 {
+				l.Set(i, i)
+			}
+
+			if !reflect.DeepEqual(6, l.Len()) {
+				t.Errorf("Expected %v; Actual = %v", 6, l.Len())
+			}
+		})
+
+		t.Run("should follow the size on resize", func(t *testing.T) {
+			l := New[int, int](10, WithWatermarks[int, int](1, 0.5))
+			l.Resize(4)
+			for i := range 5 {
+				l.Set(i, i)
+			}
+
+			if !reflect.DeepEqual([]int{4, 3}, l.Keys()) {
+				t.Errorf("Expected %v; Actual = %v", []int{4, 3}, l.Keys())
+			}
+		})
+	})
+
+	t.Run("LRU with errors", func(t *testing.T) {
+		t.Run("should return the value of a cached key", func(t *testing.T) {
+			l := New[int, int](2)
+			l.Set(1, 1)
+
+			value, err := l.GetE(1)
+			if err != nil || !reflect.DeepEqual(1, value) {
+				t.Errorf("Expected %v; Actual = %v %v", 1, value, err)
+			}
+		})
+
+		t.Run("should report missing and expired keys", func(t *testing.T) {
+			clock := NewFakeClock(time.Now())
+			l := NewWithExpiry[int, int](2, WithClock[int, int](clock))
+			defer l.Close()
+
+			l.SetWithTTL(1, 1, time.Minute)
+			clock.Advance(time.Hour)
+
+			if _, err := l.GetE(1); !errors.Is(err, ErrExpired) {
+				t.Errorf("Expected %v; Actual = %v", ErrExpired, err)
+			}
+			if _, err := l.GetE(1); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Expected %v; Actual = %v", ErrNotFound, err)
+			}
+			if !reflect.DeepEqual(uint64(2), l.Stats().Misses) {
+				t.Errorf("Expected %v; Actual = %v", 2, l.Stats().Misses)
+			}
+		})
+
+		t.Run("should return loader errors", func(t *testing.T) {
+			errLoad := errors.New("load failed")
+			l := NewLoading[int, int](2, func(_ context.Context, key int) (int, error) {
+				if key < 0 {
+					return 0, errLoad
+				}
+				return key * 10, nil
+			})
+
+			if value, err := l.GetE(2); err != nil || !reflect.DeepEqual(20, value) {
+				t.Errorf("Expected %v; Actual = %v %v", 20, value, err)
+			}
+			if _, err := l.GetE(-1); !errors.Is(err, errLoad) {
+				t.Errorf("Expected %v; Actual = %v", errLoad, err)
+			}
+		})
+
+		t.Run("should look up both tiers", func(t *testing.T) {
+			l := NewTiered[int, int](New[int, int](1), New[int, int](1))
+			defer l.Close()
+
+			l.Set(1, 1)
+			l.Set(2, 2)
+
+			if value, err := l.GetE(1); err != nil || !reflect.DeepEqual(1, value) {
+				t.Errorf("Expected %v; Actual = %v %v", 1, value, err)
+			}
+			if _, err := l.GetE(3); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Expected %v; Actual = %v", ErrNotFound, err)
+			}
+		})
+	})
+
+	t.Run("LRU rejecting writes when full", func(t *testing.T) {
+		t.Run("should reject new keys instead of evicting", func(t *testing.T) {
+			l := New[int, int](2, WithRejectWhenFull[int, int]())
+			l.Set(1, 1)
+			l.Set(2, 2)
+			l.Set(3, 3)
+
+			if err := l.SetE(4, 4); !errors.Is(err, ErrFull) {
+				t.Errorf("Expected %v; Actual = %v", ErrFull, err)
+			}
+			if err := l.SetE(1, 10); err != nil {
+				t.Errorf("Expected %v; Actual = %v", nil, err)
+			}
+			if !reflect.DeepEqual([]int{1, 2}, l.Keys()) {
+				t.Errorf("Expected %v; Actual = %v", []int{1, 2}, l.Keys())
+			}
+			if stats := l.Stats(); !reflect.DeepEqual([]uint64{2, 0}, []uint64{stats.Rejections, stats.Evictions}) {
+				t.Errorf("Expected %v; Actual = %v", []uint64{2, 0}, []uint64{stats.Rejections, stats.Evictions})
+			}
+
+			l.Del(2)
+			if err := l.SetE(4, 4); err != nil {
+				t.Errorf("Expected %v; Actual = %v", nil, err)
+			}
+		})
+
+		t.Run("should make room by removing expired items", func(t *testing.T) {
+			clock := NewFakeClock(time.Now())
+			l := NewWithExpiry[int, int](1, WithClock[int, int](clock), WithRejectWhenFull[int, int]())
+			defer l.Close()
+
+			l.SetWithTTL(1, 1, time.Minute)
+			if err := l.SetE(2, 2); !errors.Is(err, ErrFull) {
+				t.Errorf("Expected %v; Actual = %v", ErrFull, err)
+			}
+
+			clock.Advance(time.Hour)
+			if err := l.SetE(2, 2); err != nil {
+				t.Errorf("Expected %v; Actual = %v", nil, err)
+			}
+		})
+
+		t.Run("should reject items over the maximum cost", func(t *testing.T) {
+			l := NewWithCost[int, int](10, 5, WithRejectWhenFull[int, int]())
+			l.SetWithCost(1, 1, 3)
+			l.SetWithCost(2, 2, 3)
+
+			if !reflect.DeepEqual([]int{1}, l.Keys()) || !reflect.DeepEqual(int64(3), l.Cost()) {
+				t.Errorf("Expected %v; Actual = %v", []int{1}, l.Keys())
+			}
+		})
+	})
+
+	t.Run("LRU with key transform", func(t *testing.T) {
+		t.Run("should normalize keys on every operation", func(t *testing.T) {
+			l := New[string, int](2, WithKeyTransform[string, int](strings.ToLower))
+			l.Set("Alice", 1)
+			l.SetMany(map[string]int{"BOB": 2})
+
+			if value, ok := l.Get("ALICE"); !ok || !reflect.DeepEqual(1, value) {
+				t.Errorf("Expected %v; Actual = %v %v", 1, value, ok)
+			}
+			if !reflect.DeepEqual(map[string]int{"Bob": 2}, l.GetMany([]string{"Bob"})) {
+				t.Errorf("Expected %v; Actual = %v", map[string]int{"Bob": 2}, l.GetMany([]string{"Bob"}))
+			}
+			if !reflect.DeepEqual([]string{"bob", "alice"}, l.Keys()) {
+				t.Errorf("Expected %v; Actual = %v", []string{"bob", "alice"}, l.Keys())
+			}
+
+			if !l.Del("aLiCe") || l.Contains("alice") {
+				t.Errorf("Expected %v to be deleted", "alice")
+			}
+		})
+
+		t.Run("should route normalized keys to one shard", func(t *testing.T) {
+			l := NewSharded[string, int](64, 8, WithKeyTransform[string, int](strings.TrimSpace))
+			defer l.Close()
+
+			for i := 0; i < 16; i++ {
+				l.Set(fmt.Sprintf(" %d ", i), i)
+			}
+			for i := 0; i < 16; i++ {
+				if value, ok := l.Get(fmt.Sprintf("%d", i)); !ok || !reflect.DeepEqual(i, value) {
+					t.Errorf("Expected %v; Actual = %v %v", i, value, ok)
+				}
+			}
+		})
+	})
+
+	t.Run("should stop cleaner on close", func(t *testing.T) {
+		l := NewWithExpiry[int, int](3).(*lru[int, int])
+
+		l.Close()
+		l.Close()
+
+		select {
+		case <-l.done:
+		default:
+			t.Errorf("Expected cleaner to be signalled to stop")
+		}
+
+		New[int, int](3).Close()
 	})
 
 	t.Run("should handle concurrency", func(t *testing.T) {
@@ -0,0 +1,86 @@
+package lru
+
+// EvictionCandidates returns copies of up to n items the LRU cache would evict next, in eviction order,
+// without removing or promoting them: the least recently used items of the lowest priority come first,
+// while pinned, leased and expired items are left out, as they are never evicted for room.
+// It helps to plan capacity or to debug what a burst of writes would push out of the cache.
+//
+// Example usage:
+//
+//	for _, e := range cache.EvictionCandidates(10) {
+//		fmt.Println(e.Key, e.LastAccessedAt)
+//	}
+func (l *lru[K, V]) EvictionCandidates(n int) []Entry[K, V] {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.drainAccesses()
+
+	out := make([]Entry[K, V], 0, min(max(n, 0), l.length))
+	for p := PriorityLow; p <= PriorityHigh && len(out) < n; p++ {
+		if l.priorities[p-PriorityLow] == 0 {
+			continue
+		}
+
+		for c := l.back(); c != nil && len(out) < n; c = l.prev(c) {
+			if c.priority == p && !c.held() && !l.stale(c) {
+				out = append(out, c.entry())
+			}
+		}
+	}
+
+	return out
+}
+
+// EvictionCandidates returns copies of up to n items the shards would evict next, taking the next candidate
+// of every shard in turn, as each shard evicts its own items independently.
+func (s *sharded[K, V]) EvictionCandidates(n int) []Entry[K, V] {
+	candidates := make([][]Entry[K, V], len(s.shards))
+	for i, sh := range s.shards {
+		candidates[i] = sh.EvictionCandidates(n)
+	}
+
+	out := make([]Entry[K, V], 0, max(n, 0))
+	for i := 0; len(out) < n; i++ {
+		added := false
+		for _, c := range candidates {
+			if i < len(c) && len(out) < n {
+				out = append(out, c[i])
+				added = true
+			}
+		}
+
+		if !added {
+			break
+		}
+	}
+
+	return out
+}
+
+// EvictionCandidates returns copies of up to n items the tiered cache would drop next:
+// the oldest items of the second tier, followed by the candidates of the first tier, which are demoted first.
+// Only the key and the value are set for items of a second tier which provides no metadata.
+func (t *tiered[K, V]) EvictionCandidates(n int) []Entry[K, V] {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	var out []Entry[K, V]
+	if c, ok := t.cold.(interface{ EvictionCandidates(n int) []Entry[K, V] }); ok {
+		out = c.EvictionCandidates(n)
+	} else {
+		out = make([]Entry[K, V], 0, max(n, 0))
+		for key, value := range t.cold.Backward() {
+			if len(out) >= n {
+				break
+			}
+			out = append(out, Entry[K, V]{Key: key, Value: value})
+		}
+	}
+
+	if len(out) < n {
+		out = append(out, t.hot.EvictionCandidates(n-len(out))...)
+	}
+
+	return out
+}
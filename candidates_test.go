@@ -0,0 +1,79 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func keysOf[K comparable, V any](entries []Entry[K, V]) []K {
+	out := []K{}
+	for _, e := range entries {
+		out = append(out, e.Key)
+	}
+
+	return out
+}
+
+func TestEvictionCandidates(t *testing.T) {
+	t.Run("should list the items evicted next without removing them", func(t *testing.T) {
+		l := New[int, int](5)
+		for key := range 5 {
+			l.Set(key, key)
+		}
+		l.Get(0)
+		l.Pin(1)
+		l.SetWithPriority(2, 2, PriorityHigh)
+		l.SetWithPriority(3, 3, PriorityLow)
+
+		if !reflect.DeepEqual([]int{3, 4, 0, 2}, keysOf(l.EvictionCandidates(10))) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 4, 0, 2}, keysOf(l.EvictionCandidates(10)))
+		}
+		if !reflect.DeepEqual([]int{3, 4}, keysOf(l.EvictionCandidates(2))) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 4}, keysOf(l.EvictionCandidates(2)))
+		}
+		if !reflect.DeepEqual(5, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 5, l.Len())
+		}
+
+		l.Set(5, 5)
+		if l.Contains(3) {
+			t.Errorf("Expected key 3 to be evicted first")
+		}
+	})
+
+	t.Run("should return nothing for non-positive counts", func(t *testing.T) {
+		l := New[int, int](2)
+		l.Set(1, 1)
+
+		if !reflect.DeepEqual(0, len(l.EvictionCandidates(0))) {
+			t.Errorf("Expected %v; Actual = %v", 0, len(l.EvictionCandidates(0)))
+		}
+	})
+
+	t.Run("should take the candidates of every shard in turn", func(t *testing.T) {
+		l := NewSharded[int, int](100, 4)
+		for key := range 20 {
+			l.Set(key, key)
+		}
+
+		if !reflect.DeepEqual(8, len(l.EvictionCandidates(8))) {
+			t.Errorf("Expected %v; Actual = %v", 8, len(l.EvictionCandidates(8)))
+		}
+		if !reflect.DeepEqual(20, len(l.EvictionCandidates(50))) {
+			t.Errorf("Expected %v; Actual = %v", 20, len(l.EvictionCandidates(50)))
+		}
+	})
+
+	t.Run("should list the second tier first", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](2), New[int, int](2))
+		defer l.Close()
+
+		for key := range 4 {
+			l.Set(key, key)
+		}
+
+		if !reflect.DeepEqual([]int{0, 1, 2}, keysOf(l.EvictionCandidates(3))) {
+			t.Errorf("Expected %v; Actual = %v", []int{0, 1, 2}, keysOf(l.EvictionCandidates(3)))
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package lru
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the time to a cache, which uses it to stamp and expire items and to schedule its cleaner.
+// It defaults to the system clock; tests can substitute a FakeClock with WithClock to drive expiry deterministically.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTicker returns a Ticker delivering the time on its channel every period.
+	NewTicker(period time.Duration) Ticker
+}
+
+// Ticker delivers ticks of a Clock at intervals, like time.Ticker.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker. No more ticks are delivered once it returns.
+	Stop()
+}
+
+// systemClock is the Clock of the operating system.
+type systemClock struct{}
+
+// Now returns time.Now().
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTicker returns a ticker wrapping time.NewTicker.
+func (systemClock) NewTicker(period time.Duration) Ticker {
+	return systemTicker{time.NewTicker(period)}
+}
+
+// systemTicker adapts a time.Ticker to the Ticker interface.
+type systemTicker struct {
+	*time.Ticker
+}
+
+// C returns the channel of the wrapped ticker.
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// FakeClock is a Clock whose time only moves when it is advanced, so expiry can be tested without sleeping.
+// It is safe for concurrent use.
+//
+// Example usage:
+//
+//	clock := lru.NewFakeClock(time.Now())
+//	cache := lru.NewWithExpiry[string, string](10, lru.WithClock[string, string](clock))
+//	cache.SetWithTTL("key", "value", time.Minute)
+//	clock.Advance(2 * time.Minute) // "key" is now expired
+type FakeClock struct {
+	now        time.Time     // Current time of the clock.
+	tickers    []*fakeTicker // Tickers created by the clock which were not stopped.
+	sync.Mutex               // Mutex for concurrent access.
+}
+
+// NewFakeClock creates a FakeClock set to the provided time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	return c.now
+}
+
+// NewTicker returns a Ticker delivering a tick every time the clock is advanced past the next multiple of the period.
+func (c *FakeClock) NewTicker(period time.Duration) Ticker {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	t := &fakeTicker{c: make(chan time.Time, 1), period: period, next: c.now.Add(period), clock: c}
+	c.tickers = append(c.tickers, t)
+
+	return t
+}
+
+// Advance moves the clock forward by the provided duration, ticking every ticker which became due.
+// Like time.Ticker, a ticker delivers a single tick however many periods passed,
+// and drops ticks its receiver is not ready for.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		if t.next.After(c.now) {
+			continue
+		}
+
+		select {
+		case t.c <- c.now:
+		default:
+		}
+
+		for !t.next.After(c.now) {
+			t.next = t.next.Add(t.period)
+		}
+	}
+}
+
+// fakeTicker is a Ticker of a FakeClock.
+type fakeTicker struct {
+	c      chan time.Time // Channel the ticks are delivered on.
+	period time.Duration  // Time between two ticks.
+	next   time.Time      // Time of the next tick.
+	clock  *FakeClock     // Clock which created the ticker.
+}
+
+// C returns the channel on which the ticks are delivered.
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+// Stop removes the ticker from its clock.
+func (t *fakeTicker) Stop() {
+	t.clock.Mutex.Lock()
+	defer t.clock.Mutex.Unlock()
+
+	for i, other := range t.clock.tickers {
+		if other == t {
+			t.clock.tickers = append(t.clock.tickers[:i], t.clock.tickers[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	t.Run("should only move when advanced", func(t *testing.T) {
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := NewFakeClock(start)
+
+		if !reflect.DeepEqual(start, clock.Now()) {
+			t.Errorf("Expected %v; Actual = %v", start, clock.Now())
+		}
+
+		clock.Advance(time.Hour)
+		if !reflect.DeepEqual(start.Add(time.Hour), clock.Now()) {
+			t.Errorf("Expected %v; Actual = %v", start.Add(time.Hour), clock.Now())
+		}
+	})
+
+	t.Run("should tick once per advance past the period", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		ticker := clock.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		clock.Advance(30 * time.Second)
+		select {
+		case <-ticker.C():
+			t.Errorf("Expected no tick before the period")
+		default:
+		}
+
+		clock.Advance(5 * time.Minute)
+		select {
+		case now := <-ticker.C():
+			if !reflect.DeepEqual(clock.Now(), now) {
+				t.Errorf("Expected %v; Actual = %v", clock.Now(), now)
+			}
+		default:
+			t.Errorf("Expected a tick")
+		}
+
+		select {
+		case <-ticker.C():
+			t.Errorf("Expected a single tick")
+		default:
+		}
+	})
+
+	t.Run("should not tick once stopped", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		ticker := clock.NewTicker(time.Second)
+		ticker.Stop()
+
+		clock.Advance(time.Minute)
+		select {
+		case <-ticker.C():
+			t.Errorf("Expected no tick")
+		default:
+		}
+	})
+
+	t.Run("should drive expiry of a cache", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		l := NewWithExpiry[int, int](3, WithClock[int, int](clock))
+		defer l.Close()
+
+		l.SetWithTTL(1, 1, time.Hour)
+		clock.Advance(59 * time.Minute)
+
+		if ttl, ok := l.GetTTL(1); !ok || ttl != time.Minute {
+			t.Errorf("Expected %v; Actual = %v", time.Minute, ttl)
+		}
+
+		clock.Advance(time.Minute + time.Nanosecond)
+		if _, ok := l.Get(1); ok {
+			t.Errorf("Expected key 1 to expire")
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package lru
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Codec serializes values into bytes and back, for caches storing their values encoded.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec is a Codec encoding values with encoding/json.
+type JSONCodec struct{}
+
+// Marshal returns the JSON encoding of v.
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes the JSON encoded data into v.
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// GobCodec is a Codec encoding values with encoding/gob, which handles types JSON cannot, such as maps with non-string keys,
+// but needs the concrete types stored in interfaces to be registered with gob.Register.
+type GobCodec struct{}
+
+// Marshal returns the gob encoding of v.
+func (GobCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes the gob encoded data into v.
+func (GobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
@@ -0,0 +1,109 @@
+package lru
+
+import "time"
+
+// Compute atomically updates the value stored for the provided key with the provided remapping function,
+// which runs while the LRU cache is locked, so concurrent updates of the key are never lost.
+// The function receives the current value and whether the key is present, and returns the new value
+// along with whether to keep it: the new value is then stored and promoted like Set,
+// otherwise the key is removed like Del, or left missing if it was not present.
+//
+// An existing item is updated in place: it keeps its expiry, tags, priority, pin and dependencies,
+// while its dependents are removed like when it is updated with Set. Compute returns the stored value and true,
+// or an empty value and false if the key was removed or left missing.
+// The function must not call back into the same cache.
+//
+// Example usage:
+//
+//	hits, _ := cache.Compute(path, func(old int, _ bool) (int, bool) {
+//		return old + 1, true
+//	})
+func (l *lru[K, V]) Compute(key K, fn func(old V, exists bool) (V, bool)) (V, bool) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+
+	value, ok, deleted := l.compute(key, fn)
+
+	l.locker.Unlock()
+
+	if deleted {
+		l.broadcast(key)
+	}
+
+	return value, ok
+}
+
+// compute applies the remapping function of Compute under the lock.
+// It also reports whether an existing item was removed, so the deletion can be broadcast once unlocked.
+func (l *lru[K, V]) compute(key K, fn func(old V, exists bool) (V, bool)) (V, bool, bool) {
+	var old V
+
+	c, exists := l.lookup(key)
+	if exists {
+		old = c.value
+	}
+
+	value, keep := fn(old, exists)
+	if !keep {
+		var emptyVal V
+		return emptyVal, false, exists && l.del(key)
+	}
+
+	if !exists {
+		var expiry time.Time
+		if !l.set(key, value, expiry) {
+			var emptyVal V
+			return emptyVal, false, false
+		}
+
+		return value, true, false
+	}
+
+	// an existing item is updated in place, keeping its metadata
+	l.replace(c, value)
+	l.moveToFront(c)
+	l.evictOverCost()
+	if l.cache[key] != c {
+		var emptyVal V
+		return emptyVal, false, false
+	}
+
+	return value, true, false
+}
+
+// Compute atomically updates the value stored for the provided key in the shard responsible for it.
+func (s *sharded[K, V]) Compute(key K, fn func(old V, exists bool) (V, bool)) (V, bool) {
+	return s.shard(key).Compute(key, fn)
+}
+
+// Compute atomically updates the value stored for the provided key in either tier with the provided remapping function,
+// which runs while the tiered cache is locked. An item of the first tier is updated in place like the Compute
+// of an LRU cache. An item of the second tier is promoted: a kept value is stored in the first tier like Set,
+// along with the tags and the remaining TTL of the item. Otherwise the key is removed from both tiers.
+func (t *tiered[K, V]) Compute(key K, fn func(old V, exists bool) (V, bool)) (V, bool) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	if t.hot.Contains(key) {
+		return t.hot.Compute(key, fn)
+	}
+
+	old, exists := t.cold.Peek(key)
+	tags, ttl := t.coldMeta(key)
+
+	value, keep := fn(old, exists)
+	if !keep {
+		if exists {
+			t.hot.Del(key)
+			t.cold.Del(key)
+		}
+
+		var emptyVal V
+		return emptyVal, false
+	}
+
+	t.store(key, value, tags, ttl)
+
+	return value, true
+}
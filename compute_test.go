@@ -0,0 +1,139 @@
+package lru
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCompute(t *testing.T) {
+	increment := func(old int, _ bool) (int, bool) {
+		return old + 1, true
+	}
+
+	caches := map[string]func() LRU[string, int]{
+		"lru":     func() LRU[string, int] { return New[string, int](10) },
+		"sharded": func() LRU[string, int] { return NewSharded[string, int](100, 4) },
+		"tiered":  func() LRU[string, int] { return NewTiered[string, int](New[string, int](2), New[string, int](10)) },
+	}
+
+	for name, newCache := range caches {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should store the computed value", func(t *testing.T) {
+				l := newCache()
+				defer l.Close()
+
+				value, ok := l.Compute("a", func(old int, exists bool) (int, bool) {
+					if exists {
+						t.Errorf("Expected key a to be missing")
+					}
+					return 1, true
+				})
+				if !ok || !reflect.DeepEqual(1, value) {
+					t.Errorf("Expected %v; Actual = %v", 1, value)
+				}
+
+				value, _ = l.Compute("a", increment)
+				if !reflect.DeepEqual(2, value) {
+					t.Errorf("Expected %v; Actual = %v", 2, value)
+				}
+
+				value, _ = l.Get("a")
+				if !reflect.DeepEqual(2, value) {
+					t.Errorf("Expected %v; Actual = %v", 2, value)
+				}
+			})
+
+			t.Run("should delete the entry when the value is not kept", func(t *testing.T) {
+				l := newCache()
+				defer l.Close()
+
+				l.Set("a", 1)
+				value, ok := l.Compute("a", func(int, bool) (int, bool) {
+					return 0, false
+				})
+				if ok || !reflect.DeepEqual(0, value) {
+					t.Errorf("Expected %v; Actual = %v", 0, value)
+				}
+				if l.Contains("a") {
+					t.Errorf("Expected key a to be removed")
+				}
+
+				l.Compute("b", func(int, bool) (int, bool) {
+					return 1, false
+				})
+				if l.Contains("b") {
+					t.Errorf("Expected key b to be missing")
+				}
+			})
+
+			t.Run("should not lose concurrent updates", func(t *testing.T) {
+				l := newCache()
+				defer l.Close()
+
+				var wg sync.WaitGroup
+				for range 50 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						l.Compute("counter", increment)
+					}()
+				}
+				wg.Wait()
+
+				value, _ := l.Peek("counter")
+				if !reflect.DeepEqual(50, value) {
+					t.Errorf("Expected %v; Actual = %v", 50, value)
+				}
+			})
+		})
+	}
+
+	t.Run("should keep the expiry and tags of the existing item", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		l := NewWithExpiry[string, int](10, WithClock[string, int](clock))
+		defer l.Close()
+
+		l.SetWithTTL("a", 1, time.Minute)
+		l.SetWithTags("b", 1, "t")
+
+		clock.Advance(30 * time.Second)
+		l.Compute("a", increment)
+		l.Compute("b", increment)
+
+		ttl, _ := l.GetTTL("a")
+		if !reflect.DeepEqual(30*time.Second, ttl) {
+			t.Errorf("Expected %v; Actual = %v", 30*time.Second, ttl)
+		}
+		if removed := l.InvalidateTag("t"); !reflect.DeepEqual(1, removed) {
+			t.Errorf("Expected %v; Actual = %v", 1, removed)
+		}
+	})
+
+	t.Run("should keep the priority and dependencies of the existing item", func(t *testing.T) {
+		l := New[string, int](2)
+		l.SetWithPriority("a", 1, PriorityHigh)
+		l.Set("b", 1)
+
+		l.Compute("a", increment)
+		l.Get("b")
+		l.Set("c", 1)
+
+		if !l.Contains("a") || l.Contains("b") {
+			t.Errorf("Expected %v to be evicted; Actual = %v", "b", l.Keys())
+		}
+
+		l = New[string, int](2)
+		l.Set("order", 1)
+		l.SetWithDeps("summary", 1, "order")
+		l.Compute("summary", increment)
+
+		if !l.Contains("summary") {
+			t.Errorf("Expected %v to be kept", "summary")
+		}
+		if l.Compute("order", increment); l.Contains("summary") {
+			t.Errorf("Expected %v to be removed along with its updated dependency", "summary")
+		}
+	})
+}
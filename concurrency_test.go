@@ -0,0 +1,109 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrency(t *testing.T) {
+	caches := map[string]func() Cache[int, int]{
+		"LRU":             func() Cache[int, int] { return New[int, int](16) },
+		"LRU with expiry": func() Cache[int, int] { return NewWithExpiry[int, int](16) },
+		"LRU with cost":   func() Cache[int, int] { return NewWithCost[int, int](16, 8) },
+		"sharded LRU":     func() Cache[int, int] { return NewSharded[int, int](16, 4) },
+		"LFU":             func() Cache[int, int] { return NewLFU[int, int](16) },
+		"2Q":              func() Cache[int, int] { return NewTwoQueue[int, int](16, DefaultRecentRatio, DefaultGhostRatio) },
+		"tiered":          func() Cache[int, int] { return NewTiered[int, int](New[int, int](8), New[int, int](8)) },
+	}
+
+	for name, newCache := range caches {
+		t.Run("should handle concurrent mutations of the "+name+" cache", func(t *testing.T) {
+			cache := newCache()
+			defer cache.Close()
+
+			var wg sync.WaitGroup
+			for g := 0; g < 8; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+
+					for i := 0; i < 200; i++ {
+						key := (g*200 + i) % 32
+						switch i % 8 {
+						case 0, 1, 2:
+							cache.Set(key, i)
+						case 3, 4:
+							cache.Get(key)
+						case 5:
+							cache.Del(key)
+						case 6:
+							cache.Peek(key)
+							cache.Contains(key)
+							cache.Keys()
+						case 7:
+							for range cache.All() {
+							}
+							cache.Len()
+							cache.Stats()
+						}
+					}
+
+					if g == 0 {
+						cache.Resize(8)
+						cache.Purge()
+					}
+				}(g)
+			}
+
+			wg.Wait()
+
+			if cache.Cap() != Unbounded && cache.Len() > cache.Cap() {
+				t.Errorf("Expected at most %v; Actual = %v", cache.Cap(), cache.Len())
+			}
+		})
+	}
+
+	t.Run("should read the LRU cache while it is mutated", func(t *testing.T) {
+		l := newLRU[int, int](16, true, nil)
+		defer l.Close()
+
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(2)
+			go func(g int) {
+				defer wg.Done()
+
+				for i := 0; i < 200; i++ {
+					key := (g*200 + i) % 32
+					if i%3 == 0 {
+						l.Del(key)
+					} else {
+						l.SetWithTTL(key, i, time.Minute)
+					}
+				}
+			}(g)
+			go func(g int) {
+				defer wg.Done()
+
+				for i := 0; i < 200; i++ {
+					l.Contains((g*200 + i) % 32)
+
+					l.locker.RLock()
+					listAll(l)
+					l.locker.RUnlock()
+				}
+			}(g)
+		}
+
+		wg.Wait()
+
+		l.locker.RLock()
+		defer l.locker.RUnlock()
+		for key := range listAll(l) {
+			if _, ok := l.cache[key]; !ok {
+				t.Errorf("Expected key %v to be cached", key)
+			}
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package lru
+
+import "time"
+
+// Dedup is a bounded set of recently seen keys, such as log lines, webhook delivery IDs or message nonces,
+// which reports whether a key was already seen within a time window. It is backed by an LRU cache of keys
+// without values, so when it is full the least recently seen keys are forgotten first.
+type Dedup[K comparable] struct {
+	cache  *lru[K, struct{}] // Cache of the keys seen, whose values take no space.
+	window time.Duration     // How long a key is remembered after it was first seen, zero to remember it until evicted.
+}
+
+// NewDedup creates a deduplication set remembering up to size keys, each for the provided window after it was first seen.
+// A window of zero remembers keys until they are evicted. Optional behaviour of the underlying cache,
+// such as an eviction callback or a clock, can be configured by passing one or more Option values.
+// Call Close once the set is no longer needed to stop its expiry cleaner.
+//
+// Example usage:
+//
+//	deliveries := lru.NewDedup[string](100000, 24*time.Hour)
+//	defer deliveries.Close()
+//	if deliveries.Seen(delivery.ID) {
+//		return // already processed
+//	}
+func NewDedup[K comparable](size int, window time.Duration, opts ...Option[K, struct{}]) *Dedup[K] {
+	out := &Dedup[K]{
+		cache:  newLRU(size, true, opts),
+		window: window,
+	}
+	out.cache.startCleaner()
+
+	return out
+}
+
+// Seen reports whether the provided key was seen within the window, atomically recording it when it was not,
+// so only one of several concurrent callers seeing a key for the first time gets false.
+// Seeing a key again promotes it like Get but does not extend its window.
+func (d *Dedup[K]) Seen(key K) bool {
+	key = d.cache.canonical(key)
+
+	d.cache.locker.Lock()
+	defer d.cache.locker.Unlock()
+
+	if c, ok := d.cache.lookup(key); ok {
+		d.cache.hit(c)
+		return true
+	}
+
+	d.cache.stats.Misses++
+
+	var expiry time.Time
+	if d.window > 0 {
+		expiry = d.cache.now().Add(d.window)
+	}
+	d.cache.set(key, struct{}{}, expiry)
+
+	return false
+}
+
+// Contains reports whether the provided key was seen within the window, without recording it.
+func (d *Dedup[K]) Contains(key K) bool {
+	return d.cache.Contains(key)
+}
+
+// Forget removes the provided key from the set, so the next Seen of it reports false.
+// It returns false if the key was not remembered.
+func (d *Dedup[K]) Forget(key K) bool {
+	return d.cache.Del(key)
+}
+
+// Len returns the number of keys currently remembered.
+func (d *Dedup[K]) Len() int {
+	return d.cache.Len()
+}
+
+// Reset forgets every key.
+func (d *Dedup[K]) Reset() {
+	d.cache.Purge()
+}
+
+// Stats returns the usage counters of the set, where hits count duplicates and misses count first sightings.
+func (d *Dedup[K]) Stats() Stats {
+	return d.cache.Stats()
+}
+
+// Close stops the expiry cleaner of the set. It is safe to call Close more than once.
+func (d *Dedup[K]) Close() {
+	d.cache.Close()
+}
@@ -0,0 +1,90 @@
+package lru
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDedup(t *testing.T) {
+	t.Run("should report keys seen before", func(t *testing.T) {
+		d := NewDedup[string](10, time.Hour)
+		defer d.Close()
+
+		if d.Seen("a") {
+			t.Errorf("Expected key a to be new")
+		}
+		if !d.Seen("a") || !d.Contains("a") {
+			t.Errorf("Expected key a to be seen")
+		}
+		if d.Contains("b") || d.Seen("b") {
+			t.Errorf("Expected key b to be new")
+		}
+
+		stats := d.Stats()
+		if !reflect.DeepEqual([]uint64{1, 2}, []uint64{stats.Hits, stats.Misses}) {
+			t.Errorf("Expected %v; Actual = %v", []uint64{1, 2}, []uint64{stats.Hits, stats.Misses})
+		}
+	})
+
+	t.Run("should forget keys after the window", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		d := NewDedup[int](10, time.Minute, WithClock[int, struct{}](clock))
+		defer d.Close()
+
+		d.Seen(1)
+		clock.Advance(30 * time.Second)
+		if !d.Seen(1) {
+			t.Errorf("Expected key 1 to be seen")
+		}
+
+		clock.Advance(31 * time.Second)
+		if d.Seen(1) {
+			t.Errorf("Expected key 1 to be forgotten once its window passed")
+		}
+	})
+
+	t.Run("should forget the least recently seen keys when full", func(t *testing.T) {
+		d := NewDedup[int](2, 0)
+		defer d.Close()
+
+		d.Seen(1)
+		d.Seen(2)
+		d.Seen(1)
+		d.Seen(3)
+
+		if !d.Contains(1) || d.Contains(2) || !reflect.DeepEqual(2, d.Len()) {
+			t.Errorf("Expected key 2 to be forgotten")
+		}
+
+		d.Forget(1)
+		d.Reset()
+		if !reflect.DeepEqual(0, d.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 0, d.Len())
+		}
+	})
+
+	t.Run("should let a single concurrent caller see a key first", func(t *testing.T) {
+		d := NewDedup[int](10, time.Hour)
+		defer d.Close()
+
+		var wg sync.WaitGroup
+		var first atomic.Int32
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if !d.Seen(1) {
+					first.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if !reflect.DeepEqual(int32(1), first.Load()) {
+			t.Errorf("Expected %v; Actual = %v", 1, first.Load())
+		}
+	})
+}
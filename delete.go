@@ -0,0 +1,89 @@
+package lru
+
+import "strings"
+
+// DeleteFunc removes every item of the LRU cache for which the provided function returns true
+// and returns how many were removed, in a single lock acquisition.
+// The function runs under the lock, so it must not use the cache.
+// The removals are reported as deletions and, like Del, broadcast with the invalidator configured with WithInvalidator.
+//
+// Example usage:
+//
+//	removed := cache.DeleteFunc(func(_ string, s Session) bool {
+//		return s.UserID == userID
+//	})
+func (l *lru[K, V]) DeleteFunc(fn func(key K, value V) bool) int {
+	l.locker.Lock()
+
+	l.drainAccesses()
+
+	keys := []K{}
+	for h := l.front(); h != nil; h = l.next(h) {
+		if !l.stale(h) && fn(h.key, h.value) {
+			keys = append(keys, h.key)
+		}
+	}
+
+	out := 0
+	for _, key := range keys {
+		if l.del(key) {
+			out++
+		}
+	}
+
+	l.locker.Unlock()
+
+	for _, key := range keys {
+		l.broadcast(key)
+	}
+
+	return out
+}
+
+// DeletePrefix removes every item of a string-keyed cache whose key starts with the provided prefix
+// and returns how many were removed, in a single lock acquisition of the cache, or of every shard of a sharded cache.
+//
+// Example usage:
+//
+//	lru.DeletePrefix(pages, "/blog/")
+func DeletePrefix[K ~string, V any](cache interface {
+	DeleteFunc(fn func(key K, value V) bool) int
+}, prefix string) int {
+	return cache.DeleteFunc(func(key K, _ V) bool {
+		return strings.HasPrefix(string(key), prefix)
+	})
+}
+
+// DeleteFunc removes every item of every shard for which the provided function returns true
+// and returns how many were removed. Each shard is walked under its own lock.
+func (s *sharded[K, V]) DeleteFunc(fn func(key K, value V) bool) int {
+	out := 0
+	for _, sh := range s.shards {
+		out += sh.DeleteFunc(fn)
+	}
+
+	return out
+}
+
+// DeleteFunc removes every item of both tiers for which the provided function returns true
+// and returns how many were removed. A second tier which does not support DeleteFunc is walked with All
+// and the matching items are removed one by one.
+func (t *tiered[K, V]) DeleteFunc(fn func(key K, value V) bool) int {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	out := t.hot.DeleteFunc(fn)
+	if cold, ok := t.cold.(interface {
+		DeleteFunc(fn func(key K, value V) bool) int
+	}); ok {
+		return out + cold.DeleteFunc(fn)
+	}
+
+	for key, value := range t.cold.All() {
+		if fn(key, value) && t.cold.Del(key) {
+			out++
+		}
+	}
+
+	return out
+}
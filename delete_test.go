@@ -0,0 +1,66 @@
+package lru
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDeleteFunc(t *testing.T) {
+	t.Run("should remove the matching items", func(t *testing.T) {
+		var deleted []int
+		l := New[int, int](5, WithOnEvict[int, int](func(key int, _ int, reason Reason) {
+			if reason == Deleted {
+				deleted = append(deleted, key)
+			}
+		}))
+		for i := 1; i <= 5; i++ {
+			l.Set(i, i*10)
+		}
+
+		removed := l.DeleteFunc(func(_ int, value int) bool {
+			return value%20 == 0
+		})
+
+		if !reflect.DeepEqual(2, removed) || !reflect.DeepEqual([]int{5, 3, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v %v", []int{5, 3, 1}, removed, l.Keys())
+		}
+		if !reflect.DeepEqual([]int{4, 2}, deleted) {
+			t.Errorf("Expected %v; Actual = %v", []int{4, 2}, deleted)
+		}
+	})
+
+	t.Run("should remove the matching items of every shard and tier", func(t *testing.T) {
+		for _, l := range []LRU[int, int]{NewSharded[int, int](64, 4), NewTiered[int, int](New[int, int](2), NewLFU[int, int](4))} {
+			for i := 0; i < 6; i++ {
+				l.Set(i, i)
+			}
+
+			removed := l.DeleteFunc(func(key int, _ int) bool {
+				return key%2 == 1
+			})
+
+			keys := l.Keys()
+			slices.Sort(keys)
+			if !reflect.DeepEqual(3, removed) || !reflect.DeepEqual([]int{0, 2, 4}, keys) {
+				t.Errorf("Expected %v; Actual = %v %v", []int{0, 2, 4}, removed, keys)
+			}
+		}
+	})
+}
+
+func TestDeletePrefix(t *testing.T) {
+	t.Run("should remove the keys starting with the prefix", func(t *testing.T) {
+		l := New[string, int](5)
+		l.Set("/blog/a", 1)
+		l.Set("/blog/b", 2)
+		l.Set("/about", 3)
+
+		if removed := DeletePrefix(l, "/blog/"); !reflect.DeepEqual(2, removed) {
+			t.Errorf("Expected %v; Actual = %v", 2, removed)
+		}
+		if !reflect.DeepEqual([]string{"/about"}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []string{"/about"}, l.Keys())
+		}
+	})
+}
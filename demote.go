@@ -0,0 +1,26 @@
+package lru
+
+// DemoteFunc receives an item evicted from a cache to make room for other items,
+// typically to store it in a slower, larger tier.
+type DemoteFunc[K comparable, V any] func(key K, value V)
+
+// demote hands the provided evicted item to the demotion worker configured with WithDemoteFn, if any.
+func (l *lru[K, V]) demote(c *cache[K, V]) {
+	if l.demoter != nil {
+		l.demoter.publish(Event[K, V]{Key: c.key, Value: c.value, Reason: Evicted, Time: l.now()})
+	}
+}
+
+// startDemotion starts the worker calling the function configured with WithDemoteFn for every evicted item.
+func (l *lru[K, V]) startDemotion() {
+	if l.demoteFn == nil || l.demoter != nil {
+		return
+	}
+
+	l.demoter = newDispatcher[K, V](0)
+	go func(d *dispatcher[K, V], fn DemoteFunc[K, V]) {
+		for e := range d.out {
+			fn(e.Key, e.Value)
+		}
+	}(l.demoter, l.demoteFn)
+}
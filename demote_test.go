@@ -0,0 +1,58 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDemoteFn(t *testing.T) {
+	t.Run("should demote items evicted for room only", func(t *testing.T) {
+		demoted := make(chan int, 10)
+		clock := NewFakeClock(time.Now())
+		l := NewWithExpiry[int, int](2, WithClock[int, int](clock), WithDemoteFn(func(key, _ int) {
+			demoted <- key
+		}))
+		defer l.Close()
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+		l.Del(2)
+		l.Set(3, 30)
+		l.SetWithTTL(4, 4, time.Minute)
+		clock.Advance(time.Hour)
+		l.Get(4)
+		l.Set(5, 5)
+		l.Set(6, 6)
+		l.RemoveOldest()
+
+		actual := []int{<-demoted, <-demoted}
+		if !reflect.DeepEqual([]int{1, 3}, actual) {
+			t.Errorf("Expected %v; Actual = %v", []int{1, 3}, actual)
+		}
+
+		select {
+		case key := <-demoted:
+			t.Errorf("Expected no other demotion; Actual = %v", key)
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+
+	t.Run("should not block writes on a slow demotion", func(t *testing.T) {
+		release := make(chan struct{})
+		l := New[int, int](1, WithDemoteFn(func(int, int) {
+			<-release
+		}))
+		defer l.Close()
+		defer close(release)
+
+		for i := range 100 {
+			l.Set(i, i)
+		}
+
+		if !reflect.DeepEqual([]int{99}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{99}, l.Keys())
+		}
+	})
+}
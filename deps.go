@@ -0,0 +1,226 @@
+package lru
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// dependencies is the graph of the dependencies declared with SetWithDeps,
+// guarded by its own lock so the shards of a sharded cache can share it.
+type dependencies[K comparable] struct {
+	dependents map[K]map[K]struct{} // Keys depending on every key.
+	deps       map[K][]K            // Keys every key depends on.
+	sync.Mutex                      // Mutex guarding the maps.
+}
+
+// newDependencies returns an empty dependency graph.
+func newDependencies[K comparable]() *dependencies[K] {
+	return &dependencies[K]{
+		dependents: map[K]map[K]struct{}{},
+		deps:       map[K][]K{},
+	}
+}
+
+// set replaces the keys the provided key depends on.
+func (d *dependencies[K]) set(key K, deps []K) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.unlink(key)
+
+	for _, dep := range deps {
+		if dep == key {
+			continue
+		}
+
+		if d.dependents[dep] == nil {
+			d.dependents[dep] = map[K]struct{}{}
+		}
+		d.dependents[dep][key] = struct{}{}
+	}
+
+	if len(deps) > 0 {
+		d.deps[key] = deps
+	}
+}
+
+// of returns a copy of the keys the provided key depends on, nil if there are none.
+func (d *dependencies[K]) of(key K) []K {
+	d.Lock()
+	defer d.Unlock()
+
+	return slices.Clone(d.deps[key])
+}
+
+// forget removes the dependencies of the provided key, which left the cache.
+func (d *dependencies[K]) forget(key K) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.unlink(key)
+}
+
+// take returns the keys depending on the provided key and forgets them as its dependents.
+func (d *dependencies[K]) take(key K) []K {
+	d.Lock()
+	defer d.Unlock()
+
+	dependents := d.dependents[key]
+	delete(d.dependents, key)
+
+	out := make([]K, 0, len(dependents))
+	for dependent := range dependents {
+		out = append(out, dependent)
+	}
+
+	return out
+}
+
+// reset forgets every dependency.
+func (d *dependencies[K]) reset() {
+	d.Lock()
+	defer d.Unlock()
+
+	d.dependents = map[K]map[K]struct{}{}
+	d.deps = map[K][]K{}
+}
+
+// unlink removes the edges from the provided key to the keys it depends on.
+func (d *dependencies[K]) unlink(key K) {
+	for _, dep := range d.deps[key] {
+		delete(d.dependents[dep], key)
+		if len(d.dependents[dep]) == 0 {
+			delete(d.dependents, dep)
+		}
+	}
+	delete(d.deps, key)
+}
+
+// SetWithDeps adds or updates a key-value pair in the LRU cache like Set, declaring that it depends on the provided keys,
+// so it is removed as soon as one of them is deleted or updated, such as a view derived from upstream records.
+// Removals cascade: the items depending on a removed item are removed in turn. They are reported as deletions.
+// Evicted and expired items do not remove their dependents. The dependencies replace those of a previous value
+// stored for the key; Set and the other setters store values without dependencies.
+//
+// Example usage:
+//
+//	cache.SetWithDeps("order:7:summary", summary, "order:7", "customer:42")
+//	cache.Set("order:7", order) // removes "order:7:summary"
+func (l *lru[K, V]) SetWithDeps(key K, value V, deps ...K) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	var expiry time.Time
+	if l.set(key, value, expiry) {
+		l.depend(key, deps)
+	}
+}
+
+// depend records that the provided key depends on the provided keys, tracking dependencies from then on.
+func (l *lru[K, V]) depend(key K, deps []K) {
+	if len(deps) == 0 {
+		return
+	}
+
+	if l.dependencies == nil {
+		l.dependOn(newDependencies[K](), func(keys []K) {
+			l.DelMany(keys)
+		})
+	}
+
+	canonical := make([]K, len(deps))
+	for i, dep := range deps {
+		canonical[i] = l.canonical(dep)
+	}
+	l.dependencies.set(key, canonical)
+}
+
+// dependOn makes the cache track dependencies in the provided graph,
+// removing the dependents of removed items with the provided function once the cache is unlocked.
+func (l *lru[K, V]) dependOn(d *dependencies[K], cascade func(keys []K)) {
+	l.dependencies = d
+	l.cascade = cascade
+	l.locker.deferred = l.unlocked
+}
+
+// release forgets the dependencies of the provided key, which is leaving the cache,
+// and queues its dependents for removal when it is deleted or replaced.
+func (l *lru[K, V]) release(key K, reason Reason) {
+	if l.dependencies == nil {
+		return
+	}
+
+	l.dependencies.forget(key)
+	if reason == Deleted || reason == Replaced {
+		l.cascading = append(l.cascading, l.dependencies.take(key)...)
+	}
+}
+
+// dropDependents queues the dependents of the provided key for removal, as its value was updated in place,
+// without forgetting the keys it depends on itself.
+func (l *lru[K, V]) dropDependents(key K) {
+	if l.dependencies == nil {
+		return
+	}
+
+	l.cascading = append(l.cascading, l.dependencies.take(key)...)
+}
+
+// dependsOn returns the keys the provided key depends on, nil if there are none.
+func (l *lru[K, V]) dependsOn(key K) []K {
+	if l.dependencies == nil {
+		return nil
+	}
+
+	return l.dependencies.of(key)
+}
+
+// cascades returns a function removing the dependents of the items removed while the cache was locked,
+// or nil if there are none. It runs under the lock, the returned function runs once the cache is unlocked.
+func (l *lru[K, V]) cascades() func() {
+	if len(l.cascading) == 0 {
+		return nil
+	}
+
+	keys := l.cascading
+	l.cascading = nil
+
+	return func() {
+		l.cascade(keys)
+	}
+}
+
+// unlocked returns the work to run once the cache is unlocked: disposing of values and removing dependents.
+func (l *lru[K, V]) unlocked() func() {
+	dispose, cascade := l.disposals(), l.cascades()
+	switch {
+	case dispose == nil:
+		return cascade
+	case cascade == nil:
+		return dispose
+	}
+
+	return func() {
+		dispose()
+		cascade()
+	}
+}
+
+// SetWithDeps adds or updates a key-value pair in the shard responsible for the key, declaring the keys it depends on.
+// Dependencies may span shards: removing a key removes its dependents from their own shards.
+func (s *sharded[K, V]) SetWithDeps(key K, value V, deps ...K) {
+	s.shard(key).SetWithDeps(key, value, deps...)
+}
+
+// SetWithDeps adds or updates a key-value pair in the first tier, declaring the keys it depends on.
+// Items with dependencies are dropped instead of being demoted into the second tier, which does not track them.
+func (t *tiered[K, V]) SetWithDeps(key K, value V, deps ...K) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	t.makeRoom(key)
+	t.hot.SetWithDeps(key, value, deps...)
+}
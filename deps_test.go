@@ -0,0 +1,146 @@
+package lru
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSetWithDeps(t *testing.T) {
+	t.Run("should remove the dependents of a deleted key", func(t *testing.T) {
+		var deleted []string
+		l := New[string, int](5, WithOnEvict[string, int](func(key string, _ int, reason Reason) {
+			if reason == Deleted {
+				deleted = append(deleted, key)
+			}
+		}))
+		l.Set("order", 1)
+		l.Set("customer", 2)
+		l.SetWithDeps("summary", 3, "order", "customer")
+
+		l.Del("order")
+
+		if l.Contains("summary") || !l.Contains("customer") {
+			t.Errorf("Expected %v to be removed; Actual = %v", "summary", l.Keys())
+		}
+		if !reflect.DeepEqual([]string{"order", "summary"}, deleted) {
+			t.Errorf("Expected %v; Actual = %v", []string{"order", "summary"}, deleted)
+		}
+
+		// the dependency on the remaining key is forgotten along with the dependent
+		l.SetWithDeps("summary", 4)
+		l.Del("customer")
+		if !l.Contains("summary") {
+			t.Errorf("Expected %v to be kept", "summary")
+		}
+	})
+
+	t.Run("should remove the dependents of an updated key", func(t *testing.T) {
+		l := New[string, int](5)
+		l.Set("order", 1)
+		l.SetWithDeps("summary", 2, "order")
+
+		l.Set("order", 10)
+
+		if l.Contains("summary") || !l.Contains("order") {
+			t.Errorf("Expected %v to be removed; Actual = %v", "summary", l.Keys())
+		}
+	})
+
+	t.Run("should cascade through chains and cycles", func(t *testing.T) {
+		l := New[string, int](5)
+		l.Set("a", 1)
+		l.SetWithDeps("b", 2, "a")
+		l.SetWithDeps("c", 3, "b")
+		l.SetWithDeps("d", 4, "c", "d")
+		l.SetWithDeps("e", 5, "d")
+		l.SetWithDeps("d", 4, "c", "e")
+
+		l.Del("a")
+
+		if !reflect.DeepEqual(0, l.Len()) {
+			t.Errorf("Expected an empty cache; Actual = %v", l.Keys())
+		}
+	})
+
+	t.Run("should keep the dependents of evicted and expired keys", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		l := NewWithExpiry[string, int](3, WithClock[string, int](clock))
+		defer l.Close()
+
+		l.SetWithTTL("token", 1, time.Minute)
+		l.SetWithDeps("profile", 2, "token")
+
+		clock.Advance(time.Hour)
+		if _, ok := l.Get("token"); ok {
+			t.Errorf("Expected %v to expire", "token")
+		}
+		l.Set("token", 3)
+
+		if !l.Contains("profile") {
+			t.Errorf("Expected %v to be kept", "profile")
+		}
+	})
+
+	t.Run("should drop the dependencies of values stored with Set", func(t *testing.T) {
+		l := New[string, int](5)
+		l.Set("order", 1)
+		l.SetWithDeps("summary", 2, "order")
+		l.Set("summary", 3)
+
+		if e, _ := l.GetEntry("summary"); e.DependsOn != nil {
+			t.Errorf("Expected no dependencies; Actual = %v", e.DependsOn)
+		}
+
+		l.Del("order")
+		if !l.Contains("summary") {
+			t.Errorf("Expected %v to be kept", "summary")
+		}
+	})
+
+	t.Run("should snapshot and warm dependencies", func(t *testing.T) {
+		l := New[string, int](5)
+		l.Set("order", 1)
+		l.SetWithDeps("summary", 2, "order")
+
+		if e, _ := l.GetEntry("summary"); !reflect.DeepEqual([]string{"order"}, e.DependsOn) {
+			t.Errorf("Expected %v; Actual = %v", []string{"order"}, e.DependsOn)
+		}
+
+		warmed := New[string, int](5)
+		warmed.Warm(l.Snapshot())
+		warmed.Del("order")
+		if !reflect.DeepEqual([]string{}, warmed.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []string{}, warmed.Keys())
+		}
+	})
+
+	t.Run("should cascade across shards", func(t *testing.T) {
+		l := NewSharded[int, int](64, 8)
+		l.Set(0, 0)
+		for i := 1; i < 16; i++ {
+			l.SetWithDeps(i, i, i-1)
+		}
+
+		l.Set(0, 10)
+
+		if !reflect.DeepEqual([]int{0}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{0}, l.Keys())
+		}
+	})
+
+	t.Run("should drop dependent items instead of demoting them", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](2), New[int, int](4))
+		l.Set(1, 1)
+		l.SetWithDeps(2, 2, 1)
+		l.Set(3, 3)
+		l.Set(4, 4)
+
+		keys := l.Keys()
+		slices.Sort(keys)
+		if !reflect.DeepEqual([]int{1, 3, 4}, keys) {
+			t.Errorf("Expected %v; Actual = %v", []int{1, 3, 4}, keys)
+		}
+	})
+}
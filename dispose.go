@@ -0,0 +1,67 @@
+package lru
+
+import (
+	"io"
+	"reflect"
+)
+
+// Disposer releases the resources held by a value which left the cache, such as a connection or a file.
+type Disposer[V any] func(value V)
+
+// disposals returns a function disposing of the values which left the cache while it was locked, or nil if there are none.
+// It runs under the lock, the returned function runs once the cache is unlocked.
+func (l *lru[K, V]) disposals() func() {
+	if len(l.disposed) == 0 {
+		return nil
+	}
+
+	values := l.disposed
+	l.disposed = nil
+
+	return func() {
+		for _, value := range values {
+			l.disposer(value)
+		}
+	}
+}
+
+// dispose queues the value of the provided item leaving the cache for the disposer.
+// The disposal of a leased value waits for the last release of its leases instead.
+func (l *lru[K, V]) dispose(c *cache[K, V]) {
+	if c.lease != nil {
+		c.lease.removed = true
+		c.lease = nil
+		return
+	}
+
+	if l.disposer != nil {
+		l.disposed = append(l.disposed, c.value)
+	}
+}
+
+// closeValue closes the provided value if it implements io.Closer.
+func closeValue[V any](value V) {
+	if c, ok := any(value).(io.Closer); ok {
+		c.Close()
+	}
+}
+
+// same reports whether both values are known to be identical, such as the same pointer stored again.
+// Values whose type is not comparable are never reported as identical, nor are values of a comparable type
+// holding values which are not, such as a struct with a slice in an interface field.
+func same[V any](a, b V) (out bool) {
+	x, y := any(a), any(b)
+	t := reflect.TypeOf(x)
+	if t == nil || t != reflect.TypeOf(y) || !t.Comparable() {
+		return false
+	}
+
+	// comparing such values panics
+	defer func() {
+		if recover() != nil {
+			out = false
+		}
+	}()
+
+	return x == y
+}
@@ -0,0 +1,121 @@
+package lru
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type closer struct {
+	closed bool
+}
+
+func (c *closer) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestDisposer(t *testing.T) {
+	t.Run("should close evicted, expired, replaced and deleted values", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		l := NewWithExpiry[int, *closer](2, WithAutoClose[int, *closer](), WithClock[int, *closer](clock))
+		defer l.Close()
+
+		evicted, expired, replaced, deleted := &closer{}, &closer{}, &closer{}, &closer{}
+		l.Set(1, evicted)
+		l.Set(2, &closer{})
+		l.Set(3, &closer{})
+		if !evicted.closed {
+			t.Errorf("Expected the evicted value to be closed")
+		}
+
+		l.SetWithTTL(4, expired, time.Minute)
+		clock.Advance(time.Hour)
+		l.Get(4)
+		if !expired.closed {
+			t.Errorf("Expected the expired value to be closed")
+		}
+
+		l.Set(5, replaced)
+		l.Set(5, &closer{})
+		if !replaced.closed {
+			t.Errorf("Expected the replaced value to be closed")
+		}
+
+		l.Set(6, deleted)
+		l.Del(6)
+		if !deleted.closed {
+			t.Errorf("Expected the deleted value to be closed")
+		}
+	})
+
+	t.Run("should not close a value set again for its own key", func(t *testing.T) {
+		l := New[int, *closer](2, WithAutoClose[int, *closer]())
+		c := &closer{}
+		l.Set(1, c)
+		l.Set(1, c)
+
+		if c.closed {
+			t.Errorf("Expected the value to stay open")
+		}
+	})
+
+	t.Run("should dispose of purged values", func(t *testing.T) {
+		var disposed []int
+		l := New[int, int](3, WithDisposer[int](func(v int) {
+			disposed = append(disposed, v)
+		}))
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Purge()
+
+		if !reflect.DeepEqual([]int{2, 1}, disposed) {
+			t.Errorf("Expected %v; Actual = %v", []int{2, 1}, disposed)
+		}
+	})
+
+	t.Run("should ignore values which are not closers", func(t *testing.T) {
+		l := New[int, int](1, WithAutoClose[int, int]())
+		l.Set(1, 1)
+		l.Set(2, 2)
+
+		if !reflect.DeepEqual([]int{2}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{2}, l.Keys())
+		}
+	})
+
+	t.Run("should replace values holding values which are not comparable", func(t *testing.T) {
+		type payload struct {
+			data any
+		}
+
+		var disposed []payload
+		l := New[int, payload](1, WithDisposer[int, payload](func(v payload) {
+			disposed = append(disposed, v)
+		}))
+		l.Set(1, payload{data: []int{1}})
+		l.Set(1, payload{data: []int{2}})
+
+		if !reflect.DeepEqual([]payload{{data: []int{1}}}, disposed) {
+			t.Errorf("Expected %v; Actual = %v", []payload{{data: []int{1}}}, disposed)
+		}
+	})
+
+	t.Run("should dispose outside of the cache lock", func(t *testing.T) {
+		var l LRU[int, int]
+		var mu sync.Mutex
+		var lens []int
+		l = New[int, int](1, WithDisposer[int](func(v int) {
+			mu.Lock()
+			defer mu.Unlock()
+			lens = append(lens, l.Len())
+		}))
+		l.Set(1, 1)
+		l.Set(2, 2)
+
+		if !reflect.DeepEqual([]int{1}, lens) {
+			t.Errorf("Expected %v; Actual = %v", []int{1}, lens)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+// Package distributed shares a keyspace between several processes, in the style of groupcache.
+// Every key is owned by exactly one node, picked by consistent hashing.
+// A node loads the keys it owns with a Getter and fetches the other keys from their owners,
+// keeping the recently used values of both kinds in a local LRU cache.
+package distributed
+
+import (
+	"context"
+	"time"
+
+	"github.com/vhndaree/lru"
+)
+
+// Getter loads the value of a key owned by the local node, typically from a database or a remote service.
+type Getter func(ctx context.Context, key string) ([]byte, error)
+
+// Peer is a remote node owning part of the keyspace.
+type Peer interface {
+	// Fetch returns the value of the provided key from the provided group of the remote node.
+	Fetch(ctx context.Context, group, key string) ([]byte, error)
+}
+
+// PeerPicker picks the node owning a key.
+type PeerPicker interface {
+	// PickPeer returns the remote node owning the provided key,
+	// or false when the key is owned by the local node.
+	PickPeer(key string) (Peer, bool)
+}
+
+// registrar is a PeerPicker serving the groups created with it to remote nodes.
+type registrar interface {
+	register(g *Group)
+}
+
+// Group is a named keyspace shared between nodes.
+// Values are cached in a local LRU cache whichever node owns them,
+// and concurrent loads of the same key share a single call of the getter or a single fetch from its owner.
+type Group struct {
+	name   string                         // Name of the group, identical on every node.
+	getter Getter                         // Getter loading the keys owned by the local node.
+	peers  PeerPicker                     // Picker of the node owning each key.
+	cache  lru.LoadingLRU[string, []byte] // Local cache of recently used values.
+}
+
+// fromPeer is the context key marking loads requested by a remote node, which must not be forwarded again.
+type fromPeer struct{}
+
+// NewGroup creates a Group with the provided name, caching up to size values for the provided TTL, or forever when it is zero.
+// Keys owned by the local node are loaded with the getter, the others are fetched from their owner picked by peers.
+// If fetching from the owner fails, the key is loaded locally with the getter instead.
+// A nil picker keeps every key local.
+//
+// Groups created with an HTTPPool are served to the remote nodes by that pool.
+//
+// Example usage:
+//
+//	pool := distributed.NewHTTPPool("http://10.0.0.1:8080")
+//	pool.Set("http://10.0.0.1:8080", "http://10.0.0.2:8080", "http://10.0.0.3:8080")
+//	users := distributed.NewGroup("users", 1000, time.Minute, loadUser, pool)
+//	http.ListenAndServe(":8080", pool)
+func NewGroup(name string, size int, ttl time.Duration, getter Getter, peers PeerPicker) *Group {
+	g := &Group{name: name, getter: getter, peers: peers}
+	g.cache = lru.NewLoading[string, []byte](size, g.load, lru.WithDefaultTTL[string, []byte](ttl))
+
+	if r, ok := peers.(registrar); ok {
+		r.register(g)
+	}
+
+	return g
+}
+
+// Name returns the name of the group.
+func (g *Group) Name() string {
+	return g.name
+}
+
+// Get returns the value of the provided key, from the local cache, from the node owning the key or from the getter.
+// The returned slice is shared with the cache and must not be modified.
+func (g *Group) Get(ctx context.Context, key string) ([]byte, error) {
+	return g.cache.Load(ctx, key)
+}
+
+// Stats returns the usage counters of the local cache of the group.
+func (g *Group) Stats() lru.Stats {
+	return g.cache.Stats()
+}
+
+// Close stops the background goroutines of the local cache. The group must not be used after Close.
+func (g *Group) Close() {
+	g.cache.Close()
+}
+
+// serve returns the value of the provided key requested by a remote node, without forwarding the request to another node.
+func (g *Group) serve(ctx context.Context, key string) ([]byte, error) {
+	return g.cache.Load(context.WithValue(ctx, fromPeer{}, true), key)
+}
+
+// load loads the value of the provided key missing from the local cache.
+func (g *Group) load(ctx context.Context, key string) ([]byte, error) {
+	if g.peers != nil && ctx.Value(fromPeer{}) == nil {
+		if peer, ok := g.peers.PickPeer(key); ok {
+			if value, err := peer.Fetch(ctx, g.name, key); err == nil {
+				return value, nil
+			}
+		}
+	}
+
+	return g.getter(ctx, key)
+}
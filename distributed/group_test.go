@@ -0,0 +1,99 @@
+package distributed
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should load every key once on its owner", func(t *testing.T) {
+		var servers []*httptest.Server
+		var pools []*HTTPPool
+		var groups []*Group
+		loads := make([]atomic.Int32, 3)
+
+		for i := 0; i < 3; i++ {
+			// the pool needs the URL of the server it is served by
+			var pool *HTTPPool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pool.ServeHTTP(w, r)
+			}))
+			defer server.Close()
+
+			pool = NewHTTPPool(server.URL)
+			servers = append(servers, server)
+			pools = append(pools, pool)
+		}
+
+		var urls []string
+		for _, s := range servers {
+			urls = append(urls, s.URL)
+		}
+
+		for i, pool := range pools {
+			pool.Set(urls...)
+
+			i := i
+			g := NewGroup("test", 100, time.Minute, func(_ context.Context, key string) ([]byte, error) {
+				loads[i].Add(1)
+				return []byte("value " + key), nil
+			}, pool)
+			defer g.Close()
+			groups = append(groups, g)
+		}
+
+		for _, g := range groups {
+			for k := 0; k < 30; k++ {
+				key := strconv.Itoa(k)
+				actual, err := g.Get(ctx, key)
+				if err != nil || string(actual) != "value "+key {
+					t.Errorf("Expected value %v; Actual = %s, %v", key, actual, err)
+				}
+			}
+		}
+
+		total := loads[0].Load() + loads[1].Load() + loads[2].Load()
+		if !reflect.DeepEqual(int32(30), total) {
+			t.Errorf("Expected 30; Actual = %v", total)
+		}
+	})
+
+	t.Run("should load locally when the owner is unreachable", func(t *testing.T) {
+		pool := NewHTTPPool("http://self")
+		pool.Set("http://self", "http://127.0.0.1:1")
+
+		g := NewGroup("test", 100, 0, func(_ context.Context, key string) ([]byte, error) {
+			return []byte(key), nil
+		}, pool)
+		defer g.Close()
+
+		for k := 0; k < 10; k++ {
+			key := strconv.Itoa(k)
+			actual, err := g.Get(ctx, key)
+			if err != nil || string(actual) != key {
+				t.Errorf("Expected %v; Actual = %s, %v", key, actual, err)
+			}
+		}
+	})
+
+	t.Run("should return getter errors", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		g := NewGroup("test", 100, 0, func(_ context.Context, key string) ([]byte, error) {
+			return nil, errBoom
+		}, nil)
+		defer g.Close()
+
+		if _, err := g.Get(ctx, "a"); !errors.Is(err, errBoom) {
+			t.Errorf("Expected %v; Actual = %v", errBoom, err)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package distributed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// DefaultBasePath is the path under which an HTTPPool serves its groups.
+const DefaultBasePath = "/_lru/"
+
+// HTTPPool is a PeerPicker whose nodes talk HTTP, and the http.Handler serving the local groups to them.
+// Nodes are identified by their base URL, such as "http://10.0.0.1:8080",
+// and serve the value of a key of a group under DefaultBasePath + group + "/" + key.
+type HTTPPool struct {
+	self       string            // Base URL of the local node.
+	client     *http.Client      // Client fetching values from the remote nodes.
+	ring       *Ring             // Consistent hash ring of every node.
+	peers      map[string]Peer   // Remote nodes by base URL.
+	groups     map[string]*Group // Groups served to the remote nodes, by name.
+	sync.Mutex                   // Mutex for concurrent access.
+}
+
+// NewHTTPPool creates an HTTPPool for the local node with the provided base URL.
+// Its nodes are configured with Set, and it must be served over HTTP for the remote nodes to reach the local groups.
+func NewHTTPPool(self string) *HTTPPool {
+	return &HTTPPool{
+		self:   strings.TrimSuffix(self, "/"),
+		client: http.DefaultClient,
+		ring:   NewRing(DefaultReplicas),
+		peers:  map[string]Peer{},
+		groups: map[string]*Group{},
+	}
+}
+
+// Set replaces the nodes of the pool with the provided base URLs, which should include the local node.
+// Every node must be configured with the same base URLs to agree on the owner of every key.
+func (p *HTTPPool) Set(nodes ...string) {
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+
+	p.ring = NewRing(DefaultReplicas)
+	p.peers = map[string]Peer{}
+	for _, node := range nodes {
+		node = strings.TrimSuffix(node, "/")
+		p.ring.Add(node)
+		p.peers[node] = &httpPeer{baseURL: node + DefaultBasePath, client: p.client}
+	}
+}
+
+// PickPeer returns the remote node owning the provided key, or false when it is owned by the local node.
+func (p *HTTPPool) PickPeer(key string) (Peer, bool) {
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+
+	node := p.ring.Get(key)
+	if node == "" || node == p.self {
+		return nil, false
+	}
+
+	return p.peers[node], true
+}
+
+// ServeHTTP serves the value of a key of a local group to a remote node.
+func (p *HTTPPool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, key, ok := strings.Cut(strings.TrimPrefix(r.URL.EscapedPath(), DefaultBasePath), "/")
+	if !ok || !strings.HasPrefix(r.URL.EscapedPath(), DefaultBasePath) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	name, err := url.PathUnescape(name)
+	if err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	key, err = url.PathUnescape(key)
+	if err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	p.Mutex.Lock()
+	g, ok := p.groups[name]
+	p.Mutex.Unlock()
+
+	if !ok {
+		http.Error(w, "no such group: "+name, http.StatusNotFound)
+		return
+	}
+
+	value, err := g.serve(r.Context(), key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(value)
+}
+
+// register serves the provided group to the remote nodes.
+func (p *HTTPPool) register(g *Group) {
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+
+	p.groups[g.name] = g
+}
+
+// httpPeer is a remote node reached over HTTP.
+type httpPeer struct {
+	baseURL string       // URL under which the node serves its groups.
+	client  *http.Client // Client making the requests.
+}
+
+// Fetch requests the value of the provided key of the provided group from the remote node.
+func (h *httpPeer) Fetch(ctx context.Context, group, key string) ([]byte, error) {
+	u := h.baseURL + url.PathEscape(group) + "/" + url.PathEscape(key)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("distributed: fetch %s: %s: %s", u, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
@@ -0,0 +1,64 @@
+package distributed
+
+import (
+	"hash/crc32"
+	"slices"
+	"strconv"
+)
+
+// DefaultReplicas is the number of points every node gets on a Ring unless another number is passed to NewRing.
+const DefaultReplicas = 50
+
+// Ring is a consistent hash ring mapping keys to nodes.
+// Every node is placed on the ring at several points, so keys spread evenly across nodes
+// and only the keys of a node move when that node joins or leaves.
+// Nodes hash keys the same way in every process, so processes with the same nodes agree on the owner of every key.
+//
+// A Ring is not safe for concurrent use; HTTPPool guards its own ring.
+type Ring struct {
+	replicas int               // Number of points per node.
+	points   []uint32          // Sorted hashes of the points on the ring.
+	nodes    map[uint32]string // Node owning each point.
+}
+
+// NewRing creates an empty Ring placing every node at the provided number of points,
+// or at DefaultReplicas points when it is not positive.
+func NewRing(replicas int) *Ring {
+	if replicas <= 0 {
+		replicas = DefaultReplicas
+	}
+
+	return &Ring{replicas: replicas, nodes: map[uint32]string{}}
+}
+
+// Add places the provided nodes on the ring.
+func (r *Ring) Add(nodes ...string) {
+	for _, node := range nodes {
+		for i := 0; i < r.replicas; i++ {
+			h := crc32.ChecksumIEEE([]byte(strconv.Itoa(i) + node))
+			if _, ok := r.nodes[h]; ok {
+				continue
+			}
+			r.points = append(r.points, h)
+			r.nodes[h] = node
+		}
+	}
+
+	slices.Sort(r.points)
+}
+
+// Get returns the node owning the provided key, the node of the first point following the hash of the key.
+// It returns an empty string when the ring has no nodes.
+func (r *Ring) Get(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+
+	h := crc32.ChecksumIEEE([]byte(key))
+	i, _ := slices.BinarySearch(r.points, h)
+	if i == len(r.points) {
+		i = 0
+	}
+
+	return r.nodes[r.points[i]]
+}
@@ -0,0 +1,45 @@
+package distributed
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	t.Run("should map keys to the same node in every ring", func(t *testing.T) {
+		a, b := NewRing(10), NewRing(10)
+		a.Add("n1", "n2", "n3")
+		b.Add("n3", "n1", "n2")
+
+		for i := 0; i < 100; i++ {
+			key := strconv.Itoa(i)
+			if !reflect.DeepEqual(a.Get(key), b.Get(key)) {
+				t.Errorf("Expected %v; Actual = %v", a.Get(key), b.Get(key))
+			}
+		}
+	})
+
+	t.Run("should only move the keys of a new node", func(t *testing.T) {
+		r := NewRing(DefaultReplicas)
+		r.Add("n1", "n2")
+
+		before := map[string]string{}
+		for i := 0; i < 1000; i++ {
+			before[strconv.Itoa(i)] = r.Get(strconv.Itoa(i))
+		}
+
+		r.Add("n3")
+		for key, node := range before {
+			if actual := r.Get(key); actual != node && actual != "n3" {
+				t.Errorf("Expected %v or n3; Actual = %v", node, actual)
+			}
+		}
+	})
+
+	t.Run("should return no node from an empty ring", func(t *testing.T) {
+		if !reflect.DeepEqual("", NewRing(0).Get("a")) {
+			t.Errorf("Expected an empty node; Actual = %v", NewRing(0).Get("a"))
+		}
+	})
+}
@@ -0,0 +1,155 @@
+// Package dnscache caches the results of DNS lookups in an LRU cache, honouring the TTL of the records when known.
+package dnscache
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/vhndaree/lru"
+)
+
+// DefaultTTL is how long addresses are cached when the resolver does not report the TTL of the records,
+// unless another TTL is configured with WithTTL.
+const DefaultTTL = time.Minute
+
+// Resolver looks up the addresses of hosts, like *net.Resolver.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) (addrs []string, err error)
+}
+
+// TTLResolver is a Resolver which also reports how long the records it found may be cached,
+// such as a resolver built on a DNS client library. The standard library does not expose record TTLs.
+type TTLResolver interface {
+	Resolver
+
+	LookupHostTTL(ctx context.Context, host string) (addrs []string, ttl time.Duration, err error)
+}
+
+// record is the result of a lookup cached for a host.
+type record struct {
+	addrs []string      // Addresses of the host.
+	ttl   time.Duration // How long the addresses may be cached.
+	once  sync.Once     // Guards applying the TTL of the record to the cached item once it is stored.
+}
+
+// Cache is a caching Resolver. Concurrent lookups of the same uncached host share a single lookup.
+type Cache struct {
+	cache       lru.LoadingLRU[string, *record] // Records by host.
+	resolver    Resolver                        // Resolver looking up the hosts which are not cached.
+	ttl         time.Duration                   // TTL of the records of resolvers which do not report it.
+	negativeTTL time.Duration                   // How long failed lookups are remembered, zero to retry them right away.
+	clock       lru.Clock                       // Clock expiring the records, nil for the system clock.
+}
+
+// Option configures optional behaviour of a Cache at construction time.
+type Option func(*Cache)
+
+// WithTTL configures how long addresses are cached when the resolver does not report the TTL of the records.
+// Non-positive TTLs are ignored.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Cache) {
+		if ttl > 0 {
+			c.ttl = ttl
+		}
+	}
+}
+
+// WithNegativeTTL configures the Cache to remember failed lookups for the provided TTL,
+// returning the same error without asking the resolver again until it passes.
+func WithNegativeTTL(ttl time.Duration) Option {
+	return func(c *Cache) {
+		c.negativeTTL = ttl
+	}
+}
+
+// WithClock configures the clock expiring the records, such as an lru.FakeClock in tests, instead of the system clock.
+func WithClock(clock lru.Clock) Option {
+	return func(c *Cache) {
+		c.clock = clock
+	}
+}
+
+// New creates a Cache caching the addresses of up to size hosts looked up with the provided resolver,
+// such as net.DefaultResolver. Records are cached for their TTL when the resolver is a TTLResolver,
+// and for DefaultTTL or the TTL configured with WithTTL otherwise.
+// Optional behaviour can be configured by passing one or more Option values.
+// Call Close once the cache is no longer needed to stop its expiry cleaner.
+//
+// Example usage:
+//
+//	resolver := dnscache.New(net.DefaultResolver, 1000)
+//	defer resolver.Close()
+//	addrs, err := resolver.LookupHost(ctx, "example.com")
+func New(resolver Resolver, size int, opts ...Option) *Cache {
+	c := &Cache{
+		resolver: resolver,
+		ttl:      DefaultTTL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	cacheOpts := []lru.Option[string, *record]{lru.WithNegativeTTL[string, *record](c.negativeTTL)}
+	if c.clock != nil {
+		cacheOpts = append(cacheOpts, lru.WithClock[string, *record](c.clock))
+	}
+	c.cache = lru.NewLoading(size, c.lookup, cacheOpts...)
+
+	return c
+}
+
+// lookup asks the resolver for the addresses of the provided host.
+func (c *Cache) lookup(ctx context.Context, host string) (*record, error) {
+	if r, ok := c.resolver.(TTLResolver); ok {
+		addrs, ttl, err := r.LookupHostTTL(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		return &record{addrs: addrs, ttl: ttl}, nil
+	}
+
+	addrs, err := c.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	return &record{addrs: addrs, ttl: c.ttl}, nil
+}
+
+// LookupHost returns the addresses of the provided host, from the cache when its records have not expired yet.
+// Errors of the resolver are returned as is.
+func (c *Cache) LookupHost(ctx context.Context, host string) ([]string, error) {
+	rec, err := c.cache.Load(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	// the loading cache stores records without a TTL, the first caller applies the TTL of the records
+	rec.once.Do(func() {
+		if rec.ttl > 0 {
+			c.cache.UpdateTTL(host, rec.ttl)
+		} else {
+			c.cache.Del(host)
+		}
+	})
+
+	return slices.Clone(rec.addrs), nil
+}
+
+// Forget removes the cached addresses of the provided host, so the next lookup asks the resolver again.
+func (c *Cache) Forget(host string) {
+	c.cache.Del(host)
+}
+
+// Stats returns the usage counters of the cache, where hits count lookups answered from the cache.
+func (c *Cache) Stats() lru.Stats {
+	return c.cache.Stats()
+}
+
+// Close stops the expiry cleaner of the cache. It is safe to call Close more than once.
+func (c *Cache) Close() {
+	c.cache.Close()
+}
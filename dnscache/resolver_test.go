@@ -0,0 +1,145 @@
+package dnscache
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vhndaree/lru"
+)
+
+var _ Resolver = (*net.Resolver)(nil)
+
+// fakeResolver answers lookups with the addresses of its hosts and counts them.
+type fakeResolver struct {
+	hosts   map[string][]string
+	ttl     time.Duration
+	lookups atomic.Int32
+}
+
+func (r *fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	r.lookups.Add(1)
+	addrs, ok := r.hosts[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	return addrs, nil
+}
+
+// fakeTTLResolver also reports the TTL of its records.
+type fakeTTLResolver struct {
+	fakeResolver
+}
+
+func (r *fakeTTLResolver) LookupHostTTL(ctx context.Context, host string) ([]string, time.Duration, error) {
+	addrs, err := r.LookupHost(ctx, host)
+	return addrs, r.ttl, err
+}
+
+func TestCache(t *testing.T) {
+	t.Run("should cache addresses for the configured TTL", func(t *testing.T) {
+		clock := lru.NewFakeClock(time.Now())
+		r := &fakeResolver{hosts: map[string][]string{"example.com": {"192.0.2.1"}}}
+		c := New(r, 10, WithTTL(time.Minute), WithClock(clock))
+		defer c.Close()
+
+		for i := 0; i < 3; i++ {
+			addrs, err := c.LookupHost(context.Background(), "example.com")
+			if err != nil || !reflect.DeepEqual([]string{"192.0.2.1"}, addrs) {
+				t.Errorf("Expected %v; Actual = %v %v", []string{"192.0.2.1"}, addrs, err)
+			}
+		}
+		if !reflect.DeepEqual(int32(1), r.lookups.Load()) {
+			t.Errorf("Expected %v; Actual = %v", 1, r.lookups.Load())
+		}
+
+		clock.Advance(2 * time.Minute)
+		c.LookupHost(context.Background(), "example.com")
+		if !reflect.DeepEqual(int32(2), r.lookups.Load()) {
+			t.Errorf("Expected %v; Actual = %v", 2, r.lookups.Load())
+		}
+	})
+
+	t.Run("should honour the TTL of the records", func(t *testing.T) {
+		clock := lru.NewFakeClock(time.Now())
+		r := &fakeTTLResolver{fakeResolver{hosts: map[string][]string{"example.com": {"192.0.2.1"}}, ttl: 5 * time.Second}}
+		c := New(r, 10, WithTTL(time.Hour), WithClock(clock))
+		defer c.Close()
+
+		c.LookupHost(context.Background(), "example.com")
+		clock.Advance(4 * time.Second)
+		c.LookupHost(context.Background(), "example.com")
+		if !reflect.DeepEqual(int32(1), r.lookups.Load()) {
+			t.Errorf("Expected %v; Actual = %v", 1, r.lookups.Load())
+		}
+
+		clock.Advance(2 * time.Second)
+		c.LookupHost(context.Background(), "example.com")
+		if !reflect.DeepEqual(int32(2), r.lookups.Load()) {
+			t.Errorf("Expected %v; Actual = %v", 2, r.lookups.Load())
+		}
+	})
+
+	t.Run("should not cache records without a TTL", func(t *testing.T) {
+		r := &fakeTTLResolver{fakeResolver{hosts: map[string][]string{"example.com": {"192.0.2.1"}}}}
+		c := New(r, 10)
+		defer c.Close()
+
+		c.LookupHost(context.Background(), "example.com")
+		c.LookupHost(context.Background(), "example.com")
+		if !reflect.DeepEqual(int32(2), r.lookups.Load()) {
+			t.Errorf("Expected %v; Actual = %v", 2, r.lookups.Load())
+		}
+	})
+
+	t.Run("should return errors and remember them for the negative TTL", func(t *testing.T) {
+		r := &fakeResolver{hosts: map[string][]string{}}
+		c := New(r, 10, WithNegativeTTL(time.Minute))
+		defer c.Close()
+
+		for i := 0; i < 2; i++ {
+			var dnsErr *net.DNSError
+			if _, err := c.LookupHost(context.Background(), "missing.example"); !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+				t.Errorf("Expected a not found error; Actual = %v", err)
+			}
+		}
+		if !reflect.DeepEqual(int32(1), r.lookups.Load()) {
+			t.Errorf("Expected %v; Actual = %v", 1, r.lookups.Load())
+		}
+	})
+
+	t.Run("should return copies of the cached addresses", func(t *testing.T) {
+		r := &fakeResolver{hosts: map[string][]string{"example.com": {"192.0.2.1"}}}
+		c := New(r, 10)
+		defer c.Close()
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				addrs, _ := c.LookupHost(context.Background(), "example.com")
+				addrs[0] = "changed"
+			}()
+		}
+		wg.Wait()
+
+		addrs, _ := c.LookupHost(context.Background(), "example.com")
+		if !reflect.DeepEqual([]string{"192.0.2.1"}, addrs) {
+			t.Errorf("Expected %v; Actual = %v", []string{"192.0.2.1"}, addrs)
+		}
+
+		c.Forget("example.com")
+		lookups := r.lookups.Load()
+		c.LookupHost(context.Background(), "example.com")
+		if !reflect.DeepEqual(lookups+1, r.lookups.Load()) {
+			t.Errorf("Expected %v; Actual = %v", lookups+1, r.lookups.Load())
+		}
+	})
+}
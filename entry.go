@@ -0,0 +1,159 @@
+package lru
+
+import (
+	"slices"
+	"time"
+)
+
+// Entry is a copy of a key-value pair stored in a cache along with its metadata.
+type Entry[K comparable, V any] struct {
+	Key            K         // Key of the item.
+	Value          V         // Value of the item.
+	CreatedAt      time.Time // When the item was added to the cache, replacing its value does not reset it.
+	LastAccessedAt time.Time // When the item was last read with Get or a similar method, zero if it never was.
+	ExpiresAt      time.Time // When the item expires, zero when it never expires.
+	AccessCount    uint64    // Number of times the item was read with Get or a similar method.
+	Tags           []string  // Tags attached to the item with SetWithTags, sorted.
+	Version        uint64    // Version of the value, see GetWithVersion.
+	DependsOn      []K       // Keys the item depends on, declared with SetWithDeps.
+}
+
+// GetEntry returns a copy of the item stored for the provided key along with its metadata,
+// without promoting it or counting the lookup as an access.
+// If the key is not found in the cache, or its TTL has passed, an empty entry and boolean false are returned.
+//
+// With WithAccessBuffer, buffered accesses are applied first; they are timed as of the moment they were applied,
+// and accesses dropped because the buffer was full are not counted.
+//
+// Example usage:
+//
+//	if e, ok := cache.GetEntry("myKey"); ok {
+//		fmt.Println(time.Since(e.CreatedAt), e.AccessCount)
+//	}
+func (l *lru[K, V]) GetEntry(key K) (Entry[K, V], bool) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.drainAccesses()
+
+	c, ok := l.lookup(key)
+	if !ok {
+		return Entry[K, V]{}, false
+	}
+
+	out := c.entry()
+	out.DependsOn = l.dependsOn(key)
+
+	return out, true
+}
+
+// entry returns a copy of the item along with its metadata.
+func (c *cache[K, V]) entry() Entry[K, V] {
+	out := Entry[K, V]{Key: c.key, Value: c.value, ExpiresAt: c.ttl, AccessCount: c.hits, Tags: slices.Clone(c.tags), Version: c.version}
+	if c.created != 0 {
+		out.CreatedAt = time.Unix(0, c.created)
+	}
+	if c.accessed != 0 {
+		out.LastAccessedAt = time.Unix(0, c.accessed)
+	}
+
+	return out
+}
+
+// Snapshot returns a consistent copy of the unexpired items in the LRU cache along with their metadata,
+// taken under the lock, ordered from the most recently used to the least recently used.
+// An empty cache returns an empty slice.
+func (l *lru[K, V]) Snapshot() []Entry[K, V] {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	return l.entries()
+}
+
+// entries returns a copy of the unexpired items along with their metadata, the caller holds the lock.
+func (l *lru[K, V]) entries() []Entry[K, V] {
+	l.drainAccesses()
+
+	out := make([]Entry[K, V], 0, l.length)
+	for h := l.front(); h != nil; h = l.next(h) {
+		if l.stale(h) {
+			continue
+		}
+		e := h.entry()
+		e.DependsOn = l.dependsOn(h.key)
+		out = append(out, e)
+	}
+
+	return out
+}
+
+// GetEntry returns a copy of the item stored for the provided key in its shard along with its metadata,
+// without promoting it.
+func (s *sharded[K, V]) GetEntry(key K) (Entry[K, V], bool) {
+	return s.shard(key).GetEntry(key)
+}
+
+// Snapshot returns a consistent copy of the unexpired items of every shard,
+// ordered from the most recently used to the least recently used within each shard, shard after shard:
+// recency is tracked per shard, so items of different shards are not ordered against each other.
+// Every shard is locked until all of them are copied, so the copy reflects a single point in time.
+func (s *sharded[K, V]) Snapshot() []Entry[K, V] {
+	for _, sh := range s.shards {
+		sh.locker.Lock()
+	}
+	defer func() {
+		for _, sh := range s.shards {
+			sh.locker.Unlock()
+		}
+	}()
+
+	out := []Entry[K, V]{}
+	for _, sh := range s.shards {
+		out = append(out, sh.entries()...)
+	}
+
+	return out
+}
+
+// GetEntry returns a copy of the item stored for the provided key in either tier along with its metadata,
+// without promoting it. Only the key and the value are set for items of a second tier which provides no metadata.
+func (t *tiered[K, V]) GetEntry(key K) (Entry[K, V], bool) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	if e, ok := t.hot.GetEntry(key); ok {
+		return e, true
+	}
+
+	if c, ok := t.cold.(interface {
+		GetEntry(key K) (Entry[K, V], bool)
+	}); ok {
+		return c.GetEntry(key)
+	}
+
+	if value, ok := t.cold.Peek(key); ok {
+		return Entry[K, V]{Key: key, Value: value}, true
+	}
+
+	return Entry[K, V]{}, false
+}
+
+// Snapshot returns a consistent copy of the items in both tiers, in the same order as Keys.
+// Metadata is included for the tiers which provide it.
+func (t *tiered[K, V]) Snapshot() []Entry[K, V] {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	out := t.hot.Snapshot()
+	if s, ok := t.cold.(interface{ Snapshot() []Entry[K, V] }); ok {
+		return append(out, s.Snapshot()...)
+	}
+
+	for key, value := range t.cold.All() {
+		out = append(out, Entry[K, V]{Key: key, Value: value})
+	}
+
+	return out
+}
@@ -0,0 +1,176 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSnapshotEntries(t *testing.T) {
+	t.Run("should return an empty snapshot of an empty cache", func(t *testing.T) {
+		l := New[int, int](3)
+
+		if !reflect.DeepEqual([]Entry[int, int]{}, l.Snapshot()) {
+			t.Errorf("Expected %v; Actual = %v", []Entry[int, int]{}, l.Snapshot())
+		}
+	})
+
+	t.Run("should return the items with their expiry times in recency order", func(t *testing.T) {
+		l := NewWithExpiry[int, int](3)
+		defer l.Close()
+
+		expiresAt := time.Now().Add(time.Hour)
+		l.SetWithTTL(1, 1, time.Until(expiresAt))
+		l.Set(2, 2)
+		l.SetWithTTL(3, 3, time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+
+		actual := l.Snapshot()
+		if !reflect.DeepEqual(2, len(actual)) {
+			t.Fatalf("Expected 2; Actual = %v", len(actual))
+		}
+
+		if actual[0].Key != 2 || actual[0].Value != 2 || !actual[0].ExpiresAt.IsZero() {
+			t.Errorf("Expected key 2 never expiring; Actual = %v", actual[0])
+		}
+
+		if actual[1].Key != 1 || actual[1].ExpiresAt.Sub(expiresAt).Abs() > time.Second {
+			t.Errorf("Expected key 1 expiring at %v; Actual = %v", expiresAt, actual[1])
+		}
+	})
+
+	t.Run("should return a copy unaffected by later changes", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](1), NewLFU[int, int](1))
+		defer l.Close()
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		actual := l.Snapshot()
+		l.Purge()
+
+		actual[0].CreatedAt = time.Time{}
+		expected := []Entry[int, int]{{Key: 2, Value: 2, Version: 2}, {Key: 1, Value: 1}}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("Expected %v; Actual = %v", expected, actual)
+		}
+	})
+
+	t.Run("should copy every shard at once", func(t *testing.T) {
+		l := NewSharded[int, int](4096, 16)
+		for i := 0; i < 1024; i++ {
+			l.Set(i, 0)
+		}
+
+		// every round writes the keys in order, so at any point in time the values drop by one at most once
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for round := 1; ; round++ {
+				for i := 0; i < 1024; i++ {
+					select {
+					case <-stop:
+						return
+					default:
+						l.Set(i, round)
+					}
+				}
+			}
+		}()
+
+		for n := 0; n < 200; n++ {
+			values := make([]int, 1024)
+			for _, e := range l.Snapshot() {
+				values[e.Key] = e.Value
+			}
+
+			for i := 1; i < len(values); i++ {
+				if values[i] > values[i-1] || values[0]-values[i] > 1 {
+					t.Fatalf("Expected the values of a single point in time; Actual = %v", values)
+				}
+			}
+		}
+
+		close(stop)
+		<-done
+	})
+}
+
+func TestGetEntry(t *testing.T) {
+	t.Run("should return the metadata of an item", func(t *testing.T) {
+		l := New[int, int](3)
+
+		before := time.Now()
+		l.Set(1, 1)
+		l.Get(1)
+		l.Get(1)
+		l.Set(1, 10)
+
+		actual, ok := l.GetEntry(1)
+		if !ok || actual.Value != 10 || actual.AccessCount != 2 {
+			t.Errorf("Expected value 10 read twice; Actual = %v", actual)
+		}
+
+		if actual.CreatedAt.Before(before) || actual.LastAccessedAt.Before(actual.CreatedAt) || !actual.ExpiresAt.IsZero() {
+			t.Errorf("Expected creation before last access and no expiry; Actual = %v", actual)
+		}
+	})
+
+	t.Run("should not promote or count the lookup", func(t *testing.T) {
+		l := New[int, int](3)
+		l.Set(1, 1)
+		l.Set(2, 2)
+
+		l.GetEntry(1)
+		actual, _ := l.GetEntry(1)
+
+		if !reflect.DeepEqual(uint64(0), actual.AccessCount) || !actual.LastAccessedAt.IsZero() {
+			t.Errorf("Expected no access; Actual = %v", actual)
+		}
+
+		if !reflect.DeepEqual([]int{2, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{2, 1}, l.Keys())
+		}
+	})
+
+	t.Run("should count buffered accesses", func(t *testing.T) {
+		l := New[int, int](3, WithAccessBuffer[int, int](8))
+		l.Set(1, 1)
+		l.Get(1)
+		l.Get(1)
+
+		actual, _ := l.GetEntry(1)
+		if !reflect.DeepEqual(uint64(2), actual.AccessCount) || actual.LastAccessedAt.IsZero() {
+			t.Errorf("Expected 2 accesses; Actual = %v", actual)
+		}
+	})
+
+	t.Run("should report missing and expired keys", func(t *testing.T) {
+		l := NewWithExpiry[int, int](3)
+		defer l.Close()
+
+		l.SetWithTTL(1, 1, time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+
+		if _, ok := l.GetEntry(1); ok {
+			t.Errorf("Expected expired key to be missing")
+		}
+
+		if _, ok := l.GetEntry(2); ok {
+			t.Errorf("Expected missing key to be missing")
+		}
+	})
+
+	t.Run("should look up both tiers", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](1), New[int, int](1))
+		defer l.Close()
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+
+		actual, ok := l.GetEntry(1)
+		if !ok || actual.Value != 1 || actual.CreatedAt.IsZero() {
+			t.Errorf("Expected key 1 from the second tier; Actual = %v", actual)
+		}
+	})
+}
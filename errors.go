@@ -0,0 +1,20 @@
+package lru
+
+import "errors"
+
+// ErrInvalidSize is returned when a cache is created with a size it cannot honour.
+var ErrInvalidSize = errors.New("lru: size must not be negative")
+
+// ErrClosed is returned when writing to a cache which was closed.
+var ErrClosed = errors.New("lru: cache is closed")
+
+// ErrNotFound is returned by GetE for a key which is not cached,
+// and by a write-behind cache loading a key whose deletion has not reached the store yet.
+var ErrNotFound = errors.New("lru: key not found")
+
+// ErrFull is returned by SetE when the item does not fit in the cache,
+// such as a new key in a full cache created with WithRejectWhenFull.
+var ErrFull = errors.New("lru: cache is full")
+
+// ErrExpired is returned by GetE for a key whose TTL has passed.
+var ErrExpired = errors.New("lru: key expired")
@@ -0,0 +1,132 @@
+package lru
+
+import (
+	"sync"
+	"time"
+)
+
+// Reason describes why a key-value pair left the cache or was replaced.
+type Reason int
+
+const (
+	// Evicted means the item was removed to make room for other items.
+	Evicted Reason = iota + 1
+
+	// Expired means the item was removed because its TTL passed.
+	Expired
+
+	// Deleted means the item was removed explicitly with Del or Purge.
+	Deleted
+
+	// Replaced means the item's value was overwritten by a Set of the same key.
+	Replaced
+)
+
+// String returns the name of the reason.
+func (r Reason) String() string {
+	switch r {
+	case Evicted:
+		return "evicted"
+	case Expired:
+		return "expired"
+	case Deleted:
+		return "deleted"
+	case Replaced:
+		return "replaced"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a key-value pair which left the cache or was replaced.
+type Event[K comparable, V any] struct {
+	Key    K         // Key of the item.
+	Value  V         // Value the item held when the event happened.
+	Reason Reason    // Why the item left the cache or was replaced.
+	Time   time.Time // When the event happened.
+}
+
+// dispatcher delivers events to a channel from a dedicated goroutine,
+// so publishing an event never blocks the cache while its lock is held.
+// Events are queued until the consumer receives them.
+type dispatcher[K comparable, V any] struct {
+	out        chan Event[K, V] // Channel events are delivered to.
+	queue      []Event[K, V]    // Events waiting to be delivered.
+	closed     bool             // Flag set once the dispatcher is closed.
+	done       chan struct{}    // Channel closed to stop the delivery goroutine.
+	closeOnce  sync.Once        // Guards closing of the dispatcher.
+	cond       *sync.Cond       // Signals the delivery goroutine about new events.
+	sync.Mutex                  // Mutex guarding the queue.
+}
+
+// newDispatcher starts a dispatcher delivering events to a channel with the provided buffer size.
+func newDispatcher[K comparable, V any](buffer int) *dispatcher[K, V] {
+	if buffer < 0 {
+		buffer = 0
+	}
+
+	d := &dispatcher[K, V]{
+		out:  make(chan Event[K, V], buffer),
+		done: make(chan struct{}),
+	}
+	d.cond = sync.NewCond(&d.Mutex)
+
+	go d.run()
+
+	return d
+}
+
+// publish queues the provided event for delivery.
+func (d *dispatcher[K, V]) publish(e Event[K, V]) {
+	d.Mutex.Lock()
+	defer d.Mutex.Unlock()
+
+	if d.closed {
+		return
+	}
+
+	d.queue = append(d.queue, e)
+	d.cond.Signal()
+}
+
+// close stops the delivery goroutine and closes the events channel.
+// Events which were not delivered yet are dropped.
+func (d *dispatcher[K, V]) close() {
+	d.closeOnce.Do(func() {
+		d.Mutex.Lock()
+		d.closed = true
+		d.queue = nil
+		d.Mutex.Unlock()
+
+		close(d.done)
+		d.cond.Broadcast()
+	})
+}
+
+func (d *dispatcher[K, V]) run() {
+	defer close(d.out)
+
+	for {
+		d.Mutex.Lock()
+		for len(d.queue) == 0 && !d.closed {
+			d.cond.Wait()
+		}
+
+		if d.closed {
+			d.Mutex.Unlock()
+			return
+		}
+
+		batch := d.queue
+		d.queue = nil
+		d.Mutex.Unlock()
+
+		for _, e := range batch {
+			select {
+			case d.out <- e:
+			case <-d.done:
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func receive[K comparable, V any](t *testing.T, events <-chan Event[K, V], n int) []Event[K, V] {
+	t.Helper()
+
+	var out []Event[K, V]
+	for len(out) < n {
+		select {
+		case e := <-events:
+			out = append(out, e)
+		case <-time.After(time.Second):
+			t.Fatalf("Expected %d events; Actual = %d", n, len(out))
+		}
+	}
+
+	return out
+}
+
+func TestEvents(t *testing.T) {
+	t.Run("should deliver events with their reason", func(t *testing.T) {
+		l := NewWithExpiry[int, string](2, WithEvents[int, string](0))
+		defer l.Close()
+
+		l.Set(1, "one")
+		l.Set(2, "two")
+		l.Set(1, "uno")
+		l.Set(3, "three")
+		l.Del(1)
+		l.SetWithTTL(4, "four", -time.Second)
+		l.Get(4)
+
+		actual := [][3]any{}
+		for _, e := range receive(t, l.Events(), 4) {
+			if e.Time.IsZero() {
+				t.Errorf("Expected event time to be set")
+			}
+			actual = append(actual, [3]any{e.Key, e.Value, e.Reason})
+		}
+
+		expected := [][3]any{
+			{1, "one", Replaced},
+			{2, "two", Evicted},
+			{1, "uno", Deleted},
+			{4, "four", Expired},
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("Expected %v; Actual = %v", expected, actual)
+		}
+	})
+
+	t.Run("should close the channel on close", func(t *testing.T) {
+		l := New[int, int](2, WithEvents[int, int](0))
+
+		l.Set(1, 1)
+		l.Del(1)
+		l.Close()
+
+		select {
+		case _, ok := <-l.Events():
+			for ok {
+				_, ok = <-l.Events()
+			}
+		case <-time.After(time.Second):
+			t.Errorf("Expected events channel to be closed")
+		}
+	})
+
+	t.Run("should share one channel across shards", func(t *testing.T) {
+		l := NewSharded[int, int](8, 4, WithEvents[int, int](0))
+		defer l.Close()
+
+		for i := 0; i < 8; i++ {
+			l.Del(i)
+			l.Set(i, i)
+			l.Del(i)
+		}
+
+		receive(t, l.Events(), 8)
+	})
+
+	t.Run("should return nil channel when disabled", func(t *testing.T) {
+		if New[int, int](2).Events() != nil {
+			t.Errorf("Expected nil channel")
+		}
+	})
+}
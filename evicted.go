@@ -0,0 +1,66 @@
+package lru
+
+import "time"
+
+// SetEvicted adds or updates a key-value pair in the LRU cache like Set,
+// and returns the item evicted to make room for it, if any, so callers can react to the displacement,
+// such as writing the evicted item to a slower tier, without registering a callback with WithOnEvict.
+// When several items are evicted to fit the cost of the new one, the least recently used of them is returned.
+//
+// Example usage:
+//
+//	if key, value, evicted := cache.SetEvicted("key", "value"); evicted {
+//		disk.Write(key, value)
+//	}
+func (l *lru[K, V]) SetEvicted(key K, value V) (K, V, bool) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	var evictedKey K
+	var evictedVal V
+	var evicted bool
+	l.displaced = func(key K, value V) {
+		if !evicted {
+			evictedKey, evictedVal, evicted = key, value, true
+		}
+	}
+	defer func() {
+		l.displaced = nil
+	}()
+
+	var expiry time.Time
+	l.set(key, value, expiry)
+
+	return evictedKey, evictedVal, evicted
+}
+
+// SetEvicted adds or updates a key-value pair in the shard responsible for the key,
+// and returns the item of that shard evicted to make room for it, if any.
+func (s *sharded[K, V]) SetEvicted(key K, value V) (K, V, bool) {
+	return s.shard(key).SetEvicted(key, value)
+}
+
+// SetEvicted adds or updates a key-value pair in the first tier like Set,
+// and returns the item which left the cache to make room for it, if any.
+// Items demoted into the second tier stay in the cache, so only the item the second tier drops
+// to make room for a demoted one is returned, which is assumed to be its oldest.
+func (t *tiered[K, V]) SetEvicted(key K, value V) (K, V, bool) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	var evictedKey K
+	var evictedVal V
+	var evicted bool
+	if size := t.hot.Cap(); size > 0 && !t.hot.Contains(key) && t.hot.Len() >= size {
+		demoted, _, ok := t.hot.GetOldest()
+		if size := t.cold.Cap(); ok && size > 0 && !t.cold.Contains(demoted) && t.cold.Len() >= size {
+			evictedKey, evictedVal, evicted = t.coldOldest()
+		}
+	}
+
+	t.set(key, value, nil)
+
+	return evictedKey, evictedVal, evicted
+}
@@ -0,0 +1,70 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetEvicted(t *testing.T) {
+	t.Run("should return the evicted item", func(t *testing.T) {
+		l := New[int, string](2)
+
+		if _, _, evicted := l.SetEvicted(1, "one"); evicted {
+			t.Errorf("Expected no item to be evicted")
+		}
+		l.SetEvicted(2, "two")
+		l.Get(1)
+
+		key, value, evicted := l.SetEvicted(3, "three")
+		if !evicted || !reflect.DeepEqual(2, key) || !reflect.DeepEqual("two", value) {
+			t.Errorf("Expected %v %v; Actual = %v %v", 2, "two", key, value)
+		}
+
+		if _, _, evicted := l.SetEvicted(3, "tres"); evicted {
+			t.Errorf("Expected no item to be evicted on update")
+		}
+	})
+
+	t.Run("should return the least recently used item evicted for cost", func(t *testing.T) {
+		l := NewWithCost[int, int](10, 10, WithSizer[int](func(v int) int64 {
+			return int64(v)
+		}))
+		l.Set(4, 4)
+		l.Set(3, 3)
+		l.Set(2, 2)
+
+		key, _, evicted := l.SetEvicted(6, 6)
+		if !evicted || !reflect.DeepEqual(4, key) {
+			t.Errorf("Expected %v; Actual = %v", 4, key)
+		}
+		if !reflect.DeepEqual([]int{6, 2}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{6, 2}, l.Keys())
+		}
+	})
+
+	t.Run("should not report deleted items", func(t *testing.T) {
+		l := New[int, int](2)
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Del(1)
+
+		if _, _, evicted := l.SetEvicted(3, 3); evicted {
+			t.Errorf("Expected no item to be evicted")
+		}
+	})
+
+	t.Run("should return the item dropped by the second tier", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](1), New[int, int](1))
+		defer l.Close()
+
+		l.Set(1, 1)
+		if _, _, evicted := l.SetEvicted(2, 2); evicted {
+			t.Errorf("Expected item 1 to be demoted, not evicted")
+		}
+
+		key, value, evicted := l.SetEvicted(3, 3)
+		if !evicted || !reflect.DeepEqual(1, key) || !reflect.DeepEqual(1, value) {
+			t.Errorf("Expected %v %v; Actual = %v %v", 1, 1, key, value)
+		}
+	})
+}
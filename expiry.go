@@ -0,0 +1,82 @@
+package lru
+
+import (
+	"container/heap"
+	"time"
+)
+
+// expiryHeap is a min-heap of cache items ordered by their expiry time,
+// so the cleaner only needs to look at the items that are actually due.
+// Items stored without a TTL are never part of the heap.
+type expiryHeap[K comparable, V any] []*cache[K, V]
+
+func (h expiryHeap[K, V]) Len() int { return len(h) }
+
+func (h expiryHeap[K, V]) Less(i, j int) bool { return h[i].ttl.Before(h[j].ttl) }
+
+func (h expiryHeap[K, V]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *expiryHeap[K, V]) Push(x any) {
+	c := x.(*cache[K, V])
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *expiryHeap[K, V]) Pop() any {
+	old := *h
+	n := len(old)
+	c := old[n-1]
+	old[n-1] = nil
+	c.index = -1
+	*h = old[:n-1]
+
+	return c
+}
+
+// track updates the position of the provided item in the expiry heap after its TTL changed,
+// adding or removing it as the item gains or loses a TTL, or is unpinned or pinned.
+func (l *lru[K, V]) track(c *cache[K, V]) {
+	switch {
+	case (c.ttl.IsZero() || c.held()) && c.index >= 0:
+		heap.Remove(&l.expiries, c.index)
+	case c.ttl.IsZero() || c.held():
+	case c.index >= 0:
+		heap.Fix(&l.expiries, c.index)
+	default:
+		heap.Push(&l.expiries, c)
+	}
+}
+
+// untrack removes the provided item from the expiry heap.
+func (l *lru[K, V]) untrack(c *cache[K, V]) {
+	if c.index >= 0 {
+		heap.Remove(&l.expiries, c.index)
+	}
+}
+
+// slide extends the TTL of the provided item accessed at the provided time in Unix nanoseconds
+// by the sliding TTL configured with WithSlidingTTL. TTLs ending later than that are kept.
+func (l *lru[K, V]) slide(c *cache[K, V], accessed int64) {
+	if l.slidingTTL <= 0 || c.ttl.IsZero() {
+		return
+	}
+
+	if expiry := time.Unix(0, accessed).Add(l.slidingTTL); expiry.After(c.ttl) {
+		c.ttl = expiry
+		l.track(c)
+	}
+}
+
+// removeExpired deletes every item whose TTL has passed,
+// visiting only expired items rather than the whole cache.
+// Buffered accesses are applied first, so they extend sliding TTLs before they are checked.
+func (l *lru[K, V]) removeExpired() {
+	l.drainAccesses()
+	for len(l.expiries) > 0 && l.expired(l.expiries[0]) {
+		l.expire(l.expiries[0].key)
+	}
+}
@@ -0,0 +1,191 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExpiryHeap(t *testing.T) {
+	t.Run("should only remove items that are due", func(t *testing.T) {
+		l := newLRU[int, int](5, true, nil)
+
+		l.SetWithTTL(1, 1, time.Minute)
+		l.SetWithTTL(2, 2, -time.Second)
+		l.Set(3, 3)
+		l.SetWithTTL(4, 4, -time.Minute)
+		l.SetWithTTL(5, 5, time.Hour)
+
+		l.removeExpired()
+
+		expected := []int{5, 3, 1}
+		if !reflect.DeepEqual(expected, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+		}
+
+		if !reflect.DeepEqual(2, len(l.expiries)) {
+			t.Errorf("Expected 2; Actual = %v", len(l.expiries))
+		}
+	})
+
+	t.Run("should keep heap in sync with TTL changes and deletes", func(t *testing.T) {
+		l := newLRU[int, int](5, true, nil)
+
+		l.SetWithTTL(1, 1, time.Minute)
+		l.SetWithTTL(2, 2, time.Hour)
+		l.SetWithTTL(1, 1, 2*time.Hour)
+		l.Set(2, 2)
+
+		if !reflect.DeepEqual(1, len(l.expiries)) || l.expiries[0].key != 1 {
+			t.Errorf("Expected only key 1 to be tracked; Actual = %v", len(l.expiries))
+		}
+
+		l.Del(1)
+		if !reflect.DeepEqual(0, len(l.expiries)) {
+			t.Errorf("Expected 0; Actual = %v", len(l.expiries))
+		}
+	})
+}
+
+func TestSlidingTTL(t *testing.T) {
+	t.Run("should keep items alive while they are read", func(t *testing.T) {
+		l := NewWithExpiry[int, int](3, WithSlidingTTL[int, int](50*time.Millisecond))
+		defer l.Close()
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		for i := 0; i < 4; i++ {
+			time.Sleep(20 * time.Millisecond)
+			if _, ok := l.Get(1); !ok {
+				t.Fatalf("Expected key 1 to stay alive after %v reads", i)
+			}
+		}
+
+		if _, ok := l.Peek(2); ok {
+			t.Errorf("Expected idle key 2 to expire")
+		}
+	})
+
+	t.Run("should not be extended by peeks", func(t *testing.T) {
+		l := NewWithExpiry[int, int](3, WithSlidingTTL[int, int](30*time.Millisecond))
+		defer l.Close()
+
+		l.Set(1, 1)
+		time.Sleep(20 * time.Millisecond)
+		l.Peek(1)
+		time.Sleep(20 * time.Millisecond)
+
+		if _, ok := l.Get(1); ok {
+			t.Errorf("Expected key 1 to expire")
+		}
+	})
+
+	t.Run("should not shorten longer explicit TTLs", func(t *testing.T) {
+		l := newLRU[int, int](3, true, []Option[int, int]{WithSlidingTTL[int, int](time.Minute)})
+
+		l.SetWithTTL(1, 1, time.Hour)
+		l.Get(1)
+
+		if remaining := time.Until(l.cache[1].ttl); remaining <= time.Minute {
+			t.Errorf("Expected TTL of an hour; Actual = %v", remaining)
+		}
+	})
+
+	t.Run("should extend TTLs when buffered accesses are applied", func(t *testing.T) {
+		l := newLRU[int, int](3, true, []Option[int, int]{WithSlidingTTL[int, int](time.Minute), WithAccessBuffer[int, int](8)})
+
+		l.SetWithTTL(1, 1, time.Millisecond)
+		l.Get(1)
+		time.Sleep(5 * time.Millisecond)
+		l.removeExpired()
+
+		if !reflect.DeepEqual([]int{1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{1}, l.Keys())
+		}
+	})
+}
+
+func TestUpdateTTL(t *testing.T) {
+	t.Run("should return the remaining TTL", func(t *testing.T) {
+		l := NewWithExpiry[int, int](3)
+		defer l.Close()
+
+		l.SetWithTTL(1, 1, time.Minute)
+		l.Set(2, 2)
+
+		if ttl, ok := l.GetTTL(1); !ok || ttl <= 59*time.Second || ttl > time.Minute {
+			t.Errorf("Expected about a minute; Actual = %v", ttl)
+		}
+
+		if ttl, ok := l.GetTTL(2); !ok || ttl != 0 {
+			t.Errorf("Expected 0; Actual = %v", ttl)
+		}
+
+		if _, ok := l.GetTTL(3); ok {
+			t.Errorf("Expected missing key to be missing")
+		}
+	})
+
+	t.Run("should renew the TTL without touching the value or the order", func(t *testing.T) {
+		l := NewWithExpiry[int, int](3)
+		defer l.Close()
+
+		l.SetWithTTL(1, 1, 20*time.Millisecond)
+		l.Set(2, 2)
+
+		if !l.UpdateTTL(1, time.Hour) {
+			t.Fatalf("Expected key 1 to be found")
+		}
+		time.Sleep(30 * time.Millisecond)
+
+		if value, ok := l.Peek(1); !ok || value != 1 {
+			t.Errorf("Expected 1; Actual = %v", value)
+		}
+
+		if !reflect.DeepEqual([]int{2, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{2, 1}, l.Keys())
+		}
+	})
+
+	t.Run("should expire items whose TTL was shortened", func(t *testing.T) {
+		l := newLRU[int, int](3, true, nil)
+
+		l.Set(1, 1)
+		l.UpdateTTL(1, -time.Second)
+		l.removeExpired()
+
+		if !reflect.DeepEqual(0, l.Len()) {
+			t.Errorf("Expected 0; Actual = %v", l.Len())
+		}
+
+		if l.UpdateTTL(1, time.Minute) {
+			t.Errorf("Expected expired key to be missing")
+		}
+	})
+}
+
+func TestSetWithDeadline(t *testing.T) {
+	t.Run("should expire items at the deadline", func(t *testing.T) {
+		l := NewWithExpiry[int, int](3)
+		defer l.Close()
+
+		deadline := time.Now().Add(20 * time.Millisecond)
+		l.SetWithDeadline(1, 1, deadline)
+		l.SetWithDeadline(2, 2, time.Now().Add(-time.Second))
+		l.SetWithDeadline(3, 3, time.Time{})
+
+		if actual := l.Snapshot(); len(actual) != 2 || !actual[1].ExpiresAt.Equal(deadline) {
+			t.Errorf("Expected key 1 expiring at %v; Actual = %v", deadline, actual)
+		}
+
+		time.Sleep(30 * time.Millisecond)
+
+		if _, ok := l.Get(1); ok {
+			t.Errorf("Expected key 1 to expire")
+		}
+
+		if _, ok := l.Get(3); !ok {
+			t.Errorf("Expected key 3 to never expire")
+		}
+	})
+}
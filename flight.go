@@ -0,0 +1,61 @@
+package lru
+
+import (
+	"errors"
+	"sync"
+)
+
+// errFlightPanicked is returned to callers waiting on a computation which panicked.
+var errFlightPanicked = errors.New("lru: value computation panicked")
+
+// call is an in-flight computation of the value of a key.
+type call[V any] struct {
+	wg    sync.WaitGroup // Released once the computation completes.
+	value V              // Computed value.
+	err   error          // Error returned by the computation.
+}
+
+// flight de-duplicates concurrent computations of the same key,
+// so only one computation per key runs and every other caller waits for its result.
+// The zero value is ready to use.
+type flight[K comparable, V any] struct {
+	calls      map[K]*call[V] // Computations in flight keyed by the key they compute.
+	sync.Mutex                // Mutex guarding the calls map.
+}
+
+// do runs fn for the provided key unless a computation for the same key is already in flight,
+// in which case it waits for that computation and returns its result instead.
+func (f *flight[K, V]) do(key K, fn func() (V, error)) (V, error) {
+	f.Mutex.Lock()
+	if f.calls == nil {
+		f.calls = map[K]*call[V]{}
+	}
+
+	if c, ok := f.calls[key]; ok {
+		f.Mutex.Unlock()
+		c.wg.Wait()
+		return c.value, c.err
+	}
+
+	c := &call[V]{}
+	c.wg.Add(1)
+	f.calls[key] = c
+	f.Mutex.Unlock()
+
+	completed := false
+	defer func() {
+		if !completed {
+			c.err = errFlightPanicked
+		}
+
+		f.Mutex.Lock()
+		delete(f.calls, key)
+		f.Mutex.Unlock()
+		c.wg.Done()
+	}()
+
+	c.value, c.err = fn()
+	completed = true
+
+	return c.value, c.err
+}
@@ -0,0 +1,94 @@
+package lru
+
+// Freeze pauses capacity eviction and expiry until Thaw is called, for example during a bulk migration.
+// While frozen, Set grows the cache past its capacity and maximum cost instead of evicting,
+// items whose TTL passes are still served, and neither the cleaner nor memory pressure removes anything.
+// Explicit removals such as Del, Purge and RemoveOldest still work.
+//
+// Example usage:
+//
+//	cache.Freeze()
+//	for _, user := range users {
+//		cache.Set(user.ID, user)
+//	}
+//	cache.Thaw()
+func (l *lru[K, V]) Freeze() {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.frozen = true
+}
+
+// Thaw resumes capacity eviction and expiry paused by Freeze. The items whose TTL passed while the cache was frozen
+// are removed, then the least recently used items are evicted until the cache fits its capacity and maximum cost again.
+// Calling Thaw on a cache which is not frozen has no effect.
+func (l *lru[K, V]) Thaw() {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	if !l.frozen {
+		return
+	}
+
+	l.frozen = false
+	l.removeExpired()
+	for l.size != Unbounded && l.length > max(l.size, 0) && l.evictOldest() {
+	}
+	l.evictOverCost()
+}
+
+// Freeze pauses capacity eviction and expiry in every shard until Thaw is called.
+func (s *sharded[K, V]) Freeze() {
+	for _, shard := range s.shards {
+		shard.Freeze()
+	}
+}
+
+// Thaw resumes capacity eviction and expiry in every shard, evicting until every shard fits its capacity again.
+func (s *sharded[K, V]) Thaw() {
+	for _, shard := range s.shards {
+		shard.Thaw()
+	}
+}
+
+// freezer is a cache which can pause and resume eviction and expiry.
+type freezer interface {
+	Freeze()
+	Thaw()
+}
+
+// Freeze pauses capacity eviction and expiry in both tiers until Thaw is called.
+// The first tier keeps every item, so nothing is demoted into the second tier either.
+// A second tier which cannot be frozen keeps evicting for capacity.
+func (t *tiered[K, V]) Freeze() {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	t.frozen = true
+	t.hot.Freeze()
+	if cold, ok := t.cold.(freezer); ok {
+		cold.Freeze()
+	}
+}
+
+// Thaw resumes capacity eviction and expiry in both tiers.
+// The least recently used items of the first tier are demoted into the second tier until it fits its capacity again.
+func (t *tiered[K, V]) Thaw() {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	if !t.frozen {
+		return
+	}
+
+	t.frozen = false
+	if cold, ok := t.cold.(freezer); ok {
+		cold.Thaw()
+	}
+	if size := t.hot.Cap(); size > 0 {
+		for n := t.hot.Len() - size; n > 0; n-- {
+			t.demote()
+		}
+	}
+	t.hot.Thaw()
+}
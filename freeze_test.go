@@ -0,0 +1,85 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFreeze(t *testing.T) {
+	t.Run("should pause eviction and expiry until thawed", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		l := NewWithExpiry[int, int](2, WithClock[int, int](clock))
+		defer l.Close()
+
+		l.SetWithTTL(1, 1, time.Minute)
+		l.Freeze()
+		l.Set(2, 2)
+		l.Set(3, 3)
+		l.Set(4, 4)
+		clock.Advance(time.Hour)
+
+		if value, ok := l.Get(1); !ok || !reflect.DeepEqual(1, value) {
+			t.Errorf("Expected %v; Actual = %v %v", 1, value, ok)
+		}
+		if !reflect.DeepEqual(4, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 4, l.Len())
+		}
+
+		l.Thaw()
+
+		if !reflect.DeepEqual([]int{4, 3}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{4, 3}, l.Keys())
+		}
+		if stats := l.Stats(); !reflect.DeepEqual([]uint64{1, 1}, []uint64{stats.Expirations, stats.Evictions}) {
+			t.Errorf("Expected %v; Actual = %v", []uint64{1, 1}, []uint64{stats.Expirations, stats.Evictions})
+		}
+
+		l.Set(5, 5)
+		if !reflect.DeepEqual([]int{5, 4}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{5, 4}, l.Keys())
+		}
+	})
+
+	t.Run("should thaw every shard", func(t *testing.T) {
+		l := NewSharded[int, int](4, 2)
+		defer l.Close()
+
+		l.Freeze()
+		for i := 0; i < 10; i++ {
+			l.Set(i, i)
+		}
+		if !reflect.DeepEqual(10, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 10, l.Len())
+		}
+
+		l.Thaw()
+		for _, sh := range l.(*sharded[int, int]).shards {
+			if sh.Len() > sh.Cap() {
+				t.Errorf("Expected at most %v items; Actual = %v", sh.Cap(), sh.Len())
+			}
+		}
+	})
+
+	t.Run("should demote the overflow of the first tier on thaw", func(t *testing.T) {
+		cold := New[int, int](10)
+		l := NewTiered[int, int](New[int, int](2), cold)
+		defer l.Close()
+
+		l.Freeze()
+		for i := 0; i < 5; i++ {
+			l.Set(i, i)
+		}
+		if !reflect.DeepEqual(0, cold.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 0, cold.Len())
+		}
+
+		l.Thaw()
+		if !reflect.DeepEqual([]int{4, 3}, l.(*tiered[int, int]).hot.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{4, 3}, l.(*tiered[int, int]).hot.Keys())
+		}
+		if !reflect.DeepEqual(5, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 5, l.Len())
+		}
+	})
+}
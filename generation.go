@@ -0,0 +1,66 @@
+package lru
+
+// InvalidateAll removes every item from the cache in constant time, without walking the items under the lock like Purge.
+// It starts a new generation of the cache: the items of older generations are treated as missing from then on,
+// and are reclaimed lazily, when they are looked up or when room is made for new items.
+// Reclaimed items are reported to listeners as deleted, at the time they are reclaimed.
+// Len and the snapshots of the cache no longer count them, but their cost is counted until they are reclaimed.
+// Dependencies declared with SetWithDeps are forgotten.
+//
+// Example usage:
+//
+//	// a new deployment of the catalog invalidates every cached page
+//	pages.InvalidateAll()
+func (l *lru[K, V]) InvalidateAll() {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.drainAccesses()
+
+	l.generation++
+	l.invalidated = l.length
+	l.failures = nil
+	if l.dependencies != nil {
+		l.dependencies.reset()
+	}
+}
+
+// outdated reports whether the provided item was written before the last InvalidateAll.
+func (l *lru[K, V]) outdated(c *cache[K, V]) bool {
+	return c.generation != l.generation
+}
+
+// reclaim removes every item invalidated by InvalidateAll.
+// They are all at the back of the list, as none of them was written or promoted since.
+func (l *lru[K, V]) reclaim() {
+	for c := l.back(); c != nil && l.outdated(c); c = l.back() {
+		l.remove(c.key, Deleted)
+	}
+}
+
+// InvalidateAll removes every item from every shard in constant time, see the InvalidateAll of an LRU cache.
+func (s *sharded[K, V]) InvalidateAll() {
+	for _, shard := range s.shards {
+		shard.InvalidateAll()
+	}
+}
+
+// generational is a cache which can remove every item in constant time.
+type generational interface {
+	InvalidateAll()
+}
+
+// InvalidateAll removes every item from both tiers. The first tier is invalidated in constant time,
+// as is the second tier if it supports InvalidateAll, otherwise it is purged.
+func (t *tiered[K, V]) InvalidateAll() {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	t.hot.InvalidateAll()
+	if cold, ok := t.cold.(generational); ok {
+		cold.InvalidateAll()
+		return
+	}
+
+	t.cold.Purge()
+}
@@ -0,0 +1,88 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInvalidateAll(t *testing.T) {
+	t.Run("should treat the items of older generations as missing", func(t *testing.T) {
+		var deleted []int
+		l := New[int, int](3, WithOnEvict[int, int](func(key int, _ int, reason Reason) {
+			if reason == Deleted {
+				deleted = append(deleted, key)
+			}
+		}))
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Pin(2)
+
+		l.InvalidateAll()
+
+		if !reflect.DeepEqual(0, l.Len()) || l.Contains(1) || !reflect.DeepEqual([]int{}, l.Keys()) {
+			t.Errorf("Expected an empty cache; Actual = %v %v", l.Len(), l.Keys())
+		}
+		if _, ok := l.Get(2); ok {
+			t.Errorf("Expected the pinned item to be invalidated too")
+		}
+		if !reflect.DeepEqual([]int{2}, deleted) {
+			t.Errorf("Expected %v; Actual = %v", []int{2}, deleted)
+		}
+
+		l.Set(1, 10)
+		if value, ok := l.Get(1); !ok || !reflect.DeepEqual(10, value) || !reflect.DeepEqual(1, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v %v", 10, value, ok)
+		}
+	})
+
+	t.Run("should reclaim invalidated items before evicting", func(t *testing.T) {
+		l := New[int, int](2)
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.InvalidateAll()
+
+		l.Set(3, 3)
+		l.Set(4, 4)
+
+		if !reflect.DeepEqual([]int{4, 3}, l.Keys()) || !reflect.DeepEqual(uint64(0), l.Stats().Evictions) {
+			t.Errorf("Expected %v; Actual = %v", []int{4, 3}, l.Keys())
+		}
+		if l.Del(1) {
+			t.Errorf("Expected %v to be missing", 1)
+		}
+	})
+
+	t.Run("should ignore buffered accesses of invalidated items", func(t *testing.T) {
+		l := New[int, int](3, WithAccessBuffer[int, int](8)).(*lru[int, int])
+		l.Set(1, 1)
+		l.Set(2, 2)
+
+		// an access read before InvalidateAll and recorded after it
+		c := l.cache[1]
+		l.InvalidateAll()
+		l.accesses.record(c, c.reuses)
+
+		l.Set(3, 3)
+		l.EvictionCandidates(1)
+
+		if !reflect.DeepEqual([]int{3}, l.Keys()) || !reflect.DeepEqual([]int{3}, l.Values()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3}, l.Keys())
+		}
+	})
+
+	t.Run("should invalidate every shard and tier", func(t *testing.T) {
+		for _, l := range []LRU[int, int]{NewSharded[int, int](8, 2), NewTiered[int, int](New[int, int](2), NewLFU[int, int](4))} {
+			for i := 0; i < 4; i++ {
+				l.Set(i, i)
+			}
+
+			l.InvalidateAll()
+			for i := 0; i < 4; i++ {
+				if l.Contains(i) {
+					t.Errorf("Expected %v to be invalidated", i)
+				}
+			}
+			l.Close()
+		}
+	})
+}
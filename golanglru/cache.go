@@ -0,0 +1,212 @@
+// Package golanglru is a drop-in replacement for the Cache of github.com/hashicorp/golang-lru/v2,
+// backed by this module, so projects can migrate by swapping the import path:
+//
+//	import lru "github.com/vhndaree/lru/golanglru"
+//
+// Its method set and semantics match those of the original package: Add reports evictions,
+// Keys and Values are ordered from the oldest to the newest item, and the eviction callback
+// runs after the cache is unlocked, for every item which is evicted, removed or purged.
+package golanglru
+
+import (
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/vhndaree/lru"
+)
+
+// ErrInvalidSize is returned when a cache is created without a positive size.
+var ErrInvalidSize = errors.New("golanglru: must provide a positive size")
+
+// Cache is a thread-safe fixed size LRU cache.
+type Cache[K comparable, V any] struct {
+	cache        lru.LRU[K, V]        // Unlocked cache holding the items, guarded by the mutex.
+	onEvicted    func(key K, value V) // Callback invoked for every item leaving the cache, nil when disabled.
+	evicted      []eviction[K, V]     // Items which left the cache during the current call, pending for the callback.
+	sync.RWMutex                      // Mutex guarding the cache.
+}
+
+// eviction is an item which left the cache, pending for the eviction callback.
+type eviction[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// New creates an LRU cache of the provided size.
+func New[K comparable, V any](size int) (*Cache[K, V], error) {
+	return NewWithEvict[K, V](size, nil)
+}
+
+// NewWithEvict creates an LRU cache of the provided size, invoking the provided callback
+// for every item which is evicted, removed or purged. Replaced values are not reported.
+func NewWithEvict[K comparable, V any](size int, onEvicted func(key K, value V)) (*Cache[K, V], error) {
+	if size <= 0 {
+		return nil, ErrInvalidSize
+	}
+
+	c := &Cache[K, V]{onEvicted: onEvicted}
+	c.cache = lru.NewUnlocked[K, V](size, lru.WithOnEvict(func(key K, value V, reason lru.Reason) {
+		if c.onEvicted != nil && reason != lru.Replaced {
+			c.evicted = append(c.evicted, eviction[K, V]{key: key, value: value})
+		}
+	}))
+
+	return c, nil
+}
+
+// unlock releases the write lock and invokes the eviction callback for the items which left the cache meanwhile.
+func (c *Cache[K, V]) unlock() {
+	evicted := c.evicted
+	c.evicted = nil
+	c.Unlock()
+
+	for _, e := range evicted {
+		c.onEvicted(e.key, e.value)
+	}
+}
+
+// Purge is used to completely clear the cache.
+func (c *Cache[K, V]) Purge() {
+	c.Lock()
+	defer c.unlock()
+
+	c.cache.Purge()
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *Cache[K, V]) Add(key K, value V) (evicted bool) {
+	c.Lock()
+	defer c.unlock()
+
+	_, _, evicted = c.cache.SetEvicted(key, value)
+
+	return evicted
+}
+
+// Get looks up a key's value from the cache.
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	c.Lock()
+	defer c.unlock()
+
+	return c.cache.Get(key)
+}
+
+// Contains checks if a key is in the cache, without updating the recent-ness or deleting it for being stale.
+func (c *Cache[K, V]) Contains(key K) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.cache.Contains(key)
+}
+
+// Peek returns the key value (or undefined if not found) without updating the "recently used"-ness of the key.
+func (c *Cache[K, V]) Peek(key K) (value V, ok bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.cache.Peek(key)
+}
+
+// ContainsOrAdd checks if a key is in the cache without updating the recent-ness or deleting it for being stale,
+// and if not, adds the value. Returns whether found and whether an eviction occurred.
+func (c *Cache[K, V]) ContainsOrAdd(key K, value V) (ok, evicted bool) {
+	c.Lock()
+	defer c.unlock()
+
+	if c.cache.Contains(key) {
+		return true, false
+	}
+
+	_, _, evicted = c.cache.SetEvicted(key, value)
+
+	return false, evicted
+}
+
+// PeekOrAdd checks if a key is in the cache without updating the recent-ness or deleting it for being stale,
+// and if not, adds the value. Returns whether found and whether an eviction occurred.
+func (c *Cache[K, V]) PeekOrAdd(key K, value V) (previous V, ok, evicted bool) {
+	c.Lock()
+	defer c.unlock()
+
+	if previous, ok = c.cache.Peek(key); ok {
+		return previous, true, false
+	}
+
+	_, _, evicted = c.cache.SetEvicted(key, value)
+
+	return previous, false, evicted
+}
+
+// Remove removes the provided key from the cache.
+func (c *Cache[K, V]) Remove(key K) (present bool) {
+	c.Lock()
+	defer c.unlock()
+
+	return c.cache.Del(key)
+}
+
+// Resize changes the cache size.
+func (c *Cache[K, V]) Resize(size int) (evicted int) {
+	c.Lock()
+	defer c.unlock()
+
+	length := c.cache.Len()
+	c.cache.Resize(size)
+
+	return length - c.cache.Len()
+}
+
+// RemoveOldest removes the oldest item from the cache.
+func (c *Cache[K, V]) RemoveOldest() (key K, value V, ok bool) {
+	c.Lock()
+	defer c.unlock()
+
+	return c.cache.RemoveOldest()
+}
+
+// GetOldest returns the oldest entry.
+func (c *Cache[K, V]) GetOldest() (key K, value V, ok bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.cache.GetOldest()
+}
+
+// Keys returns a slice of the keys in the cache, from oldest to newest.
+func (c *Cache[K, V]) Keys() []K {
+	c.RLock()
+	defer c.RUnlock()
+
+	keys := c.cache.Keys()
+	slices.Reverse(keys)
+
+	return keys
+}
+
+// Values returns a slice of the values in the cache, from oldest to newest.
+func (c *Cache[K, V]) Values() []V {
+	c.RLock()
+	defer c.RUnlock()
+
+	values := c.cache.Values()
+	slices.Reverse(values)
+
+	return values
+}
+
+// Len returns the number of items in the cache.
+func (c *Cache[K, V]) Len() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.cache.Len()
+}
+
+// Cap returns the capacity of the cache.
+func (c *Cache[K, V]) Cap() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.cache.Cap()
+}
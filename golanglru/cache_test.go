@@ -0,0 +1,122 @@
+package golanglru
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("should reject non-positive sizes", func(t *testing.T) {
+		if _, err := New[int, int](0); !errors.Is(err, ErrInvalidSize) {
+			t.Errorf("Expected %v; Actual = %v", ErrInvalidSize, err)
+		}
+	})
+
+	t.Run("should report evictions of Add", func(t *testing.T) {
+		c, _ := New[int, int](2)
+
+		if c.Add(1, 1) || c.Add(2, 2) || c.Add(2, 20) {
+			t.Errorf("Expected no eviction")
+		}
+		if !c.Add(3, 3) {
+			t.Errorf("Expected an eviction")
+		}
+
+		if !reflect.DeepEqual([]int{2, 3}, c.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{2, 3}, c.Keys())
+		}
+		if !reflect.DeepEqual([]int{20, 3}, c.Values()) {
+			t.Errorf("Expected %v; Actual = %v", []int{20, 3}, c.Values())
+		}
+	})
+
+	t.Run("should add missing keys only", func(t *testing.T) {
+		c, _ := New[int, int](1)
+		c.Add(1, 1)
+
+		if ok, evicted := c.ContainsOrAdd(1, 10); !ok || evicted {
+			t.Errorf("Expected key 1 to be found")
+		}
+
+		previous, ok, _ := c.PeekOrAdd(1, 10)
+		if !ok || !reflect.DeepEqual(1, previous) {
+			t.Errorf("Expected %v; Actual = %v", 1, previous)
+		}
+
+		if ok, evicted := c.ContainsOrAdd(2, 2); ok || !evicted {
+			t.Errorf("Expected key 2 to be added with an eviction")
+		}
+	})
+
+	t.Run("should invoke the callback for evicted, removed and purged items", func(t *testing.T) {
+		var evicted []int
+		c, _ := NewWithEvict(2, func(key int, _ int) {
+			evicted = append(evicted, key)
+		})
+
+		c.Add(1, 1)
+		c.Add(2, 2)
+		c.Add(2, 20)
+		c.Add(3, 3)
+		c.Remove(2)
+		c.Add(4, 4)
+		c.Add(5, 5)
+		c.RemoveOldest()
+		c.Purge()
+
+		if !reflect.DeepEqual([]int{1, 2, 3, 4, 5}, evicted) {
+			t.Errorf("Expected %v; Actual = %v", []int{1, 2, 3, 4, 5}, evicted)
+		}
+	})
+
+	t.Run("should allow the callback to use the cache", func(t *testing.T) {
+		var c *Cache[int, int]
+		c, _ = NewWithEvict(1, func(key int, _ int) {
+			c.Contains(key)
+		})
+
+		c.Add(1, 1)
+		c.Add(2, 2)
+	})
+
+	t.Run("should resize the cache", func(t *testing.T) {
+		c, _ := New[int, int](3)
+		c.Add(1, 1)
+		c.Add(2, 2)
+		c.Add(3, 3)
+		c.Get(1)
+
+		if evicted := c.Resize(1); !reflect.DeepEqual(2, evicted) {
+			t.Errorf("Expected %v; Actual = %v", 2, evicted)
+		}
+
+		key, _, _ := c.GetOldest()
+		if !reflect.DeepEqual(1, key) || !reflect.DeepEqual(1, c.Cap()) || !reflect.DeepEqual(1, c.Len()) {
+			t.Errorf("Expected only key 1 to remain; Actual = %v", c.Keys())
+		}
+	})
+
+	t.Run("should be safe for concurrent use", func(t *testing.T) {
+		c, _ := New[int, int](10)
+
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range 100 {
+					c.Add(i*100+j, j)
+					c.Get(j)
+					c.Keys()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if !reflect.DeepEqual(10, c.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 10, c.Len())
+		}
+	})
+}
@@ -0,0 +1,308 @@
+package lru
+
+import (
+	"iter"
+	"sync"
+)
+
+// hashedItem is an item of a hashed cache, linked in the LRU order.
+type hashedItem[K, V any] struct {
+	key   K                 // Key associated with the cache item.
+	value V                 // Value associated with the cache item.
+	hash  uint64            // Hash of the key, indexing the bucket of the item.
+	prev  *hashedItem[K, V] // Previous, more recently used, item in the list.
+	next  *hashedItem[K, V] // Next, less recently used, item in the list.
+}
+
+// Hashed represents a Least Recently Used (LRU) cache of keys which are not comparable, such as []byte
+// or structs holding slices, which cannot be map keys. Keys are hashed with the function provided to NewHashed
+// into buckets, and the items of a bucket are told apart with the provided equality function.
+type Hashed[K, V any] struct {
+	buckets    map[uint64][]*hashedItem[K, V] // Items of every hash, usually only one.
+	root       hashedItem[K, V]               // Sentinel of the circular list, root.next is the most recently used item.
+	length     int                            // Current number of items in the cache.
+	size       int                            // Maximum number of items the cache can hold.
+	hash       func(K) uint64                 // Function hashing the keys.
+	equal      func(a, b K) bool              // Function reporting whether two keys are the same.
+	stats      Stats                          // Usage counters of the cache.
+	sync.Mutex                                // Lock for concurrent access.
+}
+
+// NewHashed creates a new instance of an LRU cache with the specified size whose keys need not be comparable.
+// hash must return the same value for keys which equal reports as the same; keys whose hashes collide
+// share a bucket and are told apart by equal, so a poor hash only slows the cache down.
+// Keys are stored as provided, so callers must not modify them afterwards.
+//
+// Example usage:
+//
+//	cache := lru.NewHashed[[]byte, User](1000, func(key []byte) uint64 {
+//		return maphash.Bytes(seed, key)
+//	}, bytes.Equal)
+func NewHashed[K, V any](size int, hash func(K) uint64, equal func(a, b K) bool) *Hashed[K, V] {
+	out := &Hashed[K, V]{
+		buckets: map[uint64][]*hashedItem[K, V]{},
+		size:    size,
+		hash:    hash,
+		equal:   equal,
+	}
+	out.root.next, out.root.prev = &out.root, &out.root
+
+	return out
+}
+
+// Contains checks if the provided key is present in the hashed cache.
+// The function does not affect the cache's state or modify any data.
+func (h *Hashed[K, V]) Contains(key K) bool {
+	h.Lock()
+	defer h.Unlock()
+
+	_, ok := h.lookup(key)
+	return ok
+}
+
+// Set adds or updates a key-value pair in the hashed cache.
+// If the key already exists in the cache, its value is updated and it becomes the most recently used item.
+// If the cache is full, the least recently used item is evicted.
+func (h *Hashed[K, V]) Set(key K, value V) {
+	h.Lock()
+	defer h.Unlock()
+
+	if item, ok := h.lookup(key); ok {
+		item.value = value
+		h.promote(item)
+		return
+	}
+
+	if h.size < 0 {
+		return
+	}
+
+	if h.size != Unbounded && h.length >= h.size {
+		h.evict()
+	}
+
+	item := &hashedItem[K, V]{key: key, value: value, hash: h.hash(key)}
+	h.buckets[item.hash] = append(h.buckets[item.hash], item)
+	h.link(item)
+	h.length++
+}
+
+// Get retrieves the value associated with the provided key from the hashed cache,
+// making it the most recently used item.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (h *Hashed[K, V]) Get(key K) (V, bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	item, ok := h.lookup(key)
+	if !ok {
+		h.stats.Misses++
+
+		var emptyVal V
+		return emptyVal, false
+	}
+
+	h.stats.Hits++
+	h.promote(item)
+
+	return item.value, true
+}
+
+// Peek retrieves the value associated with the provided key from the hashed cache
+// without updating the order of items in the cache.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (h *Hashed[K, V]) Peek(key K) (V, bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	if item, ok := h.lookup(key); ok {
+		return item.value, true
+	}
+
+	var emptyVal V
+	return emptyVal, false
+}
+
+// Del removes the key-value pair associated with the provided key from the hashed cache.
+// If the key is found and the removal is successful, the function returns true.
+// If the key is not found, it returns false.
+func (h *Hashed[K, V]) Del(key K) bool {
+	h.Lock()
+	defer h.Unlock()
+
+	item, ok := h.lookup(key)
+	if !ok {
+		return false
+	}
+
+	h.remove(item)
+
+	return true
+}
+
+// Len returns the number of items currently stored in the hashed cache.
+func (h *Hashed[K, V]) Len() int {
+	h.Lock()
+	defer h.Unlock()
+
+	return h.length
+}
+
+// Cap returns the maximum number of items the hashed cache can hold.
+// It returns Unbounded if the cache has no capacity limit.
+func (h *Hashed[K, V]) Cap() int {
+	h.Lock()
+	defer h.Unlock()
+
+	return h.size
+}
+
+// Purge removes all key-value pairs from the hashed cache, leaving it empty.
+func (h *Hashed[K, V]) Purge() {
+	h.Lock()
+	defer h.Unlock()
+
+	h.buckets = map[uint64][]*hashedItem[K, V]{}
+	h.root.next, h.root.prev = &h.root, &h.root
+	h.length = 0
+}
+
+// Resize changes the maximum number of items the hashed cache can hold.
+// If the new size is smaller than the current number of items,
+// the least recently used items are evicted until the cache fits.
+func (h *Hashed[K, V]) Resize(size int) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.size = size
+	for size != Unbounded && h.length > max(size, 0) {
+		h.evict()
+	}
+}
+
+// Keys returns a snapshot of the keys in the hashed cache,
+// ordered from the most recently used to the least recently used.
+func (h *Hashed[K, V]) Keys() []K {
+	keys, _ := h.items()
+	return keys
+}
+
+// Values returns a snapshot of the values in the hashed cache, in the same order as Keys.
+func (h *Hashed[K, V]) Values() []V {
+	_, values := h.items()
+	return values
+}
+
+// All returns an iterator over the key-value pairs in the hashed cache, in the same order as Keys.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (h *Hashed[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		forward(h.items())(yield)
+	}
+}
+
+// Backward returns an iterator over the key-value pairs in the hashed cache, in the reverse order of Keys.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (h *Hashed[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		backward(h.items())(yield)
+	}
+}
+
+// Stats returns a snapshot of the usage counters of the hashed cache.
+func (h *Hashed[K, V]) Stats() Stats {
+	h.Lock()
+	defer h.Unlock()
+
+	return Stats{
+		Hits:      h.stats.Hits,
+		Misses:    h.stats.Misses,
+		Evictions: h.stats.Evictions,
+	}
+}
+
+// Close is a no-op, the hashed cache owns no background goroutines.
+func (h *Hashed[K, V]) Close() {}
+
+// items returns a snapshot of the keys and values in the hashed cache, in the same order as Keys.
+func (h *Hashed[K, V]) items() ([]K, []V) {
+	h.Lock()
+	defer h.Unlock()
+
+	keys := make([]K, 0, h.length)
+	values := make([]V, 0, h.length)
+	for item := h.root.next; item != &h.root; item = item.next {
+		keys = append(keys, item.key)
+		values = append(values, item.value)
+	}
+
+	return keys, values
+}
+
+// lookup returns the item stored for the provided key, searching the bucket of its hash.
+func (h *Hashed[K, V]) lookup(key K) (*hashedItem[K, V], bool) {
+	for _, item := range h.buckets[h.hash(key)] {
+		if h.equal(item.key, key) {
+			return item, true
+		}
+	}
+
+	return nil, false
+}
+
+// link inserts the provided item at the front of the list.
+func (h *Hashed[K, V]) link(item *hashedItem[K, V]) {
+	item.prev, item.next = &h.root, h.root.next
+	h.root.next.prev = item
+	h.root.next = item
+}
+
+// unlink takes the provided item out of the list.
+func (h *Hashed[K, V]) unlink(item *hashedItem[K, V]) {
+	item.prev.next, item.next.prev = item.next, item.prev
+	item.prev, item.next = nil, nil
+}
+
+// promote moves the provided item to the front of the list.
+func (h *Hashed[K, V]) promote(item *hashedItem[K, V]) {
+	if h.root.next == item {
+		return
+	}
+
+	h.unlink(item)
+	h.link(item)
+}
+
+// evict removes the least recently used item, if any.
+func (h *Hashed[K, V]) evict() {
+	if h.length == 0 {
+		return
+	}
+
+	h.stats.Evictions++
+	h.remove(h.root.prev)
+}
+
+// remove takes the provided item out of the list and of its bucket.
+func (h *Hashed[K, V]) remove(item *hashedItem[K, V]) {
+	h.unlink(item)
+	h.length--
+
+	bucket := h.buckets[item.hash]
+	for i, other := range bucket {
+		if other != item {
+			continue
+		}
+
+		bucket[i] = bucket[len(bucket)-1]
+		bucket[len(bucket)-1] = nil
+		bucket = bucket[:len(bucket)-1]
+		break
+	}
+
+	if len(bucket) == 0 {
+		delete(h.buckets, item.hash)
+	} else {
+		h.buckets[item.hash] = bucket
+	}
+}
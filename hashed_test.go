@@ -0,0 +1,84 @@
+package lru
+
+import (
+	"bytes"
+	"hash/maphash"
+	"reflect"
+	"testing"
+)
+
+func newBytesCache(size int) *Hashed[[]byte, int] {
+	seed := maphash.MakeSeed()
+	return NewHashed[[]byte, int](size, func(key []byte) uint64 {
+		return maphash.Bytes(seed, key)
+	}, bytes.Equal)
+}
+
+func TestHashed(t *testing.T) {
+	t.Run("should store values under non-comparable keys", func(t *testing.T) {
+		h := newBytesCache(2)
+		h.Set([]byte("a"), 1)
+		h.Set([]byte("b"), 2)
+		h.Set([]byte("a"), 3)
+
+		if value, ok := h.Get([]byte("a")); !ok || !reflect.DeepEqual(3, value) {
+			t.Errorf("Expected %v; Actual = %v %v", 3, value, ok)
+		}
+		if !reflect.DeepEqual(2, h.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 2, h.Len())
+		}
+		if !h.Del([]byte("b")) || h.Contains([]byte("b")) {
+			t.Errorf("Expected %v to be deleted", "b")
+		}
+	})
+
+	t.Run("should evict the least recently used item", func(t *testing.T) {
+		h := newBytesCache(2)
+		h.Set([]byte("a"), 1)
+		h.Set([]byte("b"), 2)
+		h.Get([]byte("a"))
+		h.Set([]byte("c"), 3)
+
+		if !reflect.DeepEqual([][]byte{[]byte("c"), []byte("a")}, h.Keys()) {
+			t.Errorf("Expected %q; Actual = %q", [][]byte{[]byte("c"), []byte("a")}, h.Keys())
+		}
+		if !reflect.DeepEqual(Stats{Hits: 1, Evictions: 1}, h.Stats()) {
+			t.Errorf("Expected %v; Actual = %v", Stats{Hits: 1, Evictions: 1}, h.Stats())
+		}
+
+		h.Resize(1)
+		if !reflect.DeepEqual([]int{3}, h.Values()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3}, h.Values())
+		}
+	})
+
+	t.Run("should tell apart keys whose hashes collide", func(t *testing.T) {
+		h := NewHashed[[]int, string](Unbounded, func([]int) uint64 { return 0 }, func(a, b []int) bool {
+			return reflect.DeepEqual(a, b)
+		})
+		h.Set([]int{1}, "one")
+		h.Set([]int{1, 2}, "two")
+		h.Set([]int{1, 2, 3}, "three")
+		h.Del([]int{1, 2})
+
+		if value, ok := h.Peek([]int{1, 2, 3}); !ok || !reflect.DeepEqual("three", value) {
+			t.Errorf("Expected %v; Actual = %v %v", "three", value, ok)
+		}
+		if _, ok := h.Peek([]int{1, 2}); ok {
+			t.Errorf("Expected %v to be deleted", []int{1, 2})
+		}
+
+		var got []string
+		for _, value := range h.Backward() {
+			got = append(got, value)
+		}
+		if !reflect.DeepEqual([]string{"one", "three"}, got) {
+			t.Errorf("Expected %v; Actual = %v", []string{"one", "three"}, got)
+		}
+
+		h.Purge()
+		if !reflect.DeepEqual(0, h.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 0, h.Len())
+		}
+	})
+}
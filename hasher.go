@@ -0,0 +1,92 @@
+package lru
+
+import (
+	"fmt"
+	"hash/maphash"
+)
+
+// Hasher hashes keys to spread them evenly over the uint64 range.
+// It picks the shard of every key in caches created with NewSharded and the queue of every key in write-behind mode.
+// A Hasher must be safe for concurrent use and return the same hash for equal keys.
+type Hasher[K comparable] interface {
+	Hash(key K) uint64
+}
+
+// HasherFunc adapts an ordinary function to the Hasher interface.
+type HasherFunc[K comparable] func(key K) uint64
+
+// Hash returns f(key).
+func (f HasherFunc[K]) Hash(key K) uint64 {
+	return f(key)
+}
+
+// NewHasher returns the default Hasher, randomly seeded so hashes differ between processes.
+// Strings are hashed with hash/maphash and integers with a seeded 64-bit mixer, neither allocating.
+// Other key types, including types defined over strings and integers, fall back to hashing
+// their default formatted representation, which is slow and may be ambiguous,
+// so custom key types should get their own Hasher with WithHasher.
+func NewHasher[K comparable]() Hasher[K] {
+	seed := maphash.MakeSeed()
+	salt := maphash.String(seed, "")
+
+	var key K
+	var h any
+	switch any(key).(type) {
+	case string:
+		h = HasherFunc[string](func(key string) uint64 { return maphash.String(seed, key) })
+	case int:
+		h = intHasher[int](salt)
+	case int8:
+		h = intHasher[int8](salt)
+	case int16:
+		h = intHasher[int16](salt)
+	case int32:
+		h = intHasher[int32](salt)
+	case int64:
+		h = intHasher[int64](salt)
+	case uint:
+		h = intHasher[uint](salt)
+	case uint8:
+		h = intHasher[uint8](salt)
+	case uint16:
+		h = intHasher[uint16](salt)
+	case uint32:
+		h = intHasher[uint32](salt)
+	case uint64:
+		h = intHasher[uint64](salt)
+	case uintptr:
+		h = intHasher[uintptr](salt)
+	default:
+		h = HasherFunc[K](func(key K) uint64 { return maphash.String(seed, fmt.Sprint(key)) })
+	}
+
+	return h.(Hasher[K])
+}
+
+// intHasher returns a Hasher of integer keys, mixing them with the provided salt.
+func intHasher[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](salt uint64) HasherFunc[T] {
+	return func(key T) uint64 {
+		return mix(uint64(key) ^ salt)
+	}
+}
+
+// mix scrambles the bits of the provided integer with the finalizer of SplitMix64,
+// so consecutive integers are spread over the whole uint64 range.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
+
+// hasherOf returns the Hasher configured with WithHasher for the provided cache, or a new default one.
+func hasherOf[K comparable, V any](l *lru[K, V]) Hasher[K] {
+	if l.hasher != nil {
+		return l.hasher
+	}
+
+	return NewHasher[K]()
+}
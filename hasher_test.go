@@ -0,0 +1,59 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+}
+
+func TestHasher(t *testing.T) {
+	t.Run("should return the same hash for equal keys", func(t *testing.T) {
+		ints := NewHasher[int]()
+		strs := NewHasher[string]()
+		points := NewHasher[point]()
+
+		if ints.Hash(42) != ints.Hash(42) || strs.Hash("a") != strs.Hash("a") || points.Hash(point{1, 2}) != points.Hash(point{1, 2}) {
+			t.Errorf("Expected equal hashes for equal keys")
+		}
+
+		if ints.Hash(1) == ints.Hash(2) || strs.Hash("a") == strs.Hash("b") || points.Hash(point{1, 2}) == points.Hash(point{2, 1}) {
+			t.Errorf("Expected different hashes for different keys")
+		}
+	})
+
+	t.Run("should spread consecutive integers evenly", func(t *testing.T) {
+		h := NewHasher[int]()
+
+		counts := make([]int, 8)
+		for i := 0; i < 8000; i++ {
+			counts[h.Hash(i)%8]++
+		}
+
+		for i, n := range counts {
+			if n < 800 || n > 1200 {
+				t.Errorf("Expected about 1000 keys in bucket %v; Actual = %v", i, n)
+			}
+		}
+	})
+
+	t.Run("should pick shards with a custom hasher", func(t *testing.T) {
+		l := NewSharded[point, int](8, 2, WithHasher[point, int](HasherFunc[point](func(p point) uint64 {
+			return uint64(p.X)
+		}))).(*sharded[point, int])
+
+		l.Set(point{0, 1}, 1)
+		l.Set(point{1, 1}, 2)
+		l.Set(point{2, 1}, 3)
+
+		if !reflect.DeepEqual([]point{{2, 1}, {0, 1}}, l.shards[0].Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []point{{2, 1}, {0, 1}}, l.shards[0].Keys())
+		}
+
+		if !reflect.DeepEqual([]point{{1, 1}}, l.shards[1].Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []point{{1, 1}}, l.shards[1].Keys())
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package lru
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+// sketchDepth is the number of rows of the frequency sketch, each indexed by a different hash of the key.
+const sketchDepth = 4
+
+// KeyStat is the estimated access frequency of a key reported by TopKeys.
+type KeyStat[K comparable] struct {
+	Key   K      // Key looked up in the cache.
+	Count uint64 // Estimated number of lookups of the key, hits and misses alike, halved periodically.
+}
+
+// hotKeys tracks the most frequently looked up keys of a cache.
+// Frequencies are estimated with a count-min sketch, whose counters are halved once it has recorded
+// ten times as many lookups as it has counters, so recent traffic weighs more than old traffic.
+// Only the keys estimated to be the most frequent are remembered, up to the configured number.
+type hotKeys[K comparable] struct {
+	hasher     Hasher[K]             // Hasher indexing the sketch.
+	rows       [sketchDepth][]uint32 // Counters of the count-min sketch.
+	mask       uint64                // Mask of the index of a counter in a row, whose length is a power of two.
+	additions  int                   // Number of lookups recorded since the counters were last halved.
+	resetAt    int                   // Number of lookups after which the counters are halved.
+	top        map[K]uint32          // Estimated frequencies of the most frequent keys.
+	size       int                   // Maximum number of keys in top.
+	floor      uint32                // Lowest frequency in top once it is full, which a key must exceed to enter it.
+	sync.Mutex                       // Mutex guarding the tracker.
+}
+
+// newHotKeys creates a tracker remembering the provided number of most frequent keys.
+func newHotKeys[K comparable](size int, hasher Hasher[K]) *hotKeys[K] {
+	width := 1024
+	for width < 16*size {
+		width <<= 1
+	}
+
+	out := &hotKeys[K]{
+		hasher:  hasher,
+		mask:    uint64(width - 1),
+		resetAt: 10 * width,
+		top:     make(map[K]uint32, size),
+		size:    size,
+	}
+	for i := range out.rows {
+		out.rows[i] = make([]uint32, width)
+	}
+
+	return out
+}
+
+// record counts a lookup of the provided key.
+func (h *hotKeys[K]) record(key K) {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+
+	count := h.increment(key)
+	if h.additions++; h.additions >= h.resetAt {
+		h.age()
+	}
+
+	if old, ok := h.top[key]; ok {
+		h.top[key] = count
+		if old == h.floor {
+			h.lower()
+		}
+		return
+	}
+
+	if len(h.top) < h.size {
+		h.top[key] = count
+		h.lower()
+		return
+	}
+
+	if count <= h.floor {
+		return
+	}
+
+	for k, c := range h.top {
+		if c == h.floor {
+			delete(h.top, k)
+			break
+		}
+	}
+	h.top[key] = count
+	h.lower()
+}
+
+// increment adds a lookup of the provided key to the sketch and returns its new estimated frequency.
+// Only the lowest counters of the key are incremented, which keeps the estimates of other keys sharing them tighter.
+func (h *hotKeys[K]) increment(key K) uint32 {
+	hash := h.hasher.Hash(key)
+	step := hash>>32 | 1
+
+	var slots [sketchDepth]uint64
+	count := ^uint32(0)
+	for i := range h.rows {
+		slots[i] = (hash + uint64(i)*step) & h.mask
+		count = min(count, h.rows[i][slots[i]])
+	}
+
+	for i := range h.rows {
+		if h.rows[i][slots[i]] == count {
+			h.rows[i][slots[i]]++
+		}
+	}
+
+	return count + 1
+}
+
+// age halves every counter of the sketch and every frequency of the most frequent keys.
+func (h *hotKeys[K]) age() {
+	h.additions = 0
+	for i := range h.rows {
+		for j := range h.rows[i] {
+			h.rows[i][j] >>= 1
+		}
+	}
+
+	for k, c := range h.top {
+		h.top[k] = c >> 1
+	}
+	h.lower()
+}
+
+// lower updates the floor of the most frequent keys once there are as many as tracked.
+func (h *hotKeys[K]) lower() {
+	if len(h.top) < h.size {
+		h.floor = 0
+		return
+	}
+
+	h.floor = ^uint32(0)
+	for _, c := range h.top {
+		h.floor = min(h.floor, c)
+	}
+}
+
+// topKeys returns up to n of the most frequent keys, ordered from the most frequent.
+func (h *hotKeys[K]) topKeys(n int) []KeyStat[K] {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+
+	out := make([]KeyStat[K], 0, len(h.top))
+	for k, c := range h.top {
+		out = append(out, KeyStat[K]{Key: k, Count: uint64(c)})
+	}
+
+	slices.SortFunc(out, func(a, b KeyStat[K]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+
+	return out[:min(max(n, 0), len(out))]
+}
+
+// TopKeys returns up to n of the keys looked up the most often, hits and misses alike, ordered from the most frequent,
+// with their estimated number of lookups. Keys which are looked up often but never found are candidates for stampedes.
+// The order of keys with the same estimate is unspecified.
+// It returns nil unless tracking is enabled with WithHotKeys.
+//
+// Example usage:
+//
+//	for _, stat := range cache.TopKeys(10) {
+//		fmt.Println(stat.Key, stat.Count)
+//	}
+func (l *lru[K, V]) TopKeys(n int) []KeyStat[K] {
+	if l.hotKeys == nil {
+		return nil
+	}
+
+	return l.hotKeys.topKeys(n)
+}
+
+// TopKeys returns up to n of the keys looked up the most often across every shard, ordered from the most frequent.
+// Every shard tracks the number of keys configured with WithHotKeys, so n should not exceed it.
+func (s *sharded[K, V]) TopKeys(n int) []KeyStat[K] {
+	var out []KeyStat[K]
+	for _, sh := range s.shards {
+		out = append(out, sh.TopKeys(n)...)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	slices.SortFunc(out, func(a, b KeyStat[K]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+
+	return out[:min(max(n, 0), len(out))]
+}
+
+// TopKeys returns up to n of the keys looked up the most often, as tracked by the first tier, which every lookup goes through.
+func (t *tiered[K, V]) TopKeys(n int) []KeyStat[K] {
+	return t.hot.TopKeys(n)
+}
@@ -0,0 +1,79 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTopKeys(t *testing.T) {
+	t.Run("should report the most frequent keys first", func(t *testing.T) {
+		l := New[int, int](10, WithHotKeys[int, int](3))
+		for key := range 10 {
+			l.Set(key, key)
+		}
+
+		for i := range 100 {
+			l.Get(1)
+			if i%2 == 0 {
+				l.Get(2)
+			}
+			if i%4 == 0 {
+				l.Get(42)
+			}
+			l.Get(i % 10)
+		}
+
+		actual := []int{}
+		for _, stat := range l.TopKeys(3) {
+			actual = append(actual, stat.Key)
+		}
+		if !reflect.DeepEqual([]int{1, 2, 42}, actual) {
+			t.Errorf("Expected %v; Actual = %v", []int{1, 2, 42}, actual)
+		}
+
+		if top := l.TopKeys(1); len(top) != 1 || top[0].Count < 110 {
+			t.Errorf("Expected key 1 looked up at least 110 times; Actual = %v", top)
+		}
+	})
+
+	t.Run("should favour recent traffic", func(t *testing.T) {
+		h := newHotKeys[int](1, NewHasher[int]())
+		for range 100 {
+			h.record(1)
+		}
+		h.age()
+		for range 60 {
+			h.record(2)
+		}
+
+		if top := h.topKeys(1); !reflect.DeepEqual(2, top[0].Key) {
+			t.Errorf("Expected %v; Actual = %v", 2, top[0].Key)
+		}
+	})
+
+	t.Run("should return nil unless enabled", func(t *testing.T) {
+		l := New[int, int](10)
+		l.Get(1)
+
+		if top := l.TopKeys(3); top != nil {
+			t.Errorf("Expected nil; Actual = %v", top)
+		}
+	})
+
+	t.Run("should merge the keys of every shard", func(t *testing.T) {
+		l := NewSharded[int, int](100, 4, WithHotKeys[int, int](3))
+		for key := range 8 {
+			for range key + 1 {
+				l.Get(key)
+			}
+		}
+
+		actual := []int{}
+		for _, stat := range l.TopKeys(3) {
+			actual = append(actual, stat.Key)
+		}
+		if !reflect.DeepEqual([]int{7, 6, 5}, actual) {
+			t.Errorf("Expected %v; Actual = %v", []int{7, 6, 5}, actual)
+		}
+	})
+}
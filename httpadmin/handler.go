@@ -0,0 +1,156 @@
+// Package httpadmin exposes a live cache over HTTP, so operators can inspect and modify it without redeploying.
+package httpadmin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/vhndaree/lru"
+)
+
+// KeyParser converts a key taken from a request path into a cache key.
+type KeyParser[K comparable] func(s string) (K, error)
+
+// StringKey is a KeyParser for caches with string keys.
+func StringKey(s string) (string, error) {
+	return s, nil
+}
+
+// IntKey is a KeyParser for caches with int keys.
+func IntKey(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+// Handler is an http.Handler serving the following endpoints, all responding with JSON:
+//
+//	GET    /keys        lists the keys of the cache, from the most recently used to the least recently used
+//	GET    /keys/{key}  looks up the value of a key without promoting it, 404 when it is missing
+//	DELETE /keys/{key}  deletes a key, 404 when it is missing
+//	POST   /purge       removes every key from the cache
+//	GET    /stats       reports the length, the capacity and the usage counters of the cache
+//
+// Mount it under a prefix with http.StripPrefix. The handler has no authentication of its own,
+// so it must only be exposed to trusted operators.
+type Handler[K comparable, V any] struct {
+	cache lru.Base[K, V] // Cache exposed by the handler.
+	parse KeyParser[K]   // Parser of the keys taken from request paths.
+	mux   *http.ServeMux // Router of the endpoints.
+}
+
+// entry is the JSON representation of a key-value pair.
+type entry[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// stats is the JSON representation of the statistics of a cache.
+type stats struct {
+	Len         int    `json:"len"`
+	Cap         int    `json:"cap"`
+	Hits        uint64 `json:"hits"`
+	Misses      uint64 `json:"misses"`
+	Evictions   uint64 `json:"evictions"`
+	Expirations uint64 `json:"expirations"`
+}
+
+// New creates a Handler exposing the provided cache, parsing the keys of request paths with the provided parser.
+//
+// Example usage:
+//
+//	cache := lru.New[string, string](100)
+//	http.Handle("/debug/cache/", http.StripPrefix("/debug/cache", httpadmin.New(cache, httpadmin.StringKey)))
+func New[K comparable, V any](cache lru.Base[K, V], parse KeyParser[K]) *Handler[K, V] {
+	h := &Handler[K, V]{cache: cache, parse: parse, mux: http.NewServeMux()}
+
+	h.mux.HandleFunc("GET /keys", h.keys)
+	h.mux.HandleFunc("GET /keys/{key}", h.get)
+	h.mux.HandleFunc("DELETE /keys/{key}", h.del)
+	h.mux.HandleFunc("POST /purge", h.purge)
+	h.mux.HandleFunc("GET /stats", h.stats)
+
+	return h
+}
+
+// ServeHTTP routes the request to the endpoint matching its method and path.
+func (h *Handler[K, V]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// keys lists the keys of the cache.
+func (h *Handler[K, V]) keys(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, h.cache.Keys())
+}
+
+// get looks up the value of the key in the request path.
+func (h *Handler[K, V]) get(w http.ResponseWriter, r *http.Request) {
+	key, ok := h.key(w, r)
+	if !ok {
+		return
+	}
+
+	value, ok := h.cache.Peek(key)
+	if !ok {
+		writeError(w, http.StatusNotFound, "key not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entry[K, V]{Key: key, Value: value})
+}
+
+// del deletes the key in the request path.
+func (h *Handler[K, V]) del(w http.ResponseWriter, r *http.Request) {
+	key, ok := h.key(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.cache.Del(key) {
+		writeError(w, http.StatusNotFound, "key not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// purge removes every key from the cache.
+func (h *Handler[K, V]) purge(w http.ResponseWriter, _ *http.Request) {
+	h.cache.Purge()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// stats reports the statistics of the cache.
+func (h *Handler[K, V]) stats(w http.ResponseWriter, _ *http.Request) {
+	s := h.cache.Stats()
+	writeJSON(w, http.StatusOK, stats{
+		Len:         h.cache.Len(),
+		Cap:         h.cache.Cap(),
+		Hits:        s.Hits,
+		Misses:      s.Misses,
+		Evictions:   s.Evictions,
+		Expirations: s.Expirations,
+	})
+}
+
+// key parses the key in the request path, responding with 400 if it is invalid.
+func (h *Handler[K, V]) key(w http.ResponseWriter, r *http.Request) (K, bool) {
+	key, err := h.parse(r.PathValue("key"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid key: "+err.Error())
+		return key, false
+	}
+
+	return key, true
+}
+
+// writeJSON responds with the provided status and value encoded as JSON.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError responds with the provided status and an error message encoded as JSON.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,87 @@
+package httpadmin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vhndaree/lru"
+)
+
+func TestHandler(t *testing.T) {
+	serve := func(h http.Handler, method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+
+	t.Run("should list and look up keys", func(t *testing.T) {
+		cache := lru.New[int, string](3)
+		cache.Set(1, "one")
+		cache.Set(2, "two")
+		h := New(cache, IntKey)
+
+		rec := serve(h, http.MethodGet, "/keys")
+		if !reflect.DeepEqual("[2,1]", strings.TrimSpace(rec.Body.String())) {
+			t.Errorf("Expected [2,1]; Actual = %v", rec.Body.String())
+		}
+
+		rec = serve(h, http.MethodGet, "/keys/1")
+		if !reflect.DeepEqual(`{"key":1,"value":"one"}`, strings.TrimSpace(rec.Body.String())) {
+			t.Errorf(`Expected {"key":1,"value":"one"}; Actual = %v`, rec.Body.String())
+		}
+
+		// lookups do not promote keys
+		if !reflect.DeepEqual([]int{2, 1}, cache.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{2, 1}, cache.Keys())
+		}
+
+		rec = serve(h, http.MethodGet, "/keys/3")
+		if !reflect.DeepEqual(http.StatusNotFound, rec.Code) {
+			t.Errorf("Expected %v; Actual = %v", http.StatusNotFound, rec.Code)
+		}
+
+		rec = serve(h, http.MethodGet, "/keys/abc")
+		if !reflect.DeepEqual(http.StatusBadRequest, rec.Code) {
+			t.Errorf("Expected %v; Actual = %v", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("should delete keys and purge the cache", func(t *testing.T) {
+		cache := lru.New[string, int](3)
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		h := New(cache, StringKey)
+
+		rec := serve(h, http.MethodDelete, "/keys/a")
+		if !reflect.DeepEqual(http.StatusNoContent, rec.Code) {
+			t.Errorf("Expected %v; Actual = %v", http.StatusNoContent, rec.Code)
+		}
+
+		rec = serve(h, http.MethodDelete, "/keys/a")
+		if !reflect.DeepEqual(http.StatusNotFound, rec.Code) {
+			t.Errorf("Expected %v; Actual = %v", http.StatusNotFound, rec.Code)
+		}
+
+		serve(h, http.MethodPost, "/purge")
+		if !reflect.DeepEqual(0, cache.Len()) {
+			t.Errorf("Expected 0; Actual = %v", cache.Len())
+		}
+	})
+
+	t.Run("should report stats", func(t *testing.T) {
+		cache := lru.New[string, int](3)
+		cache.Set("a", 1)
+		cache.Get("a")
+		cache.Get("b")
+
+		rec := serve(New(cache, StringKey), http.MethodGet, "/stats")
+
+		expected := `{"len":1,"cap":3,"hits":1,"misses":1,"evictions":0,"expirations":0}`
+		if !reflect.DeepEqual(expected, strings.TrimSpace(rec.Body.String())) {
+			t.Errorf("Expected %v; Actual = %v", expected, rec.Body.String())
+		}
+	})
+}
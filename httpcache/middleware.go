@@ -0,0 +1,190 @@
+package httpcache
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vhndaree/lru"
+)
+
+// DefaultMaxBodySize is the size of the largest response body cached by a Middleware
+// unless another size is configured with WithMaxBodySize.
+const DefaultMaxBodySize = 1 << 20
+
+// rendered is a response rendered by a handler and cached by a Middleware.
+type rendered struct {
+	status int         // Status code of the response.
+	header http.Header // Headers of the response.
+	body   []byte      // Body of the response.
+}
+
+// Middleware caches the responses rendered by handlers to GET and HEAD requests,
+// keyed by method, path, query and the values of the configured Vary headers.
+// Only successful responses are cached, unless they carry a Set-Cookie header
+// or a Cache-Control header with the no-store or private directive.
+//
+// Responses served from the cache carry an "X-Cache: HIT" header.
+type Middleware struct {
+	cache       lru.LRUWithExpiry[string, *rendered] // Cached responses by key.
+	ttl         time.Duration                        // How long responses are cached.
+	maxBodySize int                                  // Size of the largest response body cached.
+	vary        []string                             // Request headers the cached responses vary on.
+}
+
+// MiddlewareOption configures optional behaviour of a Middleware at construction time.
+type MiddlewareOption func(*Middleware)
+
+// WithMaxBodySize configures the size of the largest response body the Middleware caches.
+// Larger responses are still served, but not cached. Non-positive sizes are ignored.
+func WithMaxBodySize(size int) MiddlewareOption {
+	return func(m *Middleware) {
+		if size > 0 {
+			m.maxBodySize = size
+		}
+	}
+}
+
+// WithVary configures the request headers the cached responses vary on,
+// such as Accept-Encoding or Accept-Language, so requests differing in them are cached separately.
+func WithVary(headers ...string) MiddlewareOption {
+	return func(m *Middleware) {
+		for _, h := range headers {
+			m.vary = append(m.vary, http.CanonicalHeaderKey(h))
+		}
+	}
+}
+
+// NewMiddleware creates a Middleware caching up to size responses for the provided TTL.
+// Optional behaviour can be configured by passing one or more MiddlewareOption values.
+//
+// Example usage:
+//
+//	m := httpcache.NewMiddleware(1000, time.Minute, httpcache.WithVary("Accept-Encoding"))
+//	defer m.Close()
+//	http.ListenAndServe(":8080", m.Wrap(mux))
+func NewMiddleware(size int, ttl time.Duration, opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{
+		cache:       lru.NewWithExpiry[string, *rendered](size),
+		ttl:         ttl,
+		maxBodySize: DefaultMaxBodySize,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Wrap returns a handler serving requests from the cache when possible, and from the provided handler otherwise.
+// Its signature makes it usable wherever a func(http.Handler) http.Handler middleware is expected.
+// Handlers wrapped by the same Middleware share its cache, so they must serve distinct paths.
+func (m *Middleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := m.key(r)
+		if cached, ok := m.cache.Get(key); ok {
+			cached.serve(w, r)
+			return
+		}
+
+		rec := &recorder{ResponseWriter: w, status: http.StatusOK, max: m.maxBodySize}
+		next.ServeHTTP(rec, r)
+
+		if rec.cacheable() {
+			m.cache.SetWithTTL(key, &rendered{status: rec.status, header: w.Header().Clone(), body: rec.body.Bytes()}, m.ttl)
+		}
+	})
+}
+
+// Close stops the background goroutine of the cache. The middleware must not be used after Close.
+func (m *Middleware) Close() {
+	m.cache.Close()
+}
+
+// key returns the cache key of the provided request.
+func (m *Middleware) key(r *http.Request) string {
+	var b strings.Builder
+	b.WriteString(r.Method)
+	b.WriteByte(' ')
+	b.WriteString(r.URL.RequestURI())
+	for _, h := range m.vary {
+		b.WriteByte('\n')
+		b.WriteString(h)
+		b.WriteString(": ")
+		b.WriteString(strings.Join(r.Header.Values(h), ", "))
+	}
+
+	return b.String()
+}
+
+// serve writes the cached response to the provided writer, without its body for HEAD requests.
+func (c *rendered) serve(w http.ResponseWriter, r *http.Request) {
+	for name, values := range c.header {
+		w.Header()[name] = values
+	}
+	w.Header().Set("X-Cache", "HIT")
+	w.WriteHeader(c.status)
+
+	if r.Method != http.MethodHead {
+		w.Write(c.body)
+	}
+}
+
+// recorder is an http.ResponseWriter passing the response through to the client
+// while recording it for caching, as long as its body does not exceed the maximum size.
+type recorder struct {
+	http.ResponseWriter
+	status   int          // Status code of the response.
+	body     bytes.Buffer // Recorded body of the response.
+	max      int          // Size of the largest body recorded.
+	overflow bool         // Whether the body exceeded the maximum size.
+	wrote    bool         // Whether the header was written.
+}
+
+// WriteHeader records the status code and writes it to the client.
+func (r *recorder) WriteHeader(status int) {
+	if !r.wrote {
+		r.wrote = true
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the provided bytes unless the body exceeded the maximum size, and writes them to the client.
+func (r *recorder) Write(p []byte) (int, error) {
+	r.wrote = true
+	if !r.overflow {
+		if r.body.Len()+len(p) > r.max {
+			r.overflow = true
+			r.body = bytes.Buffer{}
+		} else {
+			r.body.Write(p)
+		}
+	}
+
+	return r.ResponseWriter.Write(p)
+}
+
+// cacheable reports whether the recorded response may be cached.
+func (r *recorder) cacheable() bool {
+	if r.overflow || r.status != http.StatusOK {
+		return false
+	}
+
+	header := r.ResponseWriter.Header()
+	if header.Get("Set-Cookie") != "" {
+		return false
+	}
+
+	directives := cacheControl(header)
+	_, noStore := directives["no-store"]
+	_, private := directives["private"]
+
+	return !noStore && !private
+}
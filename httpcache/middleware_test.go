@@ -0,0 +1,124 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddleware(t *testing.T) {
+	serve := func(h http.Handler, method, target string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	newHandler := func(header http.Header) (http.Handler, *int) {
+		calls := 0
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			for name, values := range header {
+				w.Header()[name] = values
+			}
+			io.WriteString(w, r.URL.Path+" "+r.Header.Get("Accept-Language"))
+		}), &calls
+	}
+
+	t.Run("should cache rendered responses", func(t *testing.T) {
+		m := NewMiddleware(10, time.Minute)
+		defer m.Close()
+
+		next, calls := newHandler(http.Header{"Content-Type": {"text/plain"}})
+		h := m.Wrap(next)
+
+		serve(h, http.MethodGet, "/a", nil)
+		rec := serve(h, http.MethodGet, "/a", nil)
+		serve(h, http.MethodGet, "/b", nil)
+
+		if !reflect.DeepEqual(2, *calls) {
+			t.Errorf("Expected 2; Actual = %v", *calls)
+		}
+
+		if !reflect.DeepEqual("/a ", rec.Body.String()) {
+			t.Errorf("Expected %q; Actual = %q", "/a ", rec.Body.String())
+		}
+
+		if !reflect.DeepEqual("text/plain", rec.Header().Get("Content-Type")) {
+			t.Errorf("Expected text/plain; Actual = %v", rec.Header().Get("Content-Type"))
+		}
+
+		if !reflect.DeepEqual("HIT", rec.Header().Get("X-Cache")) {
+			t.Errorf("Expected HIT; Actual = %v", rec.Header().Get("X-Cache"))
+		}
+	})
+
+	t.Run("should key responses by the vary headers", func(t *testing.T) {
+		m := NewMiddleware(10, time.Minute, WithVary("accept-language"))
+		defer m.Close()
+
+		next, calls := newHandler(nil)
+		h := m.Wrap(next)
+
+		serve(h, http.MethodGet, "/a", http.Header{"Accept-Language": {"en"}})
+		serve(h, http.MethodGet, "/a", http.Header{"Accept-Language": {"en"}})
+		rec := serve(h, http.MethodGet, "/a", http.Header{"Accept-Language": {"fr"}})
+
+		if !reflect.DeepEqual(2, *calls) {
+			t.Errorf("Expected 2; Actual = %v", *calls)
+		}
+
+		if !reflect.DeepEqual("/a fr", rec.Body.String()) {
+			t.Errorf("Expected %q; Actual = %q", "/a fr", rec.Body.String())
+		}
+	})
+
+	t.Run("should not cache uncacheable responses", func(t *testing.T) {
+		for _, header := range []http.Header{
+			{"Set-Cookie": {"session=1"}},
+			{"Cache-Control": {"private"}},
+		} {
+			m := NewMiddleware(10, time.Minute)
+			next, calls := newHandler(header)
+			h := m.Wrap(next)
+
+			serve(h, http.MethodGet, "/a", nil)
+			serve(h, http.MethodGet, "/a", nil)
+			serve(h, http.MethodPost, "/a", nil)
+			serve(h, http.MethodPost, "/a", nil)
+
+			if !reflect.DeepEqual(4, *calls) {
+				t.Errorf("Expected 4; Actual = %v", *calls)
+			}
+
+			m.Close()
+		}
+	})
+
+	t.Run("should not cache bodies over the maximum size", func(t *testing.T) {
+		m := NewMiddleware(10, time.Minute, WithMaxBodySize(4))
+		defer m.Close()
+
+		next, calls := newHandler(nil)
+		h := m.Wrap(next)
+
+		serve(h, http.MethodGet, "/long", nil)
+		rec := serve(h, http.MethodGet, "/long", nil)
+
+		if !reflect.DeepEqual(2, *calls) {
+			t.Errorf("Expected 2; Actual = %v", *calls)
+		}
+
+		if !reflect.DeepEqual(true, strings.HasPrefix(rec.Body.String(), "/long")) {
+			t.Errorf("Expected a full body; Actual = %q", rec.Body.String())
+		}
+	})
+}
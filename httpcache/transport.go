@@ -0,0 +1,226 @@
+// Package httpcache provides in-process HTTP caching backed by an LRU cache,
+// as an http.RoundTripper for clients and as a middleware for servers.
+package httpcache
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vhndaree/lru"
+)
+
+// response is a cached response along with the request headers it varies on.
+type response struct {
+	status     string            // Status line of the response, e.g. "200 OK".
+	statusCode int               // Status code of the response.
+	proto      string            // Protocol of the response, e.g. "HTTP/1.1".
+	protoMajor int               // Major version of the protocol.
+	protoMinor int               // Minor version of the protocol.
+	header     http.Header       // Headers of the response.
+	body       []byte            // Body of the response.
+	varyValues map[string]string // Values of the request headers listed in the Vary header of the response.
+}
+
+// Transport is an http.RoundTripper caching the responses of GET requests by URL.
+// Responses are cached for as long as their Cache-Control max-age directive or their Expires header allows,
+// responses without either, or with the no-store or no-cache directive, are not cached.
+// Requests with the no-store or no-cache directive bypass the cache.
+//
+// Responses served from the cache carry an "X-Cache: HIT" header.
+type Transport struct {
+	next  http.RoundTripper                    // Transport making the requests missing from the cache.
+	cache lru.LRUWithExpiry[string, *response] // Cached responses by URL.
+}
+
+// New creates a Transport caching up to size responses in front of the provided transport,
+// or http.DefaultTransport when it is nil.
+//
+// Example usage:
+//
+//	client := &http.Client{Transport: httpcache.New(1000, nil)}
+func New(size int, next http.RoundTripper) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Transport{next: next, cache: lru.NewWithExpiry[string, *response](size)}
+}
+
+// RoundTrip serves the request from the cache when a fresh response is cached for its URL,
+// otherwise it makes the request with the underlying transport and caches the response when it is cacheable.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !cacheableRequest(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	if cached, ok := t.cache.Get(key); ok && cached.matches(req) {
+		return cached.response(req), nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, ok := freshness(resp)
+	if !ok {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	cached, ok := newResponse(req, resp, body)
+	if ok {
+		t.cache.SetWithTTL(key, cached, ttl)
+	}
+
+	return resp, nil
+}
+
+// Close stops the background goroutine of the cache. The transport must not be used after Close.
+func (t *Transport) Close() {
+	t.cache.Close()
+}
+
+// newResponse captures the provided response to the provided request for caching.
+// Responses varying on every request header are not cacheable.
+func newResponse(req *http.Request, resp *http.Response, body []byte) (*response, bool) {
+	varyValues := map[string]string{}
+	for _, vary := range resp.Header.Values("Vary") {
+		for _, name := range strings.Split(vary, ",") {
+			name = strings.TrimSpace(name)
+			if name == "*" {
+				return nil, false
+			}
+			if name != "" {
+				varyValues[http.CanonicalHeaderKey(name)] = req.Header.Get(name)
+			}
+		}
+	}
+
+	return &response{
+		status:     resp.Status,
+		statusCode: resp.StatusCode,
+		proto:      resp.Proto,
+		protoMajor: resp.ProtoMajor,
+		protoMinor: resp.ProtoMinor,
+		header:     resp.Header.Clone(),
+		body:       body,
+		varyValues: varyValues,
+	}, true
+}
+
+// matches reports whether the provided request has the same values as the cached one
+// for every header the response varies on.
+func (r *response) matches(req *http.Request) bool {
+	for name, value := range r.varyValues {
+		if req.Header.Get(name) != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// response builds a new http.Response to the provided request from the cached response.
+func (r *response) response(req *http.Request) *http.Response {
+	header := r.header.Clone()
+	header.Set("X-Cache", "HIT")
+
+	return &http.Response{
+		Status:        r.status,
+		StatusCode:    r.statusCode,
+		Proto:         r.proto,
+		ProtoMajor:    r.protoMajor,
+		ProtoMinor:    r.protoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       req,
+	}
+}
+
+// cacheableRequest reports whether the response to the provided request may be served from and stored in the cache.
+func cacheableRequest(req *http.Request) bool {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return false
+	}
+
+	directives := cacheControl(req.Header)
+	_, noStore := directives["no-store"]
+	_, noCache := directives["no-cache"]
+
+	return !noStore && !noCache
+}
+
+// freshness returns how long the provided response may be cached,
+// from the max-age directive of its Cache-Control header or else from its Expires header, less its Age.
+// It reports false for responses which must not be cached.
+func freshness(resp *http.Response) (time.Duration, bool) {
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNonAuthoritativeInfo, http.StatusMovedPermanently, http.StatusNotFound, http.StatusGone:
+	default:
+		return 0, false
+	}
+
+	directives := cacheControl(resp.Header)
+	for _, d := range []string{"no-store", "no-cache"} {
+		if _, ok := directives[d]; ok {
+			return 0, false
+		}
+	}
+
+	var ttl time.Duration
+	if maxAge, ok := directives["max-age"]; ok {
+		seconds, err := strconv.Atoi(maxAge)
+		if err != nil {
+			return 0, false
+		}
+		ttl = time.Duration(seconds) * time.Second
+	} else if expires := resp.Header.Get("Expires"); expires != "" {
+		at, err := http.ParseTime(expires)
+		if err != nil {
+			return 0, false
+		}
+
+		now := time.Now()
+		if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+			now = date
+		}
+		ttl = at.Sub(now)
+	} else {
+		return 0, false
+	}
+
+	if age, err := strconv.Atoi(resp.Header.Get("Age")); err == nil {
+		ttl -= time.Duration(age) * time.Second
+	}
+
+	return ttl, ttl > 0
+}
+
+// cacheControl parses the directives of the Cache-Control header, mapping their names to their values.
+func cacheControl(header http.Header) map[string]string {
+	out := map[string]string{}
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if name == "" {
+				continue
+			}
+			out[strings.ToLower(name)] = strings.Trim(arg, `"`)
+		}
+	}
+
+	return out
+}
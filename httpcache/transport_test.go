@@ -0,0 +1,141 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTransport(t *testing.T) {
+	newServer := func(header http.Header) (*httptest.Server, *int) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			for name, values := range header {
+				w.Header()[name] = values
+			}
+			io.WriteString(w, r.Header.Get("Accept-Language")+" body")
+		}))
+
+		return server, &calls
+	}
+
+	get := func(t *testing.T, client *http.Client, url string, header http.Header) (string, *http.Response) {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Expected no error; Actual = %v", err)
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		return string(body), resp
+	}
+
+	t.Run("should cache responses for their max-age", func(t *testing.T) {
+		server, calls := newServer(http.Header{"Cache-Control": {"public, max-age=60"}})
+		defer server.Close()
+
+		transport := New(10, nil)
+		defer transport.Close()
+		client := &http.Client{Transport: transport}
+
+		get(t, client, server.URL, nil)
+		body, resp := get(t, client, server.URL, nil)
+
+		if !reflect.DeepEqual(1, *calls) {
+			t.Errorf("Expected 1; Actual = %v", *calls)
+		}
+
+		if !reflect.DeepEqual(" body", body) {
+			t.Errorf("Expected %q; Actual = %q", " body", body)
+		}
+
+		if !reflect.DeepEqual("HIT", resp.Header.Get("X-Cache")) {
+			t.Errorf("Expected HIT; Actual = %v", resp.Header.Get("X-Cache"))
+		}
+	})
+
+	t.Run("should cache responses until they expire", func(t *testing.T) {
+		server, calls := newServer(http.Header{"Expires": {time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}})
+		defer server.Close()
+
+		transport := New(10, nil)
+		defer transport.Close()
+		client := &http.Client{Transport: transport}
+
+		get(t, client, server.URL, nil)
+		get(t, client, server.URL, nil)
+
+		if !reflect.DeepEqual(1, *calls) {
+			t.Errorf("Expected 1; Actual = %v", *calls)
+		}
+	})
+
+	t.Run("should not cache responses without freshness", func(t *testing.T) {
+		for _, header := range []http.Header{
+			{},
+			{"Cache-Control": {"no-store, max-age=60"}},
+			{"Cache-Control": {"max-age=60"}, "Vary": {"*"}},
+		} {
+			server, calls := newServer(header)
+
+			transport := New(10, nil)
+			client := &http.Client{Transport: transport}
+
+			get(t, client, server.URL, nil)
+			get(t, client, server.URL, nil)
+
+			if !reflect.DeepEqual(2, *calls) {
+				t.Errorf("Expected 2; Actual = %v", *calls)
+			}
+
+			transport.Close()
+			server.Close()
+		}
+	})
+
+	t.Run("should bypass the cache for no-cache requests", func(t *testing.T) {
+		server, calls := newServer(http.Header{"Cache-Control": {"max-age=60"}})
+		defer server.Close()
+
+		transport := New(10, nil)
+		defer transport.Close()
+		client := &http.Client{Transport: transport}
+
+		get(t, client, server.URL, nil)
+		get(t, client, server.URL, http.Header{"Cache-Control": {"no-cache"}})
+
+		if !reflect.DeepEqual(2, *calls) {
+			t.Errorf("Expected 2; Actual = %v", *calls)
+		}
+	})
+
+	t.Run("should only serve responses matching the Vary headers", func(t *testing.T) {
+		server, calls := newServer(http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"Accept-Language"}})
+		defer server.Close()
+
+		transport := New(10, nil)
+		defer transport.Close()
+		client := &http.Client{Transport: transport}
+
+		get(t, client, server.URL, http.Header{"Accept-Language": {"en"}})
+		get(t, client, server.URL, http.Header{"Accept-Language": {"en"}})
+		body, _ := get(t, client, server.URL, http.Header{"Accept-Language": {"fr"}})
+
+		if !reflect.DeepEqual(2, *calls) {
+			t.Errorf("Expected 2; Actual = %v", *calls)
+		}
+
+		if !reflect.DeepEqual("fr body", body) {
+			t.Errorf("Expected %q; Actual = %q", "fr body", body)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package lru
+
+import (
+	"context"
+	"time"
+)
+
+// invalidationRetryDelay is how long a cache waits before subscribing again when its subscription failed.
+const invalidationRetryDelay = time.Second
+
+// Invalidator broadcasts deletions between the instances of a cache running in different processes,
+// typically through a pub/sub system such as Redis.
+type Invalidator[K comparable] interface {
+	// Invalidate broadcasts the deletion of the provided key to the other instances.
+	Invalidate(ctx context.Context, key K) error
+
+	// Subscribe calls fn with every key invalidated by another instance, until the context is done
+	// or the subscription fails. Keys invalidated by the same Invalidator are not reported back.
+	Subscribe(ctx context.Context, fn func(key K)) error
+}
+
+// startInvalidation subscribes to the keys invalidated by the other instances and removes them with the provided function,
+// subscribing again after a delay whenever the subscription fails.
+// It returns a function cancelling the subscription.
+func startInvalidation[K comparable](inv Invalidator[K], remove func(key K)) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			inv.Subscribe(ctx, remove)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(invalidationRetryDelay):
+			}
+		}
+	}()
+
+	return cancel
+}
+
+// startInvalidation starts applying the deletions of the other instances when the LRU cache was created with WithInvalidator.
+func (l *lru[K, V]) startInvalidation() {
+	if l.invalidator == nil {
+		return
+	}
+
+	l.stopInvalidation = startInvalidation(l.invalidator, l.invalidate)
+}
+
+// invalidate removes the provided key deleted by another instance, without broadcasting the deletion again.
+func (l *lru[K, V]) invalidate(key K) {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.remove(key, Deleted)
+}
+
+// broadcast broadcasts the deletion of the provided key to the other instances when the LRU cache was created with WithInvalidator.
+// Errors are ignored, invalidation is best effort.
+func (l *lru[K, V]) broadcast(key K) {
+	if l.invalidator == nil {
+		return
+	}
+
+	l.invalidator.Invalidate(context.Background(), key)
+}
@@ -0,0 +1,99 @@
+package lru
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// broker is an in-memory pub/sub system connecting the invalidators of several caches.
+type broker[K comparable] struct {
+	subs map[*memInvalidator[K]]func(key K)
+	sync.Mutex
+}
+
+// memInvalidator is an Invalidator connected to a broker.
+type memInvalidator[K comparable] struct {
+	broker *broker[K]
+}
+
+func (i *memInvalidator[K]) Invalidate(_ context.Context, key K) error {
+	i.broker.Mutex.Lock()
+	defer i.broker.Mutex.Unlock()
+
+	for sub, fn := range i.broker.subs {
+		if sub != i {
+			fn(key)
+		}
+	}
+
+	return nil
+}
+
+func (i *memInvalidator[K]) Subscribe(ctx context.Context, fn func(key K)) error {
+	i.broker.Mutex.Lock()
+	i.broker.subs[i] = fn
+	i.broker.Mutex.Unlock()
+
+	<-ctx.Done()
+
+	i.broker.Mutex.Lock()
+	delete(i.broker.subs, i)
+	i.broker.Mutex.Unlock()
+
+	return ctx.Err()
+}
+
+func (b *broker[K]) subscribers() int {
+	b.Mutex.Lock()
+	defer b.Mutex.Unlock()
+
+	return len(b.subs)
+}
+
+func TestInvalidation(t *testing.T) {
+	t.Run("should remove keys deleted by other instances", func(t *testing.T) {
+		b := &broker[int]{subs: map[*memInvalidator[int]]func(int){}}
+
+		l1 := New[int, int](3, WithInvalidator[int, int](&memInvalidator[int]{broker: b}))
+		defer l1.Close()
+		l2 := NewSharded[int, int](4, 2, WithInvalidator[int, int](&memInvalidator[int]{broker: b}))
+		defer l2.Close()
+
+		for b.subscribers() < 2 {
+			time.Sleep(time.Millisecond)
+		}
+
+		l1.Set(1, 1)
+		l1.Set(2, 2)
+		l2.Set(1, 1)
+		l2.Set(2, 2)
+
+		l1.Del(1)
+		l2.Del(2)
+
+		if !reflect.DeepEqual([]int{}, l1.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{}, l1.Keys())
+		}
+
+		if !reflect.DeepEqual(0, l2.Len()) {
+			t.Errorf("Expected 0; Actual = %v", l2.Len())
+		}
+	})
+
+	t.Run("should unsubscribe on close", func(t *testing.T) {
+		b := &broker[int]{subs: map[*memInvalidator[int]]func(int){}}
+
+		l := New[int, int](3, WithInvalidator[int, int](&memInvalidator[int]{broker: b}))
+		for b.subscribers() < 1 {
+			time.Sleep(time.Millisecond)
+		}
+
+		l.Close()
+		for b.subscribers() > 0 {
+			time.Sleep(time.Millisecond)
+		}
+	})
+}
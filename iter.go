@@ -0,0 +1,25 @@
+package lru
+
+import "iter"
+
+// forward returns an iterator over the provided key-value pairs in their order.
+func forward[K, V any](keys []K, values []V) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := range keys {
+			if !yield(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// backward returns an iterator over the provided key-value pairs in reverse order.
+func backward[K, V any](keys []K, values []V) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := len(keys) - 1; i >= 0; i-- {
+			if !yield(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
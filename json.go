@@ -0,0 +1,148 @@
+package lru
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// jsonEntry is a key-value pair as marshalled to JSON.
+type jsonEntry[K comparable, V any] struct {
+	Key     K          `json:"key"`               // Key of the item.
+	Value   V          `json:"value"`             // Value of the item.
+	Expires *time.Time `json:"expires,omitempty"` // When the item expires, omitted when it never expires.
+}
+
+// MarshalJSON encodes the contents of the LRU cache as a JSON array of objects
+// holding the key, the value and, for items with a TTL, the expiry time in RFC 3339 format,
+// ordered from the most recently used to the least recently used. Expired items are left out.
+func (l *lru[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.jsonEntries())
+}
+
+// UnmarshalJSON decodes a JSON array produced by MarshalJSON and stores its key-value pairs in the LRU cache,
+// preserving their recency order and expiry times. Items which already expired are skipped.
+// Restored items are more recently used than the items already in the cache.
+// Nothing is stored if the JSON cannot be decoded.
+func (l *lru[K, V]) UnmarshalJSON(data []byte) error {
+	entries, err := unmarshalEntries[K, V](data)
+	if err != nil {
+		return err
+	}
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		l.setJSON(entries[i])
+	}
+
+	return nil
+}
+
+// jsonEntries returns the unexpired items of the LRU cache, ordered from the most recently used to the least recently used.
+func (l *lru[K, V]) jsonEntries() []jsonEntry[K, V] {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	out := make([]jsonEntry[K, V], 0, l.length)
+	for h := l.front(); h != nil; h = l.next(h) {
+		if l.stale(h) {
+			continue
+		}
+
+		e := jsonEntry[K, V]{Key: h.key, Value: h.value}
+		if !h.ttl.IsZero() {
+			expires := h.ttl
+			e.Expires = &expires
+		}
+		out = append(out, e)
+	}
+
+	return out
+}
+
+// setJSON stores the provided unmarshalled entry as the most recently used item, unless it already expired.
+func (l *lru[K, V]) setJSON(e jsonEntry[K, V]) {
+	var expiry time.Time
+	if e.Expires != nil {
+		if e.Expires.Before(l.now()) {
+			return
+		}
+		expiry = *e.Expires
+	}
+
+	l.setWithCost(e.Key, e.Value, expiry, l.costOf(e.Value))
+}
+
+// unmarshalEntries decodes a JSON array of entries.
+func unmarshalEntries[K comparable, V any](data []byte) ([]jsonEntry[K, V], error) {
+	var out []jsonEntry[K, V]
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("lru: unmarshal cache: %w", err)
+	}
+
+	return out, nil
+}
+
+// MarshalJSON encodes the contents of every shard as a single JSON array, in the same format as an LRU cache.
+// Items are ordered from the most recently used to the least recently used within each shard.
+func (s *sharded[K, V]) MarshalJSON() ([]byte, error) {
+	var entries []jsonEntry[K, V]
+	for _, sh := range s.shards {
+		entries = append(entries, sh.jsonEntries()...)
+	}
+
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON decodes a JSON array produced by MarshalJSON
+// and stores every key-value pair in the shard responsible for its key, preserving the recency order within each shard.
+// Nothing is stored if the JSON cannot be decoded.
+func (s *sharded[K, V]) UnmarshalJSON(data []byte) error {
+	entries, err := unmarshalEntries[K, V](data)
+	if err != nil {
+		return err
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		sh := s.shard(entries[i].Key)
+		sh.locker.Lock()
+		sh.setJSON(entries[i])
+		sh.locker.Unlock()
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the contents of both tiers as a single JSON array, in the same order as Keys.
+// The expiry times of the tiers are not encoded.
+func (t *tiered[K, V]) MarshalJSON() ([]byte, error) {
+	keys, values := t.items()
+
+	entries := make([]jsonEntry[K, V], len(keys))
+	for i := range keys {
+		entries[i] = jsonEntry[K, V]{Key: keys[i], Value: values[i]}
+	}
+
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON decodes a JSON array produced by MarshalJSON and sets every key-value pair from the last to the first,
+// so the first items end up in the first tier and the last ones are demoted into the second tier.
+// Nothing is stored if the JSON cannot be decoded.
+func (t *tiered[K, V]) UnmarshalJSON(data []byte) error {
+	entries, err := unmarshalEntries[K, V](data)
+	if err != nil {
+		return err
+	}
+
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		t.set(entries[i].Key, entries[i].Value, nil)
+	}
+
+	return nil
+}
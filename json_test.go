@@ -0,0 +1,75 @@
+package lru
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJSON(t *testing.T) {
+	t.Run("should marshal the contents in recency order", func(t *testing.T) {
+		l := New[string, int](3)
+		l.Set("a", 1)
+		l.Set("b", 2)
+
+		actual, err := json.Marshal(l)
+		if err != nil {
+			t.Errorf("Expected nil; Actual = %v", err)
+		}
+
+		expected := `[{"key":"b","value":2},{"key":"a","value":1}]`
+		if !reflect.DeepEqual(expected, string(actual)) {
+			t.Errorf("Expected %v; Actual = %v", expected, string(actual))
+		}
+	})
+
+	t.Run("should marshal expiry times", func(t *testing.T) {
+		l := NewWithExpiry[string, int](3)
+		defer l.Close()
+
+		expires := time.Date(2100, 1, 2, 3, 4, 5, 0, time.UTC)
+		l.SetWithTTL("a", 1, time.Until(expires))
+
+		var actual []map[string]any
+		data, _ := json.Marshal(l)
+		json.Unmarshal(data, &actual)
+
+		parsed, _ := time.Parse(time.RFC3339Nano, actual[0]["expires"].(string))
+		if parsed.Sub(expires).Abs() > time.Second {
+			t.Errorf("Expected %v; Actual = %v", expires, parsed)
+		}
+	})
+
+	t.Run("should unmarshal the contents in recency order", func(t *testing.T) {
+		data := []byte(`[
+			{"key":"c","value":3},
+			{"key":"b","value":2,"expires":"2000-01-01T00:00:00Z"},
+			{"key":"a","value":1,"expires":"2100-01-01T00:00:00Z"}
+		]`)
+
+		l := NewWithExpiry[string, int](3)
+		defer l.Close()
+
+		if err := json.Unmarshal(data, l); err != nil {
+			t.Errorf("Expected nil; Actual = %v", err)
+		}
+
+		if !reflect.DeepEqual([]string{"c", "a"}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []string{"c", "a"}, l.Keys())
+		}
+	})
+
+	t.Run("should not store anything from invalid JSON", func(t *testing.T) {
+		l := New[string, int](3)
+
+		err := json.Unmarshal([]byte(`[{"key":"a","value":1},{"key":"b","value":"two"}]`), l)
+		if err == nil {
+			t.Errorf("Expected an error; Actual = %v", err)
+		}
+
+		if !reflect.DeepEqual(0, l.Len()) {
+			t.Errorf("Expected 0; Actual = %v", l.Len())
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package lru
+
+import "sync"
+
+// DefaultKeyLocks is the number of idle per-key locks kept for reuse by caches without a capacity limit.
+const DefaultKeyLocks = 1024
+
+// keyLock is the mutex of a single key handed out by LockKey.
+type keyLock struct {
+	sync.Mutex     // Mutex serializing the holders of the key.
+	refs       int // Number of goroutines holding or waiting for the mutex.
+}
+
+// keyLocks is the lock table of LockKey. Locks which are held or awaited are kept until released,
+// while idle ones are kept for reuse in an LRU cache bounded to the provided number of locks,
+// so the table does not grow with every key ever locked.
+// The zero value is ready to use.
+type keyLocks[K comparable] struct {
+	held       map[K]*keyLock    // Locks held or awaited keyed by their key.
+	idle       *lru[K, *keyLock] // Recently released locks kept for reuse.
+	sync.Mutex                   // Mutex guarding the table.
+}
+
+// lock locks the mutex of the provided key and returns the function unlocking it.
+// The number of idle locks kept for reuse is bounded to the provided size.
+func (t *keyLocks[K]) lock(key K, size int) func() {
+	t.Mutex.Lock()
+	if t.held == nil {
+		t.held = map[K]*keyLock{}
+		t.idle = newLRU[K, *keyLock](max(size, 1), false, nil)
+		t.idle.locker.disabled = true
+	}
+
+	kl, ok := t.held[key]
+	if !ok {
+		if kl, ok = t.idle.Peek(key); ok {
+			t.idle.Del(key)
+		} else {
+			kl = &keyLock{}
+		}
+		t.held[key] = kl
+	}
+	kl.refs++
+	t.Mutex.Unlock()
+
+	kl.Mutex.Lock()
+
+	unlocked := false
+	return func() {
+		t.Mutex.Lock()
+		defer t.Mutex.Unlock()
+
+		if unlocked {
+			return
+		}
+		unlocked = true
+
+		kl.Mutex.Unlock()
+		kl.refs--
+		if kl.refs == 0 {
+			delete(t.held, key)
+			t.idle.Set(key, kl)
+		}
+	}
+}
+
+// LockKey locks the provided key and returns the function unlocking it, so callers can serialize work on a key,
+// such as regenerating an expensive value, without a global mutex. Other callers locking the same key block
+// until it is unlocked, while other keys are unaffected. The key does not need to be cached.
+//
+// The lock is independent from the cache lock, so the cache may be used freely while holding it.
+// Released locks are kept for reuse, bounded to the capacity of the cache, or to DefaultKeyLocks when it is unbounded.
+// The unlock function must be called exactly once; further calls have no effect.
+//
+// Example usage:
+//
+//	unlock := cache.LockKey(key)
+//	defer unlock()
+//	if _, ok := cache.Get(key); !ok {
+//		cache.Set(key, render(key))
+//	}
+func (l *lru[K, V]) LockKey(key K) func() {
+	key = l.canonical(key)
+
+	size := l.Cap()
+	if size <= 0 {
+		size = DefaultKeyLocks
+	}
+
+	return l.keyLocks.lock(key, size)
+}
+
+// LockKey locks the provided key in the lock table of its shard and returns the function unlocking it.
+func (s *sharded[K, V]) LockKey(key K) func() {
+	return s.shard(key).LockKey(key)
+}
+
+// LockKey locks the provided key in the lock table of the first tier and returns the function unlocking it.
+func (t *tiered[K, V]) LockKey(key K) func() {
+	return t.hot.LockKey(key)
+}
@@ -0,0 +1,67 @@
+package lru
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLockKey(t *testing.T) {
+	t.Run("should serialize callers of the same key", func(t *testing.T) {
+		l := New[int, int](10)
+
+		var wg sync.WaitGroup
+		var running, overlaps atomic.Int32
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				unlock := l.LockKey(1)
+				defer unlock()
+
+				if running.Add(1) > 1 {
+					overlaps.Add(1)
+				}
+				if _, ok := l.Get(1); !ok {
+					l.Set(1, 1)
+				}
+				running.Add(-1)
+			}()
+		}
+		wg.Wait()
+
+		if !reflect.DeepEqual(int32(0), overlaps.Load()) {
+			t.Errorf("Expected %v; Actual = %v", 0, overlaps.Load())
+		}
+	})
+
+	t.Run("should not block other keys", func(t *testing.T) {
+		l := NewSharded[int, int](10, 2)
+		unlock := l.LockKey(1)
+		defer unlock()
+
+		done := make(chan struct{})
+		go func() {
+			l.LockKey(2)()
+			close(done)
+		}()
+		<-done
+	})
+
+	t.Run("should bound the idle locks to the capacity", func(t *testing.T) {
+		l := newLRU[int, int](2, false, nil)
+		for key := range 5 {
+			unlock := l.LockKey(key)
+			unlock()
+			unlock()
+		}
+
+		if !reflect.DeepEqual(0, len(l.keyLocks.held)) {
+			t.Errorf("Expected %v; Actual = %v", 0, len(l.keyLocks.held))
+		}
+		if !reflect.DeepEqual([]int{4, 3}, l.keyLocks.idle.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{4, 3}, l.keyLocks.idle.Keys())
+		}
+	})
+}
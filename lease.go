@@ -0,0 +1,103 @@
+package lru
+
+// lease counts the outstanding leases on a value taken with Acquire.
+type lease[V any] struct {
+	value   V    // Leased value, disposed of after the last release if it left the cache meanwhile.
+	count   int  // Number of leases which have not been released yet.
+	removed bool // Flag set once the value left the cache, so the last release disposes of it.
+}
+
+// held reports whether the item is protected from eviction and expiry, either pinned or leased.
+func (c *cache[K, V]) held() bool {
+	return c.pinned || c.lease != nil
+}
+
+// Acquire returns the value associated with the provided key like Get, along with a lease on it.
+// Until the returned release function is called, the item is skipped by eviction and never expires, like a pinned item,
+// so values holding resources, such as connections, are not closed while they are in use.
+// Explicit removals, such as Del, Purge or a Set of another value, still remove the value from the cache,
+// but the disposer configured with WithDisposer or WithAutoClose only runs once its last lease is released.
+//
+// The release function must be called exactly once per lease; further calls have no effect.
+// If the key is not found in the cache, an empty value, a nil release function and boolean false are returned.
+//
+// Example usage:
+//
+//	conn, release, ok := conns.Acquire(addr)
+//	if ok {
+//		defer release()
+//		conn.Send(msg)
+//	}
+func (l *lru[K, V]) Acquire(key K) (V, func(), bool) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	c, ok := l.lookup(key)
+	if !ok {
+		l.stats.Misses++
+
+		var emptyVal V
+		return emptyVal, nil, false
+	}
+
+	l.hit(c)
+	if c.lease == nil {
+		c.lease = &lease[V]{value: c.value}
+		l.track(c)
+	}
+	c.lease.count++
+
+	return c.value, l.releaser(key, c.lease), true
+}
+
+// releaser returns the function releasing one lease of the provided lease counter taken on the provided key.
+func (l *lru[K, V]) releaser(key K, ls *lease[V]) func() {
+	released := false
+
+	return func() {
+		l.locker.Lock()
+		defer l.locker.Unlock()
+
+		if released {
+			return
+		}
+		released = true
+
+		ls.count--
+		if ls.count > 0 {
+			return
+		}
+
+		if ls.removed {
+			if l.disposer != nil {
+				l.disposed = append(l.disposed, ls.value)
+			}
+			return
+		}
+
+		if c, ok := l.cache[key]; ok && c.lease == ls {
+			c.lease = nil
+			l.track(c)
+		}
+	}
+}
+
+// Acquire returns the value associated with the provided key from its shard along with a lease on it.
+func (s *sharded[K, V]) Acquire(key K) (V, func(), bool) {
+	return s.shard(key).Acquire(key)
+}
+
+// Acquire returns the value associated with the provided key along with a lease on it.
+// An item found in the second tier is promoted into the first tier, where it stays until its last lease is released.
+func (t *tiered[K, V]) Acquire(key K) (V, func(), bool) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	if value, ok := t.cold.Peek(key); ok && !t.hot.Contains(key) {
+		t.promote(key, value)
+	}
+
+	return t.hot.Acquire(key)
+}
@@ -0,0 +1,131 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAcquire(t *testing.T) {
+	t.Run("should not evict leased items", func(t *testing.T) {
+		l := New[int, int](2)
+		l.Set(1, 1)
+		l.Set(2, 2)
+
+		value, release, ok := l.Acquire(1)
+		if !ok || !reflect.DeepEqual(1, value) {
+			t.Errorf("Expected %v; Actual = %v", 1, value)
+		}
+		l.Get(2)
+		l.Set(3, 3)
+
+		if !reflect.DeepEqual([]int{3, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 1}, l.Keys())
+		}
+
+		release()
+		l.Get(3)
+		l.Set(4, 4)
+		if !reflect.DeepEqual([]int{4, 3}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{4, 3}, l.Keys())
+		}
+	})
+
+	t.Run("should not expire leased items until released", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		l := NewWithExpiry[int, int](2, WithClock[int, int](clock))
+		defer l.Close()
+
+		l.SetWithTTL(1, 1, time.Minute)
+		_, release, _ := l.Acquire(1)
+		clock.Advance(time.Hour)
+
+		if _, ok := l.Get(1); !ok {
+			t.Errorf("Expected leased key 1 to be found")
+		}
+
+		release()
+		if _, ok := l.Get(1); ok {
+			t.Errorf("Expected key 1 to expire once released")
+		}
+	})
+
+	t.Run("should defer disposal until the last release", func(t *testing.T) {
+		c := &closer{}
+		l := New[int, *closer](2, WithAutoClose[int, *closer]())
+		l.Set(1, c)
+
+		_, first, _ := l.Acquire(1)
+		_, second, _ := l.Acquire(1)
+		l.Set(1, &closer{})
+		if c.closed {
+			t.Errorf("Expected the leased value to stay open")
+		}
+
+		first()
+		first()
+		if c.closed {
+			t.Errorf("Expected the leased value to stay open")
+		}
+
+		second()
+		if !c.closed {
+			t.Errorf("Expected the value to be closed after the last release")
+		}
+	})
+
+	t.Run("should remove leased items explicitly", func(t *testing.T) {
+		var disposed []int
+		l := New[int, int](2, WithDisposer[int](func(v int) {
+			disposed = append(disposed, v)
+		}))
+		l.Set(1, 1)
+
+		_, release, _ := l.Acquire(1)
+		l.Del(1)
+		l.Set(1, 10)
+		if _, ok := l.Get(1); !ok || len(disposed) != 0 {
+			t.Errorf("Expected the new value to be cached and nothing disposed, Actual = %v", disposed)
+		}
+
+		release()
+		if !reflect.DeepEqual([]int{1}, disposed) {
+			t.Errorf("Expected %v; Actual = %v", []int{1}, disposed)
+		}
+
+		l.Set(2, 2)
+		l.Set(3, 3)
+		if !reflect.DeepEqual([]int{3, 2}, l.Keys()) {
+			t.Errorf("Expected the new value not to be leased; Actual = %v", l.Keys())
+		}
+	})
+
+	t.Run("should report missing keys", func(t *testing.T) {
+		l := New[int, int](2)
+
+		if _, release, ok := l.Acquire(1); ok || release != nil {
+			t.Errorf("Expected key 1 not to be found")
+		}
+		if !reflect.DeepEqual(uint64(1), l.Stats().Misses) {
+			t.Errorf("Expected %v; Actual = %v", 1, l.Stats().Misses)
+		}
+	})
+
+	t.Run("should lease items of the second tier", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](1), New[int, int](2))
+		defer l.Close()
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+
+		value, release, ok := l.Acquire(1)
+		if !ok || !reflect.DeepEqual(1, value) {
+			t.Errorf("Expected %v; Actual = %v", 1, value)
+		}
+		defer release()
+
+		if !l.Contains(1) {
+			t.Errorf("Expected key 1 to be found")
+		}
+	})
+}
@@ -0,0 +1,327 @@
+package lru
+
+import (
+	"iter"
+	"sort"
+	"sync"
+)
+
+// lfuItem represents an item in the LFU cache.
+type lfuItem[K comparable, V any] struct {
+	key   K              // Key associated with the cache item.
+	value V              // Value associated with the cache item.
+	freq  int            // Number of times the item has been accessed.
+	prev  *lfuItem[K, V] // Pointer to the previous item with the same frequency.
+	next  *lfuItem[K, V] // Pointer to the next item with the same frequency.
+}
+
+// lfuList is a doubly linked list of items sharing the same access frequency,
+// ordered from the most recently used to the least recently used.
+type lfuList[K comparable, V any] struct {
+	head *lfuItem[K, V] // Most recently used item with this frequency.
+	tail *lfuItem[K, V] // Least recently used item with this frequency.
+}
+
+// lfu represents a Least Frequently Used (LFU) cache.
+// Items with the lowest access frequency are evicted first,
+// ties are broken by evicting the least recently used of them.
+type lfu[K comparable, V any] struct {
+	cache      map[K]*lfuItem[K, V]   // Map storing cached items.
+	freqs      map[int]*lfuList[K, V] // Lists of items keyed by access frequency.
+	size       int                    // Maximum number of items the cache can hold.
+	minFreq    int                    // Lowest access frequency present in the cache.
+	stats      Stats                  // Usage counters of the cache.
+	sync.Mutex                        // Mutex for concurrent access.
+}
+
+// Contains checks if the provided key is present in the LFU cache.
+// The function does not affect the cache's state or modify any data.
+func (l *lfu[K, V]) Contains(key K) bool {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	_, ok := l.cache[key]
+	return ok
+}
+
+// Set adds or updates a key-value pair in the LFU cache.
+// Updating an existing key counts as an access and increments its frequency.
+// If the cache is full, the least frequently used item is evicted to make room for a new key.
+func (l *lfu[K, V]) Set(key K, value V) {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	if c, ok := l.cache[key]; ok {
+		c.value = value
+		l.touch(c)
+		return
+	}
+
+	if l.size < 0 {
+		return
+	}
+
+	if l.size != Unbounded && len(l.cache) >= l.size {
+		l.evict()
+	}
+
+	c := &lfuItem[K, V]{key: key, value: value, freq: 1}
+	l.push(c)
+	l.cache[key] = c
+	l.minFreq = 1
+}
+
+// Get retrieves the value associated with the provided key from the LFU cache
+// and increments its access frequency.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (l *lfu[K, V]) Get(key K) (V, bool) {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	if c, ok := l.cache[key]; ok {
+		l.stats.Hits++
+		l.touch(c)
+		return c.value, true
+	}
+
+	l.stats.Misses++
+
+	var emptyVal V
+	return emptyVal, false
+}
+
+// Peek retrieves the value associated with the provided key from the LFU cache
+// without incrementing its access frequency.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (l *lfu[K, V]) Peek(key K) (V, bool) {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	if c, ok := l.cache[key]; ok {
+		return c.value, true
+	}
+
+	var emptyVal V
+	return emptyVal, false
+}
+
+// Del removes the key-value pair associated with the provided key from the LFU cache.
+// If the key is found and the removal is successful, the function returns true.
+// If the key is not found, it returns false.
+func (l *lfu[K, V]) Del(key K) bool {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	c, ok := l.cache[key]
+	if !ok {
+		return false
+	}
+
+	l.unlink(c)
+	delete(l.cache, key)
+
+	return true
+}
+
+// Len returns the number of items currently stored in the LFU cache.
+func (l *lfu[K, V]) Len() int {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	return len(l.cache)
+}
+
+// Cap returns the maximum number of items the LFU cache can hold.
+func (l *lfu[K, V]) Cap() int {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	return l.size
+}
+
+// Purge removes all key-value pairs from the LFU cache, leaving it empty.
+func (l *lfu[K, V]) Purge() {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	l.cache = map[K]*lfuItem[K, V]{}
+	l.freqs = map[int]*lfuList[K, V]{}
+	l.minFreq = 0
+}
+
+// Resize changes the maximum number of items the LFU cache can hold.
+// If the new size is smaller than the current number of items,
+// the least frequently used items are evicted until the cache fits.
+func (l *lfu[K, V]) Resize(size int) {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	for size != Unbounded && len(l.cache) > size && len(l.cache) > 0 {
+		l.evict()
+	}
+
+	l.size = size
+}
+
+// Keys returns a snapshot of the keys in the LFU cache,
+// ordered from the most frequently used to the least frequently used.
+// Keys with the same frequency are ordered from the most recently used to the least recently used.
+func (l *lfu[K, V]) Keys() []K {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	out := make([]K, 0, len(l.cache))
+	l.walk(func(c *lfuItem[K, V]) {
+		out = append(out, c.key)
+	})
+
+	return out
+}
+
+// Values returns a snapshot of the values in the LFU cache, in the same order as Keys.
+func (l *lfu[K, V]) Values() []V {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	out := make([]V, 0, len(l.cache))
+	l.walk(func(c *lfuItem[K, V]) {
+		out = append(out, c.value)
+	})
+
+	return out
+}
+
+// All returns an iterator over the key-value pairs in the LFU cache, in the same order as Keys.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (l *lfu[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		forward(l.items())(yield)
+	}
+}
+
+// Backward returns an iterator over the key-value pairs in the LFU cache, in the reverse order of Keys.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (l *lfu[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		backward(l.items())(yield)
+	}
+}
+
+// items returns a snapshot of the keys and values in the LFU cache, in the same order as Keys.
+func (l *lfu[K, V]) items() ([]K, []V) {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	keys := make([]K, 0, len(l.cache))
+	values := make([]V, 0, len(l.cache))
+	l.walk(func(c *lfuItem[K, V]) {
+		keys = append(keys, c.key)
+		values = append(values, c.value)
+	})
+
+	return keys, values
+}
+
+// Stats returns a snapshot of the usage counters of the LFU cache.
+func (l *lfu[K, V]) Stats() Stats {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+
+	return l.stats
+}
+
+// Close is a no-op, the LFU cache owns no background goroutines.
+func (l *lfu[K, V]) Close() {}
+
+// walk calls fn for every item, from the most frequently used to the least frequently used.
+func (l *lfu[K, V]) walk(fn func(c *lfuItem[K, V])) {
+	freqs := make([]int, 0, len(l.freqs))
+	for f := range l.freqs {
+		freqs = append(freqs, f)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(freqs)))
+
+	for _, f := range freqs {
+		for h := l.freqs[f].head; h != nil; h = h.next {
+			fn(h)
+		}
+	}
+}
+
+// touch increments the access frequency of the provided item,
+// moving it to the head of the list for its new frequency.
+func (l *lfu[K, V]) touch(c *lfuItem[K, V]) {
+	l.unlink(c)
+	if _, ok := l.freqs[c.freq]; !ok && l.minFreq == c.freq {
+		l.minFreq++
+	}
+
+	c.freq++
+	l.push(c)
+}
+
+// evict removes the least recently used item among those with the lowest access frequency.
+func (l *lfu[K, V]) evict() {
+	list, ok := l.freqs[l.minFreq]
+	if !ok {
+		// the lowest frequency list was emptied by Del, find the next lowest one
+		l.minFreq = 0
+		for f := range l.freqs {
+			if l.minFreq == 0 || f < l.minFreq {
+				l.minFreq = f
+			}
+		}
+
+		if list, ok = l.freqs[l.minFreq]; !ok {
+			return
+		}
+	}
+
+	c := list.tail
+	l.unlink(c)
+	delete(l.cache, c.key)
+	l.stats.Evictions++
+}
+
+// push links the provided item in as the head of the list for its frequency.
+func (l *lfu[K, V]) push(c *lfuItem[K, V]) {
+	list, ok := l.freqs[c.freq]
+	if !ok {
+		list = &lfuList[K, V]{}
+		l.freqs[c.freq] = list
+	}
+
+	c.prev = nil
+	c.next = list.head
+	if list.head == nil {
+		list.tail = c
+	} else {
+		list.head.prev = c
+	}
+	list.head = c
+}
+
+// unlink detaches the provided item from the list for its frequency,
+// dropping the list once it becomes empty.
+func (l *lfu[K, V]) unlink(c *lfuItem[K, V]) {
+	list := l.freqs[c.freq]
+
+	if c.prev == nil {
+		list.head = c.next
+	} else {
+		c.prev.next = c.next
+	}
+
+	if c.next == nil {
+		list.tail = c.prev
+	} else {
+		c.next.prev = c.prev
+	}
+
+	c.prev = nil
+	c.next = nil
+
+	if list.head == nil {
+		delete(l.freqs, c.freq)
+	}
+}
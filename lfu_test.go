@@ -0,0 +1,78 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLFU(t *testing.T) {
+	t.Run("should evict least frequently used item", func(t *testing.T) {
+		l := NewLFU[int, int](3)
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+		l.Get(1)
+		l.Get(1)
+		l.Get(3)
+		l.Set(4, 4)
+
+		if l.Contains(2) {
+			t.Errorf("Expected key 2 to be evicted")
+		}
+
+		expected := []int{1, 3, 4}
+		if !reflect.DeepEqual(expected, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+		}
+	})
+
+	t.Run("should break frequency ties by recency", func(t *testing.T) {
+		l := NewLFU[int, int](2)
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+
+		expected := []int{3, 2}
+		if !reflect.DeepEqual(expected, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+		}
+	})
+
+	t.Run("should keep evicting after deletes", func(t *testing.T) {
+		l := NewLFU[int, int](2)
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Get(2)
+		l.Del(1)
+		l.Set(3, 3)
+		l.Get(3)
+		l.Get(3)
+		l.Set(4, 4)
+
+		expected := []int{3, 4}
+		if !reflect.DeepEqual(expected, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+		}
+
+		if !reflect.DeepEqual(2, l.Len()) {
+			t.Errorf("Expected 2; Actual = %v", l.Len())
+		}
+	})
+
+	t.Run("should not count peek as an access", func(t *testing.T) {
+		l := NewLFU[int, int](2)
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Peek(1)
+		l.Resize(1)
+
+		expected := []int{2}
+		if !reflect.DeepEqual(expected, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+		}
+	})
+}
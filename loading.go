@@ -0,0 +1,192 @@
+package lru
+
+import (
+	"context"
+	"time"
+)
+
+// Loader loads the value of a key which is missing from a loading cache,
+// typically from a database or a remote service.
+type Loader[K comparable, V any] func(ctx context.Context, key K) (V, error)
+
+// Load returns the value associated with the provided key, promoting it like Get.
+// If the key is missing, the value is loaded with the loader, stored in the cache and returned.
+// Errors returned by the loader are returned as is and nothing is stored,
+// unless negative caching is enabled with WithNegativeTTL.
+//
+// The cache lock is not held while the loader runs, so a slow loader does not block other keys.
+// Concurrent loads of the same key share a single call of the loader, made with the context of the first caller,
+// and every caller receives its result.
+//
+// Example usage:
+//
+//	user, err := users.Load(ctx, userID)
+func (l *lru[K, V]) Load(ctx context.Context, key K) (V, error) {
+	key = l.canonical(key)
+
+	if value, ok := l.get(key); ok {
+		return value, nil
+	}
+
+	return l.load(ctx, key)
+}
+
+// load invokes the loader for the provided key and stores the loaded value.
+// Concurrent loads of the same key share a single call of the loader.
+func (l *lru[K, V]) load(ctx context.Context, key K) (V, error) {
+	if err := l.failed(key); err != nil {
+		var emptyVal V
+		return emptyVal, err
+	}
+
+	return l.flight.do(key, func() (V, error) {
+		if value, ok := l.peek(key); ok {
+			return value, nil
+		}
+
+		value, err := l.loader(ctx, key)
+		if err != nil {
+			l.locker.Lock()
+			l.fail(key, err)
+			l.locker.Unlock()
+
+			var emptyVal V
+			return emptyVal, err
+		}
+
+		l.locker.Lock()
+		defer l.locker.Unlock()
+
+		var expiry time.Time
+		l.set(key, value, expiry)
+
+		return value, nil
+	})
+}
+
+// failure is a loader error remembered by negative caching.
+type failure struct {
+	err    error     // Error returned by the loader.
+	expiry time.Time // When the error is forgotten and the key may be loaded again.
+}
+
+// failed returns the remembered loader error of the provided key, if negative caching is enabled
+// and the error has not expired yet.
+func (l *lru[K, V]) failed(key K) error {
+	if l.negativeTTL <= 0 {
+		return nil
+	}
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	f, ok := l.failures[key]
+	if !ok {
+		return nil
+	}
+
+	if f.expiry.Before(l.now()) {
+		delete(l.failures, key)
+		return nil
+	}
+
+	return f.err
+}
+
+// fail remembers the loader error of the provided key for the negative TTL.
+// The number of remembered errors is bounded by the size of the cache:
+// expired errors are pruned first, then arbitrary ones when it is still full.
+func (l *lru[K, V]) fail(key K, err error) {
+	if l.negativeTTL <= 0 {
+		return
+	}
+
+	if l.failures == nil {
+		l.failures = map[K]failure{}
+	}
+
+	if l.size > 0 && len(l.failures) >= l.size {
+		now := l.now()
+		for k, f := range l.failures {
+			if f.expiry.Before(now) {
+				delete(l.failures, k)
+			}
+		}
+
+		for k := range l.failures {
+			if len(l.failures) < l.size {
+				break
+			}
+			delete(l.failures, k)
+		}
+	}
+
+	l.failures[key] = failure{err: err, expiry: l.now().Add(l.negativeTTL)}
+}
+
+// refreshDue reports whether the value of the provided item is older than the refresh-ahead age
+// configured with WithRefreshAfter.
+func (l *lru[K, V]) refreshDue(c *cache[K, V]) bool {
+	return l.refreshAfter > 0 && l.now().Sub(c.updated) > l.refreshAfter
+}
+
+// updateTime returns the current time when the cache has a loader which may refresh values in the background,
+// or the zero time otherwise, which spares reading the clock on every write when item ages are not needed.
+func (l *lru[K, V]) updateTime() time.Time {
+	if l.loader == nil {
+		return time.Time{}
+	}
+
+	return l.now()
+}
+
+// revalidate starts refreshing the value of the provided stale or aging item in the background,
+// unless the cache has no loader or a refresh of the item is already running.
+func (l *lru[K, V]) revalidate(c *cache[K, V]) {
+	if l.loader == nil || c.refreshing {
+		return
+	}
+
+	c.refreshing = true
+	go l.refresh(c.key, c.version)
+}
+
+// refresh reloads the value of the provided key with the loader and stores it,
+// provided the item is unchanged since the refresh started, as told by the provided version.
+// A value written while the loader ran is kept, as are the expiry and metadata of the refreshed item.
+// If the loader fails, the current value is kept and the next access retries the refresh.
+func (l *lru[K, V]) refresh(key K, version uint64) {
+	l.flight.do(key, func() (V, error) {
+		value, err := l.loader(context.Background(), key)
+
+		l.locker.Lock()
+		defer l.locker.Unlock()
+
+		c, ok := l.cache[key]
+		if !ok {
+			return value, err
+		}
+
+		c.refreshing = false
+		if err != nil || c.version != version || l.outdated(c) {
+			return value, err
+		}
+
+		l.renew(c, value)
+
+		return value, nil
+	})
+}
+
+// renew replaces the value of the provided item in place with a refreshed one.
+// The item keeps its position, tags, priority, pin and dependencies, while its dependents are removed; an item with a TTL gets the time
+// it was valid for, from when its value was stored to its expiry, counted again from now.
+func (l *lru[K, V]) renew(c *cache[K, V], value V) {
+	if !c.ttl.IsZero() && !c.updated.IsZero() {
+		c.ttl = l.now().Add(c.ttl.Sub(c.updated))
+		l.track(c)
+	}
+
+	l.replace(c, value)
+	l.evictOverCost()
+}
@@ -0,0 +1,288 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoading(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	t.Run("should load and store missing values", func(t *testing.T) {
+		calls := 0
+		l := NewLoading[int, int](3, func(_ context.Context, key int) (int, error) {
+			calls++
+			return key * 10, nil
+		})
+		defer l.Close()
+
+		actual, ok := l.Get(1)
+		if !ok || actual != 10 {
+			t.Errorf("Expected 10; Actual = %v", actual)
+		}
+
+		actual, err := l.Load(context.Background(), 1)
+		if err != nil || actual != 10 {
+			t.Errorf("Expected 10; Actual = %v, %v", actual, err)
+		}
+
+		if !reflect.DeepEqual(1, calls) {
+			t.Errorf("Expected 1; Actual = %v", calls)
+		}
+	})
+
+	t.Run("should return loader errors without storing anything", func(t *testing.T) {
+		l := NewLoading[int, int](3, func(_ context.Context, key int) (int, error) {
+			return 0, errBoom
+		})
+		defer l.Close()
+
+		_, err := l.Load(context.Background(), 1)
+		if !errors.Is(err, errBoom) {
+			t.Errorf("Expected %v; Actual = %v", errBoom, err)
+		}
+
+		_, ok := l.Get(1)
+		if !reflect.DeepEqual(false, ok) {
+			t.Errorf("Expected false; Actual = %v", ok)
+		}
+
+		if !reflect.DeepEqual(0, l.Len()) {
+			t.Errorf("Expected 0; Actual = %v", l.Len())
+		}
+	})
+
+	t.Run("should pass the context to the loader", func(t *testing.T) {
+		type ctxKey struct{}
+		l := NewLoading[int, string](3, func(ctx context.Context, _ int) (string, error) {
+			return ctx.Value(ctxKey{}).(string), nil
+		})
+		defer l.Close()
+
+		actual, _ := l.Load(context.WithValue(context.Background(), ctxKey{}, "value"), 1)
+		if !reflect.DeepEqual("value", actual) {
+			t.Errorf("Expected value; Actual = %v", actual)
+		}
+	})
+
+	t.Run("should share a single load between concurrent callers", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		l := NewLoading[int, int](3, func(_ context.Context, key int) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return key, nil
+		})
+		defer l.Close()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				actual, err := l.Load(context.Background(), 1)
+				if err != nil || actual != 1 {
+					t.Errorf("Expected 1; Actual = %v, %v", actual, err)
+				}
+			}()
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if !reflect.DeepEqual(int32(1), calls) {
+			t.Errorf("Expected 1; Actual = %v", calls)
+		}
+	})
+
+	t.Run("should forget a load which panicked", func(t *testing.T) {
+		var f flight[int, int]
+
+		func() {
+			defer func() { recover() }()
+			f.do(1, func() (int, error) { panic("boom") })
+		}()
+
+		_, err := f.do(1, func() (int, error) { return 1, nil })
+		if err != nil {
+			t.Errorf("Expected no error; Actual = %v", err)
+		}
+	})
+
+	t.Run("should serve stale values while refreshing them", func(t *testing.T) {
+		var calls int32
+		l := NewLoading[int, int](3, func(_ context.Context, key int) (int, error) {
+			return int(atomic.AddInt32(&calls, 1)), nil
+		}, WithDefaultTTL[int, int](10*time.Millisecond), WithStaleWhileRevalidate[int, int](time.Hour))
+		defer l.Close()
+
+		l.Get(1)
+		time.Sleep(20 * time.Millisecond)
+
+		actual, ok := l.Get(1)
+		if !ok || actual != 1 {
+			t.Errorf("Expected stale value 1; Actual = %v", actual)
+		}
+
+		deadline := time.Now().Add(time.Second)
+		for actual != 2 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+			actual, _ = l.Peek(1)
+		}
+
+		if !reflect.DeepEqual(2, actual) {
+			t.Errorf("Expected refreshed value 2; Actual = %v", actual)
+		}
+	})
+
+	t.Run("should refresh aging values ahead of expiry", func(t *testing.T) {
+		var calls int32
+		l := NewLoading[int, int](3, func(_ context.Context, key int) (int, error) {
+			return int(atomic.AddInt32(&calls, 1)), nil
+		}, WithDefaultTTL[int, int](time.Hour), WithRefreshAfter[int, int](10*time.Millisecond))
+		defer l.Close()
+
+		l.Get(1)
+
+		actual, _ := l.Get(1)
+		if !reflect.DeepEqual(1, actual) {
+			t.Errorf("Expected 1; Actual = %v", actual)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		actual, _ = l.Get(1)
+		if !reflect.DeepEqual(1, actual) {
+			t.Errorf("Expected current value 1; Actual = %v", actual)
+		}
+
+		deadline := time.Now().Add(time.Second)
+		for actual != 2 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+			actual, _ = l.Peek(1)
+		}
+
+		if !reflect.DeepEqual(2, actual) {
+			t.Errorf("Expected refreshed value 2; Actual = %v", actual)
+		}
+	})
+
+	t.Run("should keep values written while refreshing", func(t *testing.T) {
+		var calls int32
+		release, returned := make(chan struct{}), make(chan struct{})
+		l := NewLoading[int, int](3, func(_ context.Context, key int) (int, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return 1, nil
+			}
+
+			<-release
+			defer close(returned)
+			return 100, nil
+		}, WithDefaultTTL[int, int](time.Hour), WithRefreshAfter[int, int](10*time.Millisecond))
+		defer l.Close()
+
+		l.Get(1)
+		time.Sleep(20 * time.Millisecond)
+		l.Get(1)
+
+		l.Set(1, 5)
+		close(release)
+		<-returned
+		time.Sleep(10 * time.Millisecond)
+
+		if actual, _ := l.Peek(1); !reflect.DeepEqual(5, actual) {
+			t.Errorf("Expected written value 5; Actual = %v", actual)
+		}
+	})
+
+	t.Run("should keep the expiry and metadata of refreshed items", func(t *testing.T) {
+		var calls int32
+		l := NewLoading[int, int](3, func(_ context.Context, key int) (int, error) {
+			return int(atomic.AddInt32(&calls, 1)), nil
+		}, WithRefreshAfter[int, int](10*time.Millisecond))
+		defer l.Close()
+
+		l.SetWithTags(1, 0, "page")
+		l.UpdateTTL(1, time.Hour)
+		before, _ := l.GetEntry(1)
+
+		time.Sleep(20 * time.Millisecond)
+		l.Get(1)
+
+		deadline := time.Now().Add(time.Second)
+		actual, _ := l.Peek(1)
+		for actual != 1 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+			actual, _ = l.Peek(1)
+		}
+
+		after, _ := l.GetEntry(1)
+		if !reflect.DeepEqual(1, after.Value) || !reflect.DeepEqual([]string{"page"}, after.Tags) {
+			t.Errorf("Expected refreshed value 1 tagged %v; Actual = %v", []string{"page"}, after)
+		}
+		if !after.ExpiresAt.After(before.ExpiresAt) || after.ExpiresAt.After(time.Now().Add(time.Hour)) {
+			t.Errorf("Expected the TTL of an hour to be renewed; Actual = %v", after.ExpiresAt)
+		}
+	})
+
+	t.Run("should remove the dependents of refreshed items", func(t *testing.T) {
+		l := NewLoading[int, int](3, func(_ context.Context, key int) (int, error) {
+			return key, nil
+		}, WithRefreshAfter[int, int](10*time.Millisecond))
+		defer l.Close()
+
+		l.Set(1, 0)
+		l.SetWithDeps(2, 0, 1)
+
+		time.Sleep(20 * time.Millisecond)
+		l.Get(1)
+
+		deadline := time.Now().Add(time.Second)
+		for l.Contains(2) && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		if l.Contains(2) {
+			t.Errorf("Expected %v to be removed along with its refreshed dependency", 2)
+		}
+	})
+
+	t.Run("should remember loader errors for the negative TTL", func(t *testing.T) {
+		calls := 0
+		l := NewLoading[int, int](3, func(_ context.Context, key int) (int, error) {
+			calls++
+			return 0, errBoom
+		}, WithNegativeTTL[int, int](20*time.Millisecond))
+		defer l.Close()
+
+		for i := 0; i < 3; i++ {
+			_, err := l.Load(context.Background(), 1)
+			if !errors.Is(err, errBoom) {
+				t.Errorf("Expected %v; Actual = %v", errBoom, err)
+			}
+		}
+
+		if !reflect.DeepEqual(1, calls) {
+			t.Errorf("Expected 1; Actual = %v", calls)
+		}
+
+		time.Sleep(30 * time.Millisecond)
+		l.Load(context.Background(), 1)
+
+		if !reflect.DeepEqual(2, calls) {
+			t.Errorf("Expected 2; Actual = %v", calls)
+		}
+
+		l.Set(1, 1)
+		actual, err := l.Load(context.Background(), 1)
+		if err != nil || actual != 1 {
+			t.Errorf("Expected 1; Actual = %v, %v", actual, err)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package lru
+
+import "sync"
+
+// locker is the read-write lock of an LRU cache.
+// Every method does nothing when the lock is disabled, as for caches created with NewUnlocked.
+type locker struct {
+	mu       sync.RWMutex  // Lock guarding the cache, unused when disabled.
+	disabled bool          // Flag set for caches which are only used by a single goroutine.
+	deferred func() func() // Collects the work to run once the cache is unlocked for writing, nil when there is none.
+}
+
+// Lock locks the cache for writing.
+func (m *locker) Lock() {
+	if !m.disabled {
+		m.mu.Lock()
+	}
+}
+
+// Unlock unlocks the cache for writing, then runs the work deferred while it was locked.
+func (m *locker) Unlock() {
+	var work func()
+	if m.deferred != nil {
+		work = m.deferred()
+	}
+
+	if !m.disabled {
+		m.mu.Unlock()
+	}
+
+	if work != nil {
+		work()
+	}
+}
+
+// RLock locks the cache for reading.
+func (m *locker) RLock() {
+	if !m.disabled {
+		m.mu.RLock()
+	}
+}
+
+// RUnlock unlocks the cache for reading.
+func (m *locker) RUnlock() {
+	if !m.disabled {
+		m.mu.RUnlock()
+	}
+}
@@ -1,5 +1,17 @@
 package lru
 
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"time"
+)
+
+// Unbounded is the cache size which disables capacity eviction,
+// so items only leave the cache when they expire or are deleted explicitly.
+const Unbounded = 0
+
 type Base[K comparable, V any] interface {
 	// Contains checks if the provided key is present in the LRU cache.
 	// It returns true if the key is found in the cache, and false otherwise.
@@ -8,12 +20,17 @@ type Base[K comparable, V any] interface {
 
 	// Get retrieves the value associated with the provided key from the LRU cache.
 	// If the key exists in the cache, its corresponding value is returned along with a boolean true.
-	// If the key is not found in the cache, an empty value and boolean false are returned.
+	// If the key is not found in the cache, or its TTL has passed, an empty value and boolean false are returned.
 	//
 	// The Get operation updates the order of items in the cache to reflect the most recently accessed item.
 	// If the item exists, it is moved to the head of the cache to prioritize recently accessed items.
 	Get(key K) (value V, found bool)
 
+	// Peek retrieves the value associated with the provided key from the LRU cache
+	// without updating the order of items in the cache.
+	// If the key is not found in the cache, an empty value and boolean false are returned.
+	Peek(key K) (value V, found bool)
+
 	// Del removes the key-value pair associated with the provided key from the LRU cache.
 	// If the key is found and the removal is successful, the function returns true.
 	// If the key is not found, it returns false.
@@ -22,24 +39,260 @@ type Base[K comparable, V any] interface {
 	// If the removed item was the head or tail of the list, appropriate adjustments are made.
 	// The deleted item's memory is released for garbage collection.
 	Del(key K) bool
+
+	// Len returns the number of items currently stored in the LRU cache.
+	Len() int
+
+	// Cap returns the maximum number of items the LRU cache can hold.
+	// It returns Unbounded if the cache has no capacity limit.
+	Cap() int
+
+	// Purge removes all key-value pairs from the LRU cache, leaving it empty.
+	Purge()
+
+	// Resize changes the maximum number of items the LRU cache can hold.
+	// If the new size is smaller than the current number of items,
+	// the least recently used items are evicted until the cache fits.
+	Resize(size int)
+
+	// Keys returns a snapshot of the keys in the LRU cache,
+	// ordered from the most recently used to the least recently used.
+	Keys() []K
+
+	// Values returns a snapshot of the values in the LRU cache,
+	// ordered from the most recently used to the least recently used.
+	Values() []V
+
+	// All returns an iterator over the key-value pairs in the cache,
+	// ordered from the most recently used to the least recently used.
+	//
+	// The iterator ranges over a snapshot taken under the lock when iteration starts,
+	// so the loop body may freely use and modify the cache; such changes are not observed by the iteration.
+	All() iter.Seq2[K, V]
+
+	// Backward returns an iterator over the key-value pairs in the cache,
+	// ordered from the least recently used to the most recently used.
+	// It has the same consistency semantics as All.
+	Backward() iter.Seq2[K, V]
+
+	// Stats returns a snapshot of the usage counters of the cache,
+	// such as the number of hits, misses, evictions and expirations.
+	Stats() Stats
+
+	// Close stops any background goroutine owned by the LRU cache, such as the expiry cleaner.
+	// It is safe to call Close more than once; the cache must not be used after Close.
+	Close()
 }
 
-// LRU is a generic interface representing a Least Recently Used (LRU) cache.
-type LRU[K comparable, V any] interface {
+// Cache is a generic interface shared by every eviction policy provided by this package.
+type Cache[K comparable, V any] interface {
 	Base[K, V]
 
-	// Set adds or updates a key-value pair in the LRU cache with the provided key and value.
+	// Set adds or updates a key-value pair in the cache with the provided key and value.
 	// If the key already exists in the cache, its corresponding value will be updated.
-	// If the key is new, a new entry will be created with the provided value.
+	// If the key is new, a new entry will be created with the provided value,
+	// evicting an item chosen by the cache's policy when the cache is full.
 	//
-	// This function is thread-safe and utilizes a read-write lock to ensure concurrent access
+	// This function is thread-safe and utilizes a lock to ensure concurrent access
 	// to the cache's internal data structures.
 	Set(key K, value V)
 }
 
+// Core holds the methods shared by the LRU and LRUWithExpiry interfaces, every method of an LRU cache
+// apart from those storing items with or without a TTL.
+type Core[K comparable, V any] interface {
+	Base[K, V]
+
+	// GetE retrieves the value associated with the provided key like Get, reporting a miss as an error:
+	// ErrExpired if the TTL of the item has passed, or ErrNotFound otherwise.
+	GetE(key K) (value V, err error)
+
+	// SetE adds or updates a key-value pair in the cache like Set, returning ErrFull if the item was not stored,
+	// such as a new key while a cache created with WithRejectWhenFull is full.
+	SetE(key K, value V) error
+
+	// SetNX stores the provided value like Set, provided the key is missing from the cache, and reports whether it stored it.
+	// An existing item is left untouched: it is neither promoted nor counted as a hit.
+	SetNX(key K, value V) bool
+
+	// GetOldest returns the least recently used key-value pair of the cache without removing or promoting it.
+	// If the cache is empty, empty values and boolean false are returned.
+	GetOldest() (key K, value V, found bool)
+
+	// EvictionCandidates returns copies of up to n items the cache would evict next, in eviction order,
+	// without removing or promoting them. Pinned, leased and expired items are left out.
+	EvictionCandidates(n int) []Entry[K, V]
+
+	// RemoveOldest removes the least recently used key-value pair from the cache and returns it.
+	// The removal is reported as an eviction.
+	// If the cache is empty, empty values and boolean false are returned.
+	RemoveOldest() (key K, value V, found bool)
+
+	// Events returns the channel on which events are delivered when the cache was created with WithEvents.
+	// It returns nil otherwise. The channel is closed when the cache is closed.
+	Events() <-chan Event[K, V]
+
+	// GetMany retrieves the values associated with the provided keys, promoting them like Get,
+	// and returns the ones found in the cache. The cache is locked once for all the keys.
+	GetMany(keys []K) map[K]V
+
+	// SetMany adds or updates the provided key-value pairs like Set, locking the cache once for all of them.
+	SetMany(items map[K]V)
+
+	// DelMany removes the key-value pairs associated with the provided keys like Del,
+	// locking the cache once for all of them, and returns how many were removed.
+	DelMany(keys []K) int
+
+	// Compute atomically updates the value stored for the provided key with the provided remapping function,
+	// which receives the current value and whether the key is present, and runs while the cache is locked.
+	// The returned value is stored if the function returns true, otherwise the key is removed.
+	// It returns the stored value and true, or an empty value and false if the key was removed or left missing.
+	Compute(key K, fn func(old V, exists bool) (V, bool)) (value V, ok bool)
+
+	// SetEvicted adds or updates a key-value pair in the cache like Set,
+	// and returns the item evicted to make room for it, if any.
+	SetEvicted(key K, value V) (evictedKey K, evictedValue V, evicted bool)
+
+	// Swap stores the provided value for the provided key like Set and returns the value it replaced, if any.
+	// The existed result is true if the key was present.
+	Swap(key K, value V) (old V, existed bool)
+
+	// CompareAndSwapFunc stores the provided new value for the provided key like Set,
+	// provided the key is present and its current value equals the provided old value according to the provided function.
+	// It reports whether the value was swapped.
+	CompareAndSwapFunc(key K, old, value V, equal func(a, b V) bool) (swapped bool)
+
+	// SetWithPriority adds or updates a key-value pair in the cache like Set, with the provided eviction priority.
+	// Eviction picks the least recently used item of the lowest priority present in the cache.
+	SetWithPriority(key K, value V, priority Priority)
+
+	// SetWithTags adds or updates a key-value pair in the cache like Set, attaching the provided tags to it.
+	// The tags replace those of a previous value stored for the key.
+	SetWithTags(key K, value V, tags ...string)
+
+	// InvalidateTag removes every item carrying the provided tag from the cache and returns how many were removed.
+	InvalidateTag(tag string) int
+
+	// DeleteFunc removes every item for which the provided function returns true and returns how many were removed,
+	// in a single lock acquisition. The function runs under the lock, so it must not use the cache.
+	DeleteFunc(fn func(key K, value V) bool) int
+
+	// SetWithDeps adds or updates a key-value pair in the cache like Set, declaring that it depends on the provided keys:
+	// deleting or updating one of them removes it too, and removals cascade to its own dependents.
+	SetWithDeps(key K, value V, deps ...K)
+
+	// Pin protects the item stored for the provided key from leaving the cache on its own:
+	// it is skipped by eviction and never expires, but explicit removals such as Del still remove it.
+	// It returns false if the key is not found in the cache.
+	Pin(key K) bool
+
+	// Unpin lets the item stored for the provided key be evicted and expire again.
+	// It returns false if the key is not found in the cache or is not pinned.
+	Unpin(key K) bool
+
+	// GetWithVersion retrieves the value associated with the provided key like Get, along with its version,
+	// which increases with every write of the key, for use with SetIfVersion.
+	GetWithVersion(key K) (value V, version uint64, found bool)
+
+	// SetIfVersion stores the provided value like Set, provided the version of the value currently stored for the key
+	// is the provided version, or the key is missing and the provided version is zero. It reports whether it stored the value.
+	SetIfVersion(key K, value V, version uint64) bool
+
+	// InvalidateAll removes every item from the cache in constant time: the items stored so far are treated as missing
+	// from then on and are reclaimed lazily, instead of being walked under the lock like Purge does.
+	InvalidateAll()
+
+	// Freeze pauses capacity eviction and expiry until Thaw is called: the cache grows past its capacity
+	// and items whose TTL passes are still served, so nothing leaves the cache unless it is removed explicitly.
+	Freeze()
+
+	// Thaw resumes capacity eviction and expiry paused by Freeze, removing the expired items
+	// and evicting the least recently used items until the cache fits its capacity again.
+	Thaw()
+
+	// Acquire returns the value associated with the provided key like Get, along with a lease on it
+	// which protects the item from eviction and expiry until the returned release function is called.
+	// Explicit removals and replacements still remove the value, but defer its disposal until the last release.
+	// If the key is not found in the cache, an empty value, a nil release function and boolean false are returned.
+	Acquire(key K) (value V, release func(), found bool)
+
+	// LockKey locks the provided key, whether it is cached or not, and returns the function unlocking it,
+	// so callers can serialize work on a key without a global mutex. The lock is independent from the cache lock.
+	LockKey(key K) (unlock func())
+
+	// GetEntry returns a copy of the item stored for the provided key along with its metadata,
+	// such as when it was created and last accessed and how often it was read,
+	// without promoting it or counting the lookup as an access.
+	// If the key is not found in the cache, an empty entry and boolean false are returned.
+	GetEntry(key K) (entry Entry[K, V], found bool)
+
+	// Snapshot returns a consistent copy of the unexpired items in the cache along with their metadata,
+	// ordered from the most recently used to the least recently used. An empty cache returns an empty slice.
+	// Sharded caches track recency per shard, so their items are only ordered within each shard, shard after shard.
+	Snapshot() []Entry[K, V]
+
+	// Warm stores the provided entries, ordered like Snapshot, in a single lock acquisition,
+	// preserving their order and metadata without invoking the eviction callback nor publishing events.
+	Warm(entries []Entry[K, V])
+
+	// Merge imports the items of the provided cache, such as another LRU cache, keeping their relative recency order
+	// and making them more recently used than the items already in the cache. Keys present in both caches are stored
+	// with the value returned by the conflict function, which receives both values; a nil function keeps the source value.
+	Merge(src interface{ Snapshot() []Entry[K, V] }, conflict func(dst, src V) V)
+
+	// AsReadOnly returns a read-only view of the cache, exposing only Get, Peek, Contains, Len and Keys.
+	AsReadOnly() ReadOnly[K, V]
+
+	// TopKeys returns up to n of the keys looked up the most often, ordered from the most frequent,
+	// with their estimated number of lookups. It returns nil unless tracking is enabled with WithHotKeys.
+	TopKeys(n int) []KeyStat[K]
+
+	// Save writes a snapshot of the cache to the provided writer, encoded with encoding/gob.
+	// The snapshot holds every key-value pair along with its expiry time and cost,
+	// ordered from the least recently used to the most recently used. Expired items are left out.
+	Save(w io.Writer) error
+
+	// Restore reads a snapshot written by Save from the provided reader and stores its key-value pairs in the cache,
+	// preserving their recency order, expiry times and costs.
+	// Nothing is stored if the snapshot cannot be decoded.
+	Restore(r io.Reader) error
+
+	// MarshalJSON encodes the contents of the cache as a JSON array of objects
+	// holding the key, the value and, for items with a TTL, the expiry time,
+	// ordered from the most recently used to the least recently used.
+	MarshalJSON() ([]byte, error)
+
+	// UnmarshalJSON decodes a JSON array produced by MarshalJSON and stores its key-value pairs in the cache,
+	// preserving their recency order and expiry times.
+	UnmarshalJSON(data []byte) error
+}
+
+// LRU is a generic interface representing a Least Recently Used (LRU) cache.
+type LRU[K comparable, V any] interface {
+	Cache[K, V]
+	Core[K, V]
+
+	// GetOrSet returns the existing value for the key if present, promoting it like Get.
+	// Otherwise, it stores the provided value and returns it.
+	// The loaded result is true if the value was loaded, false if it was stored.
+	GetOrSet(key K, value V) (actual V, loaded bool)
+
+	// GetOrCompute returns the existing value for the key if present, promoting it like Get.
+	// Otherwise, it calls fn, stores the result and returns it.
+	// The loaded result is true if the value was loaded, false if it was computed.
+	//
+	// Concurrent callers missing the same key share a single call of fn and all receive its result, or panic if it panicked.
+	GetOrCompute(key K, fn func() V) (actual V, loaded bool)
+}
+
 // LRUWithExpiry is a generic interface representing a Least Recently Used (LRU) cache.
 type LRUWithExpiry[K comparable, V any] interface {
-	Base[K, V]
+	Core[K, V]
+
+	// Set adds or updates a key-value pair in the LRU cache with the provided key and value.
+	// The item expires after the default TTL configured with WithDefaultTTL,
+	// or never expires when no default TTL is configured.
+	Set(key K, value V)
 
 	// SetWithExpiry adds or updates a key-value pair in the LRU cache with the provided key, value, and time-to-live (TTL).
 	// If the key already exists in the cache, its corresponding value and TTL will be updated.
@@ -50,30 +303,434 @@ type LRUWithExpiry[K comparable, V any] interface {
 	// This function is thread-safe and utilizes a read-write lock to ensure concurrent access
 	// to the cache's internal data structures.
 	SetWithExpiry(key K, value V, ttl int)
+
+	// SetWithTTL adds or updates a key-value pair in the LRU cache with the provided key, value, and time-to-live (TTL).
+	// It behaves like SetWithExpiry but takes the TTL as a time.Duration.
+	SetWithTTL(key K, value V, ttl time.Duration)
+
+	// SetWithDeadline adds or updates a key-value pair in the LRU cache which expires at the provided instant,
+	// such as the expiry claim of a token. A zero deadline stores the item like Set.
+	SetWithDeadline(key K, value V, deadline time.Time)
+
+	// GetTTL returns the remaining lifetime of the item stored for the provided key, without promoting it.
+	// Items stored without a TTL report a zero duration, items served stale within the window configured
+	// with WithStaleWhileRevalidate report a negative one.
+	// If the key is not found in the cache, or its TTL has passed, zero and boolean false are returned.
+	GetTTL(key K) (ttl time.Duration, found bool)
+
+	// UpdateTTL changes the TTL of the item stored for the provided key to expire the provided duration from now,
+	// without rewriting its value or promoting it. It returns false if the key is not found in the cache.
+	UpdateTTL(key K, ttl time.Duration) bool
+}
+
+// LRUWithCost is a generic interface representing a Least Recently Used (LRU) cache
+// bounded by the total cost of its items as well as their number.
+type LRUWithCost[K comparable, V any] interface {
+	LRU[K, V]
+
+	// SetWithCost adds or updates a key-value pair in the LRU cache with the provided key, value, and cost.
+	// If the total cost of the cached items exceeds the maximum cost of the cache,
+	// the least recently used items are evicted until it fits.
+	// An item whose cost alone exceeds the maximum cost is not stored.
+	//
+	// Items stored with Set, GetOrSet or GetOrCompute have the cost reported by the Sizer
+	// configured with WithSizer, or a cost of 1 when no sizer is configured.
+	SetWithCost(key K, value V, cost int64)
+
+	// Cost returns the total cost of the items currently stored in the LRU cache.
+	Cost() int64
+
+	// MaxCost returns the maximum total cost of the items the LRU cache can hold.
+	MaxCost() int64
+}
+
+// LoadingLRU is a generic interface representing a read-through Least Recently Used (LRU) cache,
+// which loads missing values with a Loader.
+type LoadingLRU[K comparable, V any] interface {
+	LRUWithExpiry[K, V]
+
+	// Load returns the value associated with the provided key, promoting it like Get.
+	// If the key is missing, the value is loaded with the loader, stored in the cache and returned.
+	// Errors returned by the loader are returned as is and nothing is stored.
+	Load(ctx context.Context, key K) (V, error)
+}
+
+// BackedLRU is a generic interface representing a Least Recently Used (LRU) cache in front of a Store.
+// Misses are read from the store and writes go through to the store.
+type BackedLRU[K comparable, V any] interface {
+	Base[K, V]
+
+	// GetE retrieves the value associated with the provided key like Get, reporting a miss as an error:
+	// ErrExpired if the TTL of the item has passed, or ErrNotFound otherwise.
+	GetE(key K) (value V, err error)
+
+	// GetOldest returns the least recently used key-value pair of the cache without removing or promoting it.
+	// If the cache is empty, empty values and boolean false are returned.
+	GetOldest() (key K, value V, found bool)
+
+	// RemoveOldest removes the least recently used key-value pair from the cache and returns it.
+	// The removal is reported as an eviction. The store is left untouched.
+	// If the cache is empty, empty values and boolean false are returned.
+	RemoveOldest() (key K, value V, found bool)
+
+	// Events returns the channel on which events are delivered when the cache was created with WithEvents.
+	// It returns nil otherwise. The channel is closed when the cache is closed.
+	Events() <-chan Event[K, V]
+
+	// Load returns the value associated with the provided key, promoting it like Get.
+	// If the key is missing, the value is read from the store, stored in the cache and returned.
+	// Errors returned by the store are returned as is and nothing is stored.
+	Load(ctx context.Context, key K) (V, error)
+
+	// Set writes the provided key-value pair to the store and, once the store accepted it, to the cache.
+	// If the store fails, its error is returned and the cache is left untouched.
+	// In write-behind mode, the write is queued for the store instead.
+	Set(ctx context.Context, key K, value V) error
+
+	// Delete removes the provided key from the store and from the cache.
+	// In write-behind mode, the deletion is queued for the store instead.
+	// Del only removes the key from the cache.
+	Delete(ctx context.Context, key K) error
+
+	// Flush waits until every write queued so far in write-behind mode reached the store, or the context is done.
+	// It returns the errors of the writes dropped after their last retry since the previous Flush.
+	// In write-through mode, there is nothing to flush and it returns nil immediately.
+	Flush(ctx context.Context) error
 }
 
 // New creates a new instance of a Least Recently Used (LRU) cache with the specified size.
+// Optional behaviour can be configured by passing one or more Option values.
 // It returns a pointer to an lru[K, V] instance.
-func New[K comparable, V any](size int) LRU[K, V] {
-	return &lru[K, V]{
-		cache:  map[K]*cache[K, V]{},
-		size:   size,
-		length: 0,
-		head:   nil,
+//
+// A size of Unbounded creates a cache which never evicts items for capacity,
+// only when they expire or are deleted explicitly.
+// A cache created with a negative size holds nothing; use NewE to reject such sizes.
+func New[K comparable, V any](size int, opts ...Option[K, V]) LRU[K, V] {
+	out := newLRU(size, false, opts)
+	out.startPersistence()
+	out.startInvalidation()
+
+	return out
+}
+
+// NewUnlocked creates a new instance of a Least Recently Used (LRU) cache like New, but without any locking,
+// sparing the cost of the lock when the cache is owned by a single goroutine, such as a stage of a pipeline.
+//
+// The returned cache is NOT safe for concurrent use: every call, including Close, must be made from the same goroutine
+// or be synchronized by the caller. Options running background goroutines which access the cache,
+// such as WithInvalidator or WithPersistence with a positive interval, must not be used with it.
+// WithMemoryPressure is ignored: no goroutine watches the memory use of the process for it.
+//
+// Example usage:
+//
+//	seen := lru.NewUnlocked[string, struct{}](10000)
+//	for event := range events {
+//		if _, ok := seen.Get(event.ID); ok {
+//			continue
+//		}
+//		seen.Set(event.ID, struct{}{})
+//		process(event)
+//	}
+func NewUnlocked[K comparable, V any](size int, opts ...Option[K, V]) LRU[K, V] {
+	out := newLRU(size, false, append(opts[:len(opts):len(opts)], func(l *lru[K, V]) {
+		l.locker.disabled = true
+	}))
+	out.startPersistence()
+	out.startInvalidation()
+
+	return out
+}
+
+// NewE is like New but returns an error wrapping ErrInvalidSize if the size is negative.
+func NewE[K comparable, V any](size int, opts ...Option[K, V]) (LRU[K, V], error) {
+	if err := validateSize(size); err != nil {
+		return nil, err
 	}
+
+	return New(size, opts...), nil
 }
 
-// New creates a new instance of a Least Recently Used (LRU) cache with the specified size.
+// NewWithExpiry creates a new instance of a Least Recently Used (LRU) cache with item expiry and the specified size.
+// Optional behaviour can be configured by passing one or more Option values.
 // It returns a pointer to an lru[K, V] instance.
-func NewWithExpiry[K comparable, V any](size int) LRUWithExpiry[K, V] {
-	out := &lru[K, V]{
-		cache:      map[K]*cache[K, V]{},
-		size:       size,
-		withExpiry: true,
-		length:     0,
-		head:       nil,
+//
+// With a size of Unbounded, items only leave the cache when they expire or are deleted explicitly.
+func NewWithExpiry[K comparable, V any](size int, opts ...Option[K, V]) LRUWithExpiry[K, V] {
+	out := newLRU(size, true, opts)
+	out.startCleaner()
+	out.startPersistence()
+	out.startInvalidation()
+
+	return out
+}
+
+// NewWithExpiryE is like NewWithExpiry but returns an error wrapping ErrInvalidSize if the size is negative.
+func NewWithExpiryE[K comparable, V any](size int, opts ...Option[K, V]) (LRUWithExpiry[K, V], error) {
+	if err := validateSize(size); err != nil {
+		return nil, err
+	}
+
+	return NewWithExpiry(size, opts...), nil
+}
+
+// NewWithCost creates a new instance of a Least Recently Used (LRU) cache
+// bounded by the specified size and by the specified maximum total cost of its items.
+// Optional behaviour can be configured by passing one or more Option values.
+func NewWithCost[K comparable, V any](size int, maxCost int64, opts ...Option[K, V]) LRUWithCost[K, V] {
+	out := newLRU(size, false, opts)
+	out.maxCost = maxCost
+	out.startPersistence()
+	out.startInvalidation()
+
+	return out
+}
+
+// NewLoading creates a new instance of a read-through Least Recently Used (LRU) cache with the specified size,
+// which loads missing values with the provided loader. The loader must not be nil.
+//
+// Get loads missing values with a background context and reports a miss if the loader fails,
+// Load lets callers pass their own context and receive the loader's error.
+// Loaded values expire after the default TTL configured with WithDefaultTTL, if any.
+// Optional behaviour can be configured by passing one or more Option values.
+func NewLoading[K comparable, V any](size int, loader Loader[K, V], opts ...Option[K, V]) LoadingLRU[K, V] {
+	out := newLRU(size, true, opts)
+	out.loader = loader
+	out.startCleaner()
+	out.startPersistence()
+	out.startInvalidation()
+
+	return out
+}
+
+// NewBacked creates a new instance of a Least Recently Used (LRU) cache with the specified size
+// in front of the provided store. The store must not be nil.
+//
+// Get and Load read missing values from the store like a cache created with NewLoading,
+// Set and Delete write through to the store before updating the cache,
+// or queue their writes for the store when write-behind mode is enabled with WithWriteBehind.
+// Values expire after the default TTL configured with WithDefaultTTL, if any.
+// Optional behaviour can be configured by passing one or more Option values.
+//
+// Example usage:
+//
+//	users := lru.NewBacked[int, User](100, userStore)
+//	err := users.Set(ctx, user.ID, user)
+func NewBacked[K comparable, V any](size int, store Store[K, V], opts ...Option[K, V]) BackedLRU[K, V] {
+	out := newLRU(size, true, opts)
+	b := &backed[K, V]{lru: out, store: store}
+	if out.writeBehind != nil {
+		b.writer = newWriter(store, *out.writeBehind, hasherOf(out))
 	}
+	out.loader = b.load
 	out.startCleaner()
+	out.startPersistence()
+	out.startInvalidation()
+
+	return b
+}
+
+// NewSharded creates a new instance of a Least Recently Used (LRU) cache split into the specified number of shards.
+// The size is spread evenly across the shards and every key is hashed to exactly one of them,
+// so recency and eviction are tracked per shard rather than for the cache as a whole.
+// The number of shards is clamped between 1 and size, an Unbounded sharded cache uses a single shard.
+//
+// Sharding trades exact LRU ordering for lower lock contention under heavy concurrent access.
+// Optional behaviour can be configured by passing one or more Option values, which apply to every shard.
+func NewSharded[K comparable, V any](size, shards int, opts ...Option[K, V]) LRU[K, V] {
+	if shards > size {
+		shards = size
+	}
+	if shards < 1 {
+		shards = 1
+	}
+
+	out := &sharded[K, V]{
+		shards: make([]*lru[K, V], shards),
+	}
+	for i, n := range shardSizes(size, shards) {
+		out.shards[i] = newLRU(n, false, opts)
+
+		// every shard publishes to the events channel of the first one
+		if i == 0 && out.shards[0].events != nil {
+			events := out.shards[0].events
+			opts = append(opts[:len(opts):len(opts)], func(l *lru[K, V]) {
+				l.events = events
+			})
+		}
+	}
+	out.hasher = hasherOf(out.shards[0])
+
+	// the shards share their dependencies, dependents are removed from their own shard
+	deps := newDependencies[K]()
+	for _, shard := range out.shards {
+		shard.dependOn(deps, func(keys []K) {
+			out.DelMany(keys)
+		})
+	}
+
+	// the shards are persisted together, keys are rehashed to their shards on restore
+	if path := out.shards[0].persistPath; path != "" {
+		out.persistence = startPersistence(out, path, out.shards[0].persistInterval)
+	}
+
+	// the shards subscribe together, keys are removed from their shard
+	if inv := out.shards[0].invalidator; inv != nil {
+		out.stopInvalidation = startInvalidation(inv, func(key K) {
+			out.shard(key).invalidate(key)
+		})
+	}
+
+	return out
+}
+
+// NewTiered creates a new instance of a two-tier cache in front of the provided caches.
+// Items are stored in the first tier, when it is full its least recently used item is demoted into the second tier,
+// and items found in the second tier are promoted back into the first one.
+// The second tier is typically a bigger cache or one backed by slower storage, such as a disk.
+//
+// The tiered cache owns both tiers: they must not be used directly once passed to NewTiered,
+// and closing the tiered cache closes them.
+//
+// Example usage:
+//
+//	cache := lru.NewTiered[string, []byte](lru.New[string, []byte](1000), lru.New[string, []byte](100000))
+func NewTiered[K comparable, V any](l1 LRU[K, V], l2 Cache[K, V]) LRU[K, V] {
+	return &tiered[K, V]{hot: l1, cold: l2}
+}
+
+// NewLFU creates a new instance of a Least Frequently Used (LFU) cache with the specified size.
+// When the cache is full, the item with the lowest access frequency is evicted,
+// ties are broken by evicting the least recently used of them.
+func NewLFU[K comparable, V any](size int) Cache[K, V] {
+	return &lfu[K, V]{
+		cache: map[K]*lfuItem[K, V]{},
+		freqs: map[int]*lfuList[K, V]{},
+		size:  size,
+	}
+}
+
+// NewTwoQueue creates a new instance of a 2Q cache with the specified size.
+// New items enter a probationary FIFO queue holding roughly recentRatio of the size,
+// and are promoted to a protected LRU queue when accessed again, which makes the cache scan resistant.
+// Up to ghostRatio times the size of keys evicted from the probationary queue are remembered,
+// so they are admitted straight into the protected queue when set again.
+// Ratios are clamped between 0 and 1; DefaultRecentRatio and DefaultGhostRatio are sensible defaults.
+func NewTwoQueue[K comparable, V any](size int, recentRatio, ghostRatio float64) Cache[K, V] {
+	out := &twoQueue[K, V]{
+		recentRatio: clampRatio(recentRatio),
+		ghostRatio:  clampRatio(ghostRatio),
+		recent:      newLRU[K, V](size, false, nil),
+		frequent:    newLRU[K, V](size, false, nil),
+		ghost:       newLRU[K, struct{}](size, false, nil),
+	}
+	out.resize(size)
+
+	return out
+}
+
+// NewSegmented creates a new instance of a segmented LRU (SLRU) cache with the specified size.
+// New items enter a probationary segment and are promoted to a protected segment holding up to protectedRatio
+// of the size when accessed again. Items pushed out of the protected segment are demoted back to the probationary one,
+// and evictions come from the probationary segment first, which makes the cache scan resistant.
+// The ratio is clamped between 0 and 1; DefaultProtectedRatio is a sensible default.
+//
+// Example usage:
+//
+//	cache := lru.NewSegmented[string, int](1000, lru.DefaultProtectedRatio)
+func NewSegmented[K comparable, V any](size int, protectedRatio float64) Cache[K, V] {
+	out := &segmented[K, V]{
+		protectedRatio: clampRatio(protectedRatio),
+		probation:      newLRU[K, V](size, false, nil),
+		protected:      newLRU[K, V](size, false, nil),
+	}
+	out.resize(size)
+
+	return out
+}
+
+// NewSecondChance creates a new instance of a CLOCK, or second-chance, cache with the specified size,
+// which approximates LRU with much cheaper reads. Get only sets a reference bit on the item under the read lock,
+// instead of moving it to the head of a list, so concurrent reads do not contend.
+// When the cache is full, a hand sweeps the items, clearing their reference bits,
+// and evicts the first item which was not read since the hand last passed over it.
+//
+// Example usage:
+//
+//	cache := lru.NewSecondChance[string, []byte](1_000_000)
+func NewSecondChance[K comparable, V any](size int) Cache[K, V] {
+	return &secondChance[K, V]{
+		cache: map[K]int{},
+		size:  size,
+	}
+}
+
+// NewSampled creates a new instance of a cache with the specified size approximating LRU by sampling, like Redis.
+// Items are kept in no particular order, so neither reads nor writes maintain a linked list:
+// Get only stamps the item with a logical clock under the read lock.
+// When the cache is full, samples random items are compared and the least recently used of them is evicted.
+// Larger samples evict closer to exact LRU at the cost of slower writes;
+// non-positive sample sizes use DefaultSampleSize.
+//
+// Example usage:
+//
+//	cache := lru.NewSampled[string, []byte](1_000_000, lru.DefaultSampleSize)
+func NewSampled[K comparable, V any](size, samples int) Cache[K, V] {
+	if samples <= 0 {
+		samples = DefaultSampleSize
+	}
+
+	return &sampled[K, V]{
+		cache:   map[K]*sampledItem[K, V]{},
+		size:    size,
+		samples: samples,
+	}
+}
+
+func clampRatio(ratio float64) float64 {
+	if ratio < 0 {
+		return 0
+	}
+	if ratio > 1 {
+		return 1
+	}
+
+	return ratio
+}
+
+func validateSize(size int) error {
+	if size < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidSize, size)
+	}
+
+	return nil
+}
+
+func newLRU[K comparable, V any](size int, withExpiry bool, opts []Option[K, V]) *lru[K, V] {
+	out := &lru[K, V]{
+		cache:           map[K]*cache[K, V]{},
+		size:            size,
+		withExpiry:      withExpiry,
+		length:          0,
+		nodes:           newArena[K, V](size),
+		head:            nilIndex,
+		tail:            nilIndex,
+		cleanupInterval: DefaultCleanupInterval,
+		eventsBuffer:    -1,
+	}
+	for _, opt := range opts {
+		opt(out)
+	}
+	if out.eventsBuffer >= 0 && out.events == nil {
+		out.events = newDispatcher[K, V](out.eventsBuffer)
+	}
+	if out.disposer != nil {
+		out.locker.deferred = out.disposals
+	}
+	if out.hotKeysSize > 0 {
+		out.hotKeys = newHotKeys(out.hotKeysSize, hasherOf(out))
+	}
+	out.startDemotion()
+	out.startPressureWatcher()
 
 	return out
 }
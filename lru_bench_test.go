@@ -35,3 +35,135 @@ func BenchmarkMYLRU(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkShardedLRU(b *testing.B) {
+	lr := NewSharded[int, string](n, 16)
+
+	b.Run("SET", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				lr.Set(i%n, "value")
+				i++
+			}
+		})
+	})
+
+	b.Run("Get", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				lr.Get(i % n)
+				i++
+			}
+		})
+	})
+}
+
+func BenchmarkAccessBufferLRU(b *testing.B) {
+	for name, lr := range map[string]LRU[int, string]{
+		"Locked":   New[int, string](n),
+		"Buffered": New[int, string](n, WithAccessBuffer[int, string](DefaultAccessBufferSize)),
+	} {
+		for i := 0; i < n; i++ {
+			lr.Set(i, "value")
+		}
+
+		b.Run(name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					lr.Get(i % n)
+					i++
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkLRUList(b *testing.B) {
+	b.Run("SetEvict", func(b *testing.B) {
+		lr := New[int, int](1024)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			lr.Set(i, i)
+		}
+	})
+
+	b.Run("GetPromote", func(b *testing.B) {
+		lr := New[int, int](n)
+		for i := 0; i < n; i++ {
+			lr.Set(i, i)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			lr.Get((i * 7919) % n)
+		}
+	})
+
+	b.Run("Keys", func(b *testing.B) {
+		lr := New[int, int](n)
+		for i := 0; i < n; i++ {
+			lr.Set(i, i)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			lr.Keys()
+		}
+	})
+}
+
+func BenchmarkUnlockedLRU(b *testing.B) {
+	for name, lr := range map[string]LRU[int, int]{
+		"Locked":   New[int, int](1024),
+		"Unlocked": NewUnlocked[int, int](1024),
+	} {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				lr.Set(i%2048, i)
+				lr.Get(i % 1024)
+			}
+		})
+	}
+}
+
+func BenchmarkHasher(b *testing.B) {
+	b.Run("Int", func(b *testing.B) {
+		h := NewHasher[int]()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			h.Hash(i)
+		}
+	})
+
+	b.Run("String", func(b *testing.B) {
+		h := NewHasher[string]()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			h.Hash("user:1234567890")
+		}
+	})
+}
+
+func BenchmarkSecondChance(b *testing.B) {
+	for name, lr := range map[string]Cache[int, string]{
+		"LRU":          New[int, string](n),
+		"SecondChance": NewSecondChance[int, string](n),
+	} {
+		for i := 0; i < n; i++ {
+			lr.Set(i, "value")
+		}
+
+		b.Run(name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					lr.Get(i % n)
+					i++
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Package memoize caches the results of functions in an LRU cache.
+package memoize
+
+import (
+	"context"
+	"time"
+
+	"github.com/vhndaree/lru"
+)
+
+// Memoize returns a function returning the result of f for its argument,
+// calling f only when the result of the same argument is not cached.
+// Up to size results are cached for the provided TTL, or until they are evicted when the TTL is zero.
+// Concurrent calls with the same uncached argument share a single call of f.
+//
+// f should be pure: its result must only depend on its argument.
+//
+// Example usage:
+//
+//	fib := memoize.Memoize(func(n int) int { return slowFib(n) }, 1000, time.Hour)
+//	fib(40)
+func Memoize[A comparable, R any](f func(A) R, size int, ttl time.Duration) func(A) R {
+	cache := lru.New[A, R](size, lru.WithDefaultTTL[A, R](ttl))
+
+	return func(arg A) R {
+		result, _ := cache.GetOrCompute(arg, func() R {
+			return f(arg)
+		})
+
+		return result
+	}
+}
+
+// MemoizeErr is like Memoize for functions taking a context and returning an error.
+// Errors are returned to every caller sharing the call of f, and are not cached,
+// so the next call with the same argument calls f again.
+// Concurrent calls share the call of f made with the context of the first caller.
+//
+// The cache of the returned function holds a background goroutine removing expired results
+// for as long as the program runs, so MemoizeErr is meant for functions memoized once, such as at package level.
+//
+// Example usage:
+//
+//	getUser := memoize.MemoizeErr(db.FindUser, 1000, time.Minute)
+//	user, err := getUser(ctx, 42)
+func MemoizeErr[A comparable, R any](f func(context.Context, A) (R, error), size int, ttl time.Duration) func(context.Context, A) (R, error) {
+	cache := lru.NewLoading[A, R](size, lru.Loader[A, R](f), lru.WithDefaultTTL[A, R](ttl))
+
+	return cache.Load
+}
@@ -0,0 +1,94 @@
+package memoize
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoize(t *testing.T) {
+	t.Run("should call the function once per argument", func(t *testing.T) {
+		calls := 0
+		square := Memoize(func(n int) int {
+			calls++
+			return n * n
+		}, 10, 0)
+
+		for i := 0; i < 3; i++ {
+			if !reflect.DeepEqual(4, square(2)) {
+				t.Errorf("Expected 4; Actual = %v", square(2))
+			}
+		}
+
+		square(3)
+		if !reflect.DeepEqual(2, calls) {
+			t.Errorf("Expected 2; Actual = %v", calls)
+		}
+	})
+
+	t.Run("should call the function again once the result expired", func(t *testing.T) {
+		calls := 0
+		square := Memoize(func(n int) int {
+			calls++
+			return n * n
+		}, 10, 10*time.Millisecond)
+
+		square(2)
+		time.Sleep(20 * time.Millisecond)
+		square(2)
+
+		if !reflect.DeepEqual(2, calls) {
+			t.Errorf("Expected 2; Actual = %v", calls)
+		}
+	})
+
+	t.Run("should share concurrent calls with the same argument", func(t *testing.T) {
+		var calls atomic.Int32
+		release := make(chan struct{})
+		slow := MemoizeErr(func(_ context.Context, n int) (int, error) {
+			calls.Add(1)
+			<-release
+			return n, nil
+		}, 10, time.Minute)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				slow(context.Background(), 1)
+			}()
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if !reflect.DeepEqual(int32(1), calls.Load()) {
+			t.Errorf("Expected 1; Actual = %v", calls.Load())
+		}
+	})
+
+	t.Run("should not cache errors", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		calls := 0
+		failing := MemoizeErr(func(_ context.Context, n int) (int, error) {
+			calls++
+			return 0, errBoom
+		}, 10, time.Minute)
+
+		for i := 0; i < 2; i++ {
+			if _, err := failing(context.Background(), 1); !errors.Is(err, errBoom) {
+				t.Errorf("Expected %v; Actual = %v", errBoom, err)
+			}
+		}
+
+		if !reflect.DeepEqual(2, calls) {
+			t.Errorf("Expected 2; Actual = %v", calls)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package lru
+
+import (
+	"math"
+	"runtime/debug"
+	"runtime/metrics"
+	"time"
+)
+
+// DefaultMemoryThreshold is the share of the memory limit set with GOMEMLIMIT from which items are evicted
+// under memory pressure, unless another limit is configured in MemoryPressure.
+const DefaultMemoryThreshold = 0.9
+
+// DefaultPressureFraction is the share of the items evicted at every check finding the memory use over the limit,
+// unless another fraction is configured in MemoryPressure.
+const DefaultPressureFraction = 0.1
+
+// DefaultMemoryCheckInterval is how often memory use is checked under memory pressure,
+// unless another interval is configured in MemoryPressure.
+const DefaultMemoryCheckInterval = time.Second
+
+// MemoryPressure configures the eviction of items when the memory use of the process approaches its limit,
+// see WithMemoryPressure.
+type MemoryPressure struct {
+	Limit    uint64        // Memory use in bytes from which items are evicted, zero for DefaultMemoryThreshold of GOMEMLIMIT.
+	Fraction float64       // Share of the items evicted at every check over the limit, zero for DefaultPressureFraction.
+	Interval time.Duration // How often memory use is checked, zero for DefaultMemoryCheckInterval.
+}
+
+// memorySamples are the runtime metrics making up the memory use the Go runtime holds against GOMEMLIMIT.
+var memorySamples = []string{"/memory/classes/total:bytes", "/memory/classes/heap/released:bytes"}
+
+// memoryInUse returns the memory mapped by the Go runtime and not yet returned to the operating system,
+// which is the memory use GOMEMLIMIT limits.
+func memoryInUse() uint64 {
+	samples := []metrics.Sample{{Name: memorySamples[0]}, {Name: memorySamples[1]}}
+	metrics.Read(samples)
+
+	return samples[0].Value.Uint64() - samples[1].Value.Uint64()
+}
+
+// memoryLimit returns the memory use from which the provided configuration evicts items,
+// or zero when neither a limit nor GOMEMLIMIT is set.
+func memoryLimit(config MemoryPressure) uint64 {
+	if config.Limit > 0 {
+		return config.Limit
+	}
+
+	limit := debug.SetMemoryLimit(-1)
+	if limit <= 0 || limit == math.MaxInt64 {
+		return 0
+	}
+
+	return uint64(float64(limit) * DefaultMemoryThreshold)
+}
+
+// startPressureWatcher starts a background goroutine checking the memory use of the process
+// at the interval configured with WithMemoryPressure, and evicting the configured fraction of the items
+// from the tail of the cache whenever it is over the limit. The goroutine exits once the cache is closed.
+func (l *lru[K, V]) startPressureWatcher() {
+	// the watcher would evict items concurrently with the goroutine owning a cache created with NewUnlocked
+	if l.memoryPressure == nil || l.pressureDone != nil || l.locker.disabled {
+		return
+	}
+
+	config := *l.memoryPressure
+	if config.Fraction <= 0 || config.Fraction > 1 {
+		config.Fraction = DefaultPressureFraction
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultMemoryCheckInterval
+	}
+
+	var clock Clock = systemClock{}
+	if l.clock != nil {
+		clock = l.clock
+	}
+
+	l.pressureDone = make(chan struct{})
+	ticker := clock.NewTicker(config.Interval)
+
+	go func(done <-chan struct{}) {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C():
+			}
+
+			if limit := memoryLimit(config); limit > 0 && memoryInUse() >= limit {
+				l.relieve(config.Fraction)
+			}
+		}
+	}(l.pressureDone)
+}
+
+// relieve evicts the provided fraction of the items, at least one, from the tail of the cache.
+// Pinned and leased items are kept, like for any other eviction.
+func (l *lru[K, V]) relieve(fraction float64) {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	n := int(math.Ceil(float64(l.length) * fraction))
+	for i := 0; i < n && l.evictOldest(); i++ {
+	}
+}
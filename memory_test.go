@@ -0,0 +1,78 @@
+package lru
+
+import (
+	"math"
+	"reflect"
+	"runtime/debug"
+	"testing"
+	"time"
+)
+
+func TestMemoryPressure(t *testing.T) {
+	t.Run("should evict the least recently used items over the memory limit", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		l := New[int, int](10, WithClock[int, int](clock), WithMemoryPressure[int, int](MemoryPressure{Limit: 1, Fraction: 0.5}))
+		defer l.Close()
+
+		for i := 0; i < 4; i++ {
+			l.Set(i, i)
+		}
+
+		clock.Advance(DefaultMemoryCheckInterval)
+
+		// the watcher runs in the background once the tick is delivered
+		for deadline := time.Now().Add(time.Second); l.Stats().Evictions < 2 && time.Now().Before(deadline); {
+			time.Sleep(time.Millisecond)
+		}
+
+		if !reflect.DeepEqual([]int{3, 2}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 2}, l.Keys())
+		}
+	})
+
+	t.Run("should keep the items under the memory limit", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		l := New[int, int](10, WithClock[int, int](clock), WithMemoryPressure[int, int](MemoryPressure{Limit: 1 << 62, Interval: time.Minute}))
+		defer l.Close()
+
+		l.Set(1, 1)
+		clock.Advance(time.Minute)
+		time.Sleep(10 * time.Millisecond)
+
+		if !reflect.DeepEqual(1, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 1, l.Len())
+		}
+	})
+
+	t.Run("should not watch the memory use for unlocked caches", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		l := NewUnlocked[int, int](10, WithClock[int, int](clock), WithMemoryPressure[int, int](MemoryPressure{Limit: 1, Fraction: 0.5}))
+		defer l.Close()
+
+		for i := 0; i < 4; i++ {
+			l.Set(i, i)
+		}
+		clock.Advance(DefaultMemoryCheckInterval)
+		time.Sleep(10 * time.Millisecond)
+
+		if !reflect.DeepEqual(4, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 4, l.Len())
+		}
+	})
+
+	t.Run("should default to a share of GOMEMLIMIT", func(t *testing.T) {
+		defer debug.SetMemoryLimit(debug.SetMemoryLimit(10 << 30))
+
+		if limit := memoryLimit(MemoryPressure{}); !reflect.DeepEqual(uint64(0.9*(10<<30)), limit) {
+			t.Errorf("Expected %v; Actual = %v", uint64(0.9*(10<<30)), limit)
+		}
+		if limit := memoryLimit(MemoryPressure{Limit: 42}); !reflect.DeepEqual(uint64(42), limit) {
+			t.Errorf("Expected %v; Actual = %v", 42, limit)
+		}
+
+		debug.SetMemoryLimit(math.MaxInt64)
+		if limit := memoryLimit(MemoryPressure{}); !reflect.DeepEqual(uint64(0), limit) {
+			t.Errorf("Expected %v; Actual = %v", 0, limit)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package lru
+
+// Merge imports the items of the provided cache into the LRU cache, such as when consolidating
+// per-worker caches into a shared one at the end of a batch job.
+// The items are taken from a Snapshot of the source, so they keep their relative recency order
+// and become more recently used than the items already in the cache; when they do not all fit,
+// the least recently used ones are evicted like with Set, starting with those already in the cache.
+//
+// Keys present in both caches are stored with the value returned by the conflict function,
+// which receives the value of the cache and the value of the source and runs under the lock.
+// A nil conflict function keeps the value of the source.
+// Each item keeps its expiry time and tags, items without an expiry time get the default TTL like Set.
+// The source is not modified.
+//
+// Example usage:
+//
+//	shared.Merge(worker, func(dst, src int) int {
+//		return dst + src
+//	})
+func (l *lru[K, V]) Merge(src interface{ Snapshot() []Entry[K, V] }, conflict func(dst, src V) V) {
+	entries := src.Snapshot()
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.merge(entries, conflict)
+}
+
+// merge stores the provided entries, ordered like Snapshot, resolving conflicts with the provided function.
+func (l *lru[K, V]) merge(entries []Entry[K, V], conflict func(dst, src V) V) {
+	now := l.now()
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if !e.ExpiresAt.IsZero() && e.ExpiresAt.Before(now) {
+			continue
+		}
+
+		key, value := l.canonical(e.Key), e.Value
+		if c, ok := l.lookup(key); ok && conflict != nil {
+			value = conflict(c.value, value)
+		}
+
+		l.set(key, value, e.ExpiresAt)
+		if c, ok := l.cache[key]; ok {
+			l.tag(c, e.Tags)
+			l.depend(key, e.DependsOn)
+		}
+	}
+}
+
+// Merge imports the items of the provided cache into their shards, locking every shard once.
+// The relative order of the items of each shard is preserved.
+func (s *sharded[K, V]) Merge(src interface{ Snapshot() []Entry[K, V] }, conflict func(dst, src V) V) {
+	groups := make([][]Entry[K, V], len(s.shards))
+	for _, e := range src.Snapshot() {
+		i := s.index(e.Key)
+		groups[i] = append(groups[i], e)
+	}
+
+	for i, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+
+		s.shards[i].locker.Lock()
+		s.shards[i].merge(group, conflict)
+		s.shards[i].locker.Unlock()
+	}
+}
+
+// Merge imports the items of the provided cache into the first tier like Set, oldest first,
+// resolving conflicts with the value stored in either tier. Expiry times are not kept.
+func (t *tiered[K, V]) Merge(src interface{ Snapshot() []Entry[K, V] }, conflict func(dst, src V) V) {
+	entries := src.Snapshot()
+
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+
+		value := e.Value
+		if current, ok := t.peek(e.Key); ok && conflict != nil {
+			value = conflict(current, value)
+		}
+
+		t.set(e.Key, value, e.Tags)
+	}
+}
@@ -0,0 +1,94 @@
+package lru
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	t.Run("should import the items in recency order", func(t *testing.T) {
+		dst := New[string, int](3)
+		dst.Set("a", 1)
+		dst.Set("b", 2)
+
+		src := New[string, int](3)
+		src.Set("c", 3)
+		src.Set("d", 4)
+
+		dst.Merge(src, nil)
+
+		if !reflect.DeepEqual([]string{"d", "c", "b"}, dst.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []string{"d", "c", "b"}, dst.Keys())
+		}
+		if !reflect.DeepEqual([]string{"d", "c"}, src.Keys()) {
+			t.Errorf("Expected the source to be unchanged; Actual = %v", src.Keys())
+		}
+	})
+
+	t.Run("should resolve conflicts", func(t *testing.T) {
+		dst := New[string, int](3)
+		dst.Set("a", 1)
+		dst.Set("b", 2)
+
+		src := New[string, int](3)
+		src.Set("a", 10)
+
+		dst.Merge(src, func(dst, src int) int {
+			return dst + src
+		})
+		if value, _ := dst.Peek("a"); !reflect.DeepEqual(11, value) {
+			t.Errorf("Expected %v; Actual = %v", 11, value)
+		}
+
+		dst.Merge(src, nil)
+		if value, _ := dst.Peek("a"); !reflect.DeepEqual(10, value) {
+			t.Errorf("Expected %v; Actual = %v", 10, value)
+		}
+	})
+
+	t.Run("should keep expiry times and tags", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		dst := NewWithExpiry[string, int](3, WithClock[string, int](clock))
+		defer dst.Close()
+
+		src := NewWithExpiry[string, int](3, WithClock[string, int](clock))
+		defer src.Close()
+		src.SetWithTTL("a", 1, time.Minute)
+		src.SetWithTags("b", 2, "batch")
+
+		dst.Merge(src, nil)
+		if removed := dst.InvalidateTag("batch"); !reflect.DeepEqual(1, removed) {
+			t.Errorf("Expected %v; Actual = %v", 1, removed)
+		}
+
+		clock.Advance(time.Hour)
+		if dst.Contains("a") {
+			t.Errorf("Expected %v to expire", "a")
+		}
+	})
+
+	t.Run("should merge into every shard and tier", func(t *testing.T) {
+		src := New[int, int](8)
+		for i := 0; i < 4; i++ {
+			src.Set(i, i)
+		}
+
+		for _, l := range []LRU[int, int]{NewSharded[int, int](64, 4), NewTiered[int, int](New[int, int](2), New[int, int](4))} {
+			l.Set(0, 10)
+			l.Merge(src, func(dst, src int) int {
+				return dst + src
+			})
+
+			keys := l.Keys()
+			slices.Sort(keys)
+			if !reflect.DeepEqual([]int{0, 1, 2, 3}, keys) {
+				t.Errorf("Expected %v; Actual = %v", []int{0, 1, 2, 3}, keys)
+			}
+			if value, _ := l.Peek(0); !reflect.DeepEqual(10, value) {
+				t.Errorf("Expected %v; Actual = %v", 10, value)
+			}
+		}
+	})
+}
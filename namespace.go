@@ -0,0 +1,390 @@
+package lru
+
+import (
+	"iter"
+	"sync/atomic"
+	"time"
+)
+
+// NamespacedKey is the key under which a namespace of a Namespaced cache stores its items in the shared cache.
+type NamespacedKey[K comparable] struct {
+	Namespace string // Name of the namespace owning the item.
+	Key       K      // Key of the item within its namespace.
+}
+
+// Namespaced is an LRU cache shared by several namespaces, such as the subsystems of an application,
+// which compete for a single capacity budget while seeing only their own keys.
+// Every namespace is accessed through the view returned by Namespace.
+type Namespaced[K comparable, V any] struct {
+	cache  *lru[NamespacedKey[K], V]   // Cache shared by every namespace.
+	spaces map[string]*namespace[K, V] // Namespaces by name, guarded by the lock of the shared cache.
+}
+
+// NewNamespaced creates an LRU cache of the specified size shared by namespaces.
+// Optional behaviour of the shared cache, such as a default TTL or an eviction callback, can be configured
+// by passing one or more Option values keyed by NamespacedKey.
+//
+// Example usage:
+//
+//	shared := lru.NewNamespaced[string, []byte](10000)
+//	defer shared.Close()
+//	sessions := shared.Namespace("sessions")
+//	pages := shared.Namespace("pages")
+func NewNamespaced[K comparable, V any](size int, opts ...Option[NamespacedKey[K], V]) *Namespaced[K, V] {
+	out := &Namespaced[K, V]{
+		cache:  newLRU(size, true, opts),
+		spaces: map[string]*namespace[K, V]{},
+	}
+
+	onEvict := out.cache.onEvict
+	out.cache.onEvict = func(key NamespacedKey[K], value V, reason Reason) {
+		out.forget(key, reason)
+		if onEvict != nil {
+			onEvict(key, value, reason)
+		}
+	}
+
+	out.cache.startCleaner()
+	out.cache.startPersistence()
+	out.cache.startInvalidation()
+
+	return out
+}
+
+// Namespace returns the view of the namespace with the provided name, creating the namespace on first use.
+// The view only sees the keys of its namespace, and its Purge and Stats only cover them,
+// whereas capacity eviction picks the least recently used item of the whole shared cache,
+// unless the namespace exceeds the quota configured with SetQuota or SetQuotaRatio.
+// Views of the same name share their items. Closing a view has no effect, close the Namespaced cache instead.
+func (n *Namespaced[K, V]) Namespace(name string) Cache[K, V] {
+	n.cache.locker.Lock()
+	defer n.cache.locker.Unlock()
+
+	return n.space(name)
+}
+
+// SetQuota limits the namespace with the provided name to the provided number of items,
+// so it cannot evict the items of the other namespaces once it holds its share of the capacity:
+// a namespace over its quota evicts its own least recently used item instead.
+// The namespace is trimmed to the quota immediately. A quota of Unbounded removes the limit,
+// the namespace is then only bounded by the capacity of the shared cache.
+//
+// Example usage:
+//
+//	shared.SetQuota("pages", 1000)
+func (n *Namespaced[K, V]) SetQuota(name string, size int) {
+	n.cache.locker.Lock()
+	defer n.cache.locker.Unlock()
+
+	ns := n.space(name)
+	ns.quota = size
+	ns.ratio = 0
+	ns.trim()
+}
+
+// SetQuotaRatio limits the namespace with the provided name to the provided share of the capacity of the shared cache,
+// between 0 and 1, like SetQuota. The quota follows the capacity when the shared cache is resized,
+// and always allows at least one item. A non-positive ratio removes the limit.
+// Ratios have no effect on an Unbounded shared cache.
+//
+// Example usage:
+//
+//	shared.SetQuotaRatio("pages", 0.25)
+func (n *Namespaced[K, V]) SetQuotaRatio(name string, ratio float64) {
+	n.cache.locker.Lock()
+	defer n.cache.locker.Unlock()
+
+	ns := n.space(name)
+	ns.quota = Unbounded
+	ns.ratio = max(ratio, 0)
+	ns.trim()
+}
+
+// space returns the namespace with the provided name, creating it if needed. It runs under the lock of the shared cache.
+func (n *Namespaced[K, V]) space(name string) *namespace[K, V] {
+	ns, ok := n.spaces[name]
+	if !ok {
+		ns = &namespace[K, V]{owner: n, name: name, keys: map[K]struct{}{}}
+		n.spaces[name] = ns
+	}
+
+	return ns
+}
+
+// Len returns the number of items currently stored across all namespaces.
+func (n *Namespaced[K, V]) Len() int {
+	return n.cache.Len()
+}
+
+// Cap returns the maximum number of items the shared cache can hold across all namespaces.
+func (n *Namespaced[K, V]) Cap() int {
+	return n.cache.Cap()
+}
+
+// Resize changes the maximum number of items the shared cache can hold across all namespaces.
+// Namespaces whose quota is a ratio of the capacity are trimmed to their new quota.
+func (n *Namespaced[K, V]) Resize(size int) {
+	n.cache.Resize(size)
+
+	n.cache.locker.Lock()
+	defer n.cache.locker.Unlock()
+
+	for _, ns := range n.spaces {
+		ns.trim()
+	}
+}
+
+// Purge removes the items of every namespace.
+func (n *Namespaced[K, V]) Purge() {
+	n.cache.Purge()
+}
+
+// Stats returns the usage counters of the shared cache, covering every namespace.
+func (n *Namespaced[K, V]) Stats() Stats {
+	return n.cache.Stats()
+}
+
+// Close stops any background goroutine owned by the shared cache.
+// It is safe to call Close more than once; the cache and its namespaces must not be used after Close.
+func (n *Namespaced[K, V]) Close() {
+	n.cache.Close()
+}
+
+// forget drops the provided key from the index of its namespace once it left the shared cache,
+// counting evictions and expirations against the namespace. It runs under the lock of the shared cache.
+func (n *Namespaced[K, V]) forget(key NamespacedKey[K], reason Reason) {
+	ns, ok := n.spaces[key.Namespace]
+	if !ok || reason == Replaced {
+		return
+	}
+
+	delete(ns.keys, key.Key)
+	switch reason {
+	case Evicted:
+		ns.stats.Evictions++
+	case Expired:
+		ns.stats.Expirations++
+	}
+}
+
+// namespace is the view of a namespace of a Namespaced cache.
+type namespace[K comparable, V any] struct {
+	owner  *Namespaced[K, V] // Cache the namespace belongs to.
+	name   string            // Name of the namespace.
+	keys   map[K]struct{}    // Keys of the items of the namespace, guarded by the lock of the shared cache.
+	quota  int               // Maximum number of items of the namespace, or Unbounded.
+	ratio  float64           // Maximum share of the shared capacity held by the namespace, or zero.
+	hits   atomic.Uint64     // Number of lookups of the namespace which found the key.
+	misses atomic.Uint64     // Number of lookups of the namespace which did not find the key.
+	stats  Stats             // Evictions and expirations of the namespace, guarded by the lock of the shared cache.
+}
+
+// key returns the key under which the provided key of the namespace is stored in the shared cache.
+func (ns *namespace[K, V]) key(key K) NamespacedKey[K] {
+	return NamespacedKey[K]{Namespace: ns.name, Key: key}
+}
+
+// Contains checks if the provided key is present in the namespace.
+func (ns *namespace[K, V]) Contains(key K) bool {
+	return ns.owner.cache.Contains(ns.key(key))
+}
+
+// Set adds or updates a key-value pair in the namespace.
+// If the namespace is at its quota, its own least recently used item is evicted.
+// When every item of the namespace is pinned, the namespace grows past its quota.
+// Otherwise, if the shared cache is full, its least recently used item is evicted, whichever namespace it belongs to.
+func (ns *namespace[K, V]) Set(key K, value V) {
+	l := ns.owner.cache
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	if _, ok := ns.keys[key]; !ok {
+		limit := ns.limit()
+		for limit != Unbounded && len(ns.keys) >= limit && ns.evictOldest() {
+		}
+	}
+
+	var expiry time.Time
+	l.set(ns.key(key), value, expiry)
+
+	if _, ok := l.cache[ns.key(key)]; ok {
+		ns.keys[key] = struct{}{}
+	}
+}
+
+// Get retrieves the value associated with the provided key from the namespace, promoting it in the shared cache.
+func (ns *namespace[K, V]) Get(key K) (V, bool) {
+	value, ok := ns.owner.cache.Get(ns.key(key))
+	if ok {
+		ns.hits.Add(1)
+	} else {
+		ns.misses.Add(1)
+	}
+
+	return value, ok
+}
+
+// Peek retrieves the value associated with the provided key from the namespace without promoting it.
+func (ns *namespace[K, V]) Peek(key K) (V, bool) {
+	return ns.owner.cache.Peek(ns.key(key))
+}
+
+// Del removes the key-value pair associated with the provided key from the namespace.
+func (ns *namespace[K, V]) Del(key K) bool {
+	return ns.owner.cache.Del(ns.key(key))
+}
+
+// Len returns the number of items currently stored in the namespace.
+func (ns *namespace[K, V]) Len() int {
+	ns.owner.cache.locker.Lock()
+	defer ns.owner.cache.locker.Unlock()
+
+	return len(ns.keys)
+}
+
+// Cap returns the maximum number of items the namespace can hold: its quota if it has one,
+// or the capacity of the shared cache which it competes for otherwise.
+func (ns *namespace[K, V]) Cap() int {
+	ns.owner.cache.locker.Lock()
+	defer ns.owner.cache.locker.Unlock()
+
+	return ns.limit()
+}
+
+// Purge removes every item of the namespace, leaving the other namespaces untouched.
+func (ns *namespace[K, V]) Purge() {
+	l := ns.owner.cache
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	for key := range ns.keys {
+		l.del(ns.key(key))
+	}
+}
+
+// Resize sets the quota of the namespace to the provided number of items, like SetQuota.
+// The capacity of the shared cache is left untouched.
+func (ns *namespace[K, V]) Resize(size int) {
+	ns.owner.cache.locker.Lock()
+	defer ns.owner.cache.locker.Unlock()
+
+	ns.quota = size
+	ns.ratio = 0
+	ns.trim()
+}
+
+// limit returns the maximum number of items of the namespace, or Unbounded.
+// It runs under the lock of the shared cache.
+func (ns *namespace[K, V]) limit() int {
+	size := ns.owner.cache.size
+	switch {
+	case ns.quota != Unbounded:
+		return ns.quota
+	case ns.ratio > 0 && size != Unbounded:
+		return max(int(float64(size)*ns.ratio), 1)
+	default:
+		return size
+	}
+}
+
+// trim evicts the least recently used items of the namespace until it fits its quota.
+// It runs under the lock of the shared cache.
+func (ns *namespace[K, V]) trim() {
+	limit := ns.limit()
+	for limit != Unbounded && len(ns.keys) > limit && ns.evictOldest() {
+	}
+}
+
+// evictOldest evicts the least recently used unpinned item of the namespace.
+// It returns false if the namespace has no item which can be evicted, or if the shared cache is frozen.
+// The shared cache is walked from its back, skipping the items of the other namespaces.
+func (ns *namespace[K, V]) evictOldest() bool {
+	l := ns.owner.cache
+	if l.frozen {
+		return false
+	}
+
+	l.drainAccesses()
+
+	for h := l.back(); h != nil; h = l.prev(h) {
+		if h.key.Namespace == ns.name && !h.held() {
+			l.stats.Evictions++
+			l.remove(h.key, Evicted)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Keys returns a snapshot of the keys of the namespace,
+// ordered from the most recently used to the least recently used.
+func (ns *namespace[K, V]) Keys() []K {
+	keys, _ := ns.items()
+	return keys
+}
+
+// Values returns a snapshot of the values of the namespace,
+// ordered from the most recently used to the least recently used.
+func (ns *namespace[K, V]) Values() []V {
+	_, values := ns.items()
+	return values
+}
+
+// All returns an iterator over the key-value pairs of the namespace,
+// ordered from the most recently used to the least recently used.
+// The iterator ranges over a snapshot taken when iteration starts.
+func (ns *namespace[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		forward(ns.items())(yield)
+	}
+}
+
+// Backward returns an iterator over the key-value pairs of the namespace,
+// ordered from the least recently used to the most recently used.
+// It has the same consistency semantics as All.
+func (ns *namespace[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		backward(ns.items())(yield)
+	}
+}
+
+// items returns a snapshot of the keys and values of the namespace,
+// ordered from the most recently used to the least recently used.
+// It walks the whole shared cache, skipping the items of the other namespaces.
+func (ns *namespace[K, V]) items() ([]K, []V) {
+	l := ns.owner.cache
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	keys := make([]K, 0, len(ns.keys))
+	values := make([]V, 0, len(ns.keys))
+	for h := l.front(); h != nil; h = l.next(h) {
+		if h.key.Namespace == ns.name {
+			keys = append(keys, h.key.Key)
+			values = append(values, h.value)
+		}
+	}
+
+	return keys, values
+}
+
+// Stats returns the usage counters of the namespace: its hits and misses,
+// and the evictions and expirations of its items.
+func (ns *namespace[K, V]) Stats() Stats {
+	ns.owner.cache.locker.Lock()
+	defer ns.owner.cache.locker.Unlock()
+
+	return Stats{
+		Hits:        ns.hits.Load(),
+		Misses:      ns.misses.Load(),
+		Evictions:   ns.stats.Evictions,
+		Expirations: ns.stats.Expirations,
+	}
+}
+
+// Close has no effect, the Namespaced cache owning the namespace is closed instead.
+func (ns *namespace[K, V]) Close() {}
@@ -0,0 +1,254 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNamespaced(t *testing.T) {
+	t.Run("should isolate the keys of namespaces", func(t *testing.T) {
+		n := NewNamespaced[string, int](10)
+		defer n.Close()
+
+		users := n.Namespace("users")
+		orders := n.Namespace("orders")
+		users.Set("1", 1)
+		orders.Set("1", 100)
+
+		value, ok := users.Get("1")
+		if !ok || !reflect.DeepEqual(1, value) {
+			t.Errorf("Expected %v; Actual = %v", 1, value)
+		}
+		value, ok = orders.Get("1")
+		if !ok || !reflect.DeepEqual(100, value) {
+			t.Errorf("Expected %v; Actual = %v", 100, value)
+		}
+		if orders.Contains("2") {
+			t.Errorf("Expected key 2 to be missing")
+		}
+
+		if !reflect.DeepEqual(2, n.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 2, n.Len())
+		}
+		if !reflect.DeepEqual(1, users.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 1, users.Len())
+		}
+	})
+
+	t.Run("should return the same namespace for the same name", func(t *testing.T) {
+		n := NewNamespaced[string, int](10)
+		defer n.Close()
+
+		n.Namespace("users").Set("1", 1)
+		if !n.Namespace("users").Contains("1") {
+			t.Errorf("Expected key 1 to be present")
+		}
+	})
+
+	t.Run("should share the capacity across namespaces", func(t *testing.T) {
+		n := NewNamespaced[int, int](3)
+		defer n.Close()
+
+		a := n.Namespace("a")
+		b := n.Namespace("b")
+		a.Set(1, 1)
+		a.Set(2, 2)
+		b.Set(1, 10)
+		b.Set(2, 20)
+
+		if !reflect.DeepEqual([]int{2}, a.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{2}, a.Keys())
+		}
+		if !reflect.DeepEqual([]int{2, 1}, b.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{2, 1}, b.Keys())
+		}
+		if !reflect.DeepEqual(1, a.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 1, a.Len())
+		}
+		if !reflect.DeepEqual(3, a.Cap()) {
+			t.Errorf("Expected %v; Actual = %v", 3, a.Cap())
+		}
+	})
+
+	t.Run("should purge a single namespace", func(t *testing.T) {
+		n := NewNamespaced[int, int](10)
+		defer n.Close()
+
+		a := n.Namespace("a")
+		b := n.Namespace("b")
+		a.Set(1, 1)
+		a.Set(2, 2)
+		b.Set(1, 10)
+
+		a.Purge()
+		if !reflect.DeepEqual(0, a.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 0, a.Len())
+		}
+		if !reflect.DeepEqual([]int{1}, b.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{1}, b.Keys())
+		}
+		if !reflect.DeepEqual(1, n.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 1, n.Len())
+		}
+	})
+
+	t.Run("should keep stats per namespace", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		n := NewNamespaced[int, int](2, WithClock[NamespacedKey[int], int](clock), WithDefaultTTL[NamespacedKey[int], int](time.Minute))
+		defer n.Close()
+
+		a := n.Namespace("a")
+		b := n.Namespace("b")
+		a.Set(1, 1)
+		a.Get(1)
+		a.Get(2)
+		b.Set(1, 10)
+		b.Set(2, 20)
+		b.Get(2)
+
+		expected := Stats{Hits: 1, Misses: 1, Evictions: 1}
+		if !reflect.DeepEqual(expected, a.Stats()) {
+			t.Errorf("Expected %v; Actual = %v", expected, a.Stats())
+		}
+
+		clock.Advance(2 * time.Minute)
+		b.Get(1)
+		b.Get(2)
+		expected = Stats{Hits: 1, Misses: 2, Expirations: 2}
+		if !reflect.DeepEqual(expected, b.Stats()) {
+			t.Errorf("Expected %v; Actual = %v", expected, b.Stats())
+		}
+		if !reflect.DeepEqual(0, b.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 0, b.Len())
+		}
+	})
+
+	t.Run("should range over the items of the namespace", func(t *testing.T) {
+		n := NewNamespaced[int, string](10)
+		defer n.Close()
+
+		a := n.Namespace("a")
+		n.Namespace("b").Set(3, "three")
+		a.Set(1, "one")
+		a.Set(2, "two")
+
+		var keys []int
+		for key := range a.Backward() {
+			keys = append(keys, key)
+		}
+		if !reflect.DeepEqual([]int{1, 2}, keys) {
+			t.Errorf("Expected %v; Actual = %v", []int{1, 2}, keys)
+		}
+		if !reflect.DeepEqual([]string{"two", "one"}, a.Values()) {
+			t.Errorf("Expected %v; Actual = %v", []string{"two", "one"}, a.Values())
+		}
+	})
+
+	t.Run("should still invoke the eviction callback", func(t *testing.T) {
+		var evicted []NamespacedKey[int]
+		n := NewNamespaced[int, int](1, WithOnEvict(func(key NamespacedKey[int], _ int, _ Reason) {
+			evicted = append(evicted, key)
+		}))
+		defer n.Close()
+
+		n.Namespace("a").Set(1, 1)
+		n.Namespace("b").Set(1, 1)
+
+		expected := []NamespacedKey[int]{{Namespace: "a", Key: 1}}
+		if !reflect.DeepEqual(expected, evicted) {
+			t.Errorf("Expected %v; Actual = %v", expected, evicted)
+		}
+	})
+}
+
+func TestNamespaceQuota(t *testing.T) {
+	t.Run("should evict the items of the namespace over its quota", func(t *testing.T) {
+		n := NewNamespaced[int, int](10)
+		defer n.Close()
+
+		n.SetQuota("noisy", 2)
+		quiet := n.Namespace("quiet")
+		noisy := n.Namespace("noisy")
+		quiet.Set(1, 1)
+		for i := range 20 {
+			noisy.Set(i, i)
+		}
+
+		if !reflect.DeepEqual([]int{19, 18}, noisy.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{19, 18}, noisy.Keys())
+		}
+		if !quiet.Contains(1) {
+			t.Errorf("Expected key 1 to be present")
+		}
+		if !reflect.DeepEqual(uint64(18), noisy.Stats().Evictions) {
+			t.Errorf("Expected %v; Actual = %v", 18, noisy.Stats().Evictions)
+		}
+		if !reflect.DeepEqual(2, noisy.Cap()) {
+			t.Errorf("Expected %v; Actual = %v", 2, noisy.Cap())
+		}
+	})
+
+	t.Run("should trim the namespace when the quota is set", func(t *testing.T) {
+		n := NewNamespaced[int, int](10)
+		defer n.Close()
+
+		a := n.Namespace("a")
+		a.Set(1, 1)
+		a.Set(2, 2)
+		a.Set(3, 3)
+
+		a.Resize(1)
+		if !reflect.DeepEqual([]int{3}, a.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3}, a.Keys())
+		}
+		if !reflect.DeepEqual(10, n.Cap()) {
+			t.Errorf("Expected %v; Actual = %v", 10, n.Cap())
+		}
+
+		a.Resize(Unbounded)
+		a.Set(4, 4)
+		if !reflect.DeepEqual([]int{4, 3}, a.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{4, 3}, a.Keys())
+		}
+	})
+
+	t.Run("should follow the shared capacity with a ratio", func(t *testing.T) {
+		n := NewNamespaced[int, int](10)
+		defer n.Close()
+
+		n.SetQuotaRatio("a", 0.5)
+		a := n.Namespace("a")
+		for i := range 10 {
+			a.Set(i, i)
+		}
+		if !reflect.DeepEqual(5, a.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 5, a.Len())
+		}
+
+		n.Resize(4)
+		if !reflect.DeepEqual([]int{9, 8}, a.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{9, 8}, a.Keys())
+		}
+
+		n.Resize(1)
+		if !reflect.DeepEqual(1, a.Cap()) {
+			t.Errorf("Expected %v; Actual = %v", 1, a.Cap())
+		}
+	})
+
+	t.Run("should not evict pinned items for the quota", func(t *testing.T) {
+		n := NewNamespaced[int, int](10)
+		defer n.Close()
+
+		a := n.Namespace("a")
+		a.Set(1, 1)
+		n.cache.Pin(NamespacedKey[int]{Namespace: "a", Key: 1})
+		a.Resize(1)
+		a.Set(2, 2)
+
+		if !reflect.DeepEqual([]int{2, 1}, a.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{2, 1}, a.Keys())
+		}
+	})
+}
@@ -0,0 +1,401 @@
+package lru
+
+import "time"
+
+// Option configures optional behaviour of an LRU cache at construction time.
+type Option[K comparable, V any] func(*lru[K, V])
+
+// EvictCallback is invoked with a key-value pair which left the cache or was replaced,
+// along with the reason why.
+type EvictCallback[K comparable, V any] func(key K, value V, reason Reason)
+
+// WithOnEvict registers a callback that is invoked whenever a key-value pair leaves the cache
+// or its value is overwritten by a Set of the same key.
+// The reason tells whether the item was evicted for capacity, expired, deleted explicitly or purged, or replaced.
+//
+// The callback runs synchronously while the cache lock is held,
+// so it must not call back into the same cache.
+//
+// Example usage:
+//
+//	cache := lru.New[string, *os.File](10, lru.WithOnEvict(func(_ string, f *os.File, _ lru.Reason) {
+//		f.Close()
+//	}))
+func WithOnEvict[K comparable, V any](fn EvictCallback[K, V]) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.onEvict = fn
+	}
+}
+
+// Sizer computes the cost of a value, such as its size in bytes,
+// which is counted against the maximum cost of a cache created with NewWithCost.
+type Sizer[V any] func(value V) int64
+
+// WithSizer configures the cache to compute the cost of every value it stores with the provided sizer,
+// so values set with Set, SetWithTTL, GetOrSet or GetOrCompute are weighed automatically.
+// SetWithCost still stores the explicitly provided cost.
+//
+// Example usage:
+//
+//	cache := lru.NewWithCost[string, []byte](1000, 64<<20, lru.WithSizer[string](func(v []byte) int64 {
+//		return int64(len(v))
+//	}))
+func WithSizer[K comparable, V any](fn Sizer[V]) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.sizer = fn
+	}
+}
+
+// WithDefaultTTL configures the TTL applied to items stored without an explicit one,
+// such as items stored with Set, GetOrSet or GetOrCompute on a cache created with NewWithExpiry.
+// SetWithTTL and SetWithExpiry still override it per item.
+//
+// Example usage:
+//
+//	cache := lru.NewWithExpiry[string, string](100, lru.WithDefaultTTL[string, string](10*time.Minute))
+func WithDefaultTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.defaultTTL = ttl
+	}
+}
+
+// WithCleanupInterval configures how often the background cleaner of a cache created with NewWithExpiry
+// looks for expired items. Non-positive intervals are ignored and DefaultCleanupInterval is used.
+//
+// A shorter interval releases expired items sooner at the cost of more frequent wakeups.
+// Expired items are never returned by Get regardless of the interval.
+func WithCleanupInterval[K comparable, V any](interval time.Duration) Option[K, V] {
+	return func(l *lru[K, V]) {
+		if interval > 0 {
+			l.cleanupInterval = interval
+		}
+	}
+}
+
+// WithEvents enables delivery of an Event for every key-value pair which leaves the cache or is replaced,
+// carrying the key, the value, the Reason and the time of the event.
+// Events are delivered on the channel returned by Events, whose buffer has the provided size,
+// from a dedicated goroutine, so a slow consumer never blocks the cache.
+//
+// Undelivered events are queued in memory, so the channel must be drained for as long as the cache is used.
+// The channel is closed when the cache is closed.
+//
+// Example usage:
+//
+//	cache := lru.New[string, string](100, lru.WithEvents[string, string](64))
+//	go func() {
+//		for e := range cache.Events() {
+//			log.Printf("%v %s", e.Reason, e.Key)
+//		}
+//	}()
+func WithEvents[K comparable, V any](buffer int) Option[K, V] {
+	return func(l *lru[K, V]) {
+		if buffer < 0 {
+			buffer = 0
+		}
+		l.eventsBuffer = buffer
+	}
+}
+
+// WithStaleWhileRevalidate configures how long past its TTL a value may still be served.
+// Within that window, Get returns the stale value immediately and, for caches created with NewLoading,
+// refreshes it in the background with the loader, so expiry does not cause a latency spike.
+// Once the window has passed as well, the item is treated as expired.
+//
+// Example usage:
+//
+//	users := lru.NewLoading[int, User](100, loadUser,
+//		lru.WithDefaultTTL[int, User](time.Minute),
+//		lru.WithStaleWhileRevalidate[int, User](10*time.Second),
+//	)
+func WithStaleWhileRevalidate[K comparable, V any](window time.Duration) Option[K, V] {
+	return func(l *lru[K, V]) {
+		if window > 0 {
+			l.staleWindow = window
+		}
+	}
+}
+
+// WithRefreshAfter configures caches created with NewLoading to refresh values older than the provided age
+// in the background when they are accessed, while still returning the current value immediately.
+// Keeping the age below the TTL keeps frequently read keys fresh without readers ever waiting for the loader.
+//
+// Example usage:
+//
+//	users := lru.NewLoading[int, User](100, loadUser,
+//		lru.WithDefaultTTL[int, User](time.Minute),
+//		lru.WithRefreshAfter[int, User](45*time.Second),
+//	)
+func WithRefreshAfter[K comparable, V any](age time.Duration) Option[K, V] {
+	return func(l *lru[K, V]) {
+		if age > 0 {
+			l.refreshAfter = age
+		}
+	}
+}
+
+// WithNegativeTTL configures caches created with NewLoading to remember loader errors for the provided TTL.
+// Until it passes, loading the same key returns the remembered error without calling the loader again,
+// which keeps missing rows or failing backends from being hammered.
+// Setting or deleting the key forgets its error immediately.
+//
+// Example usage:
+//
+//	users := lru.NewLoading[int, User](100, loadUser, lru.WithNegativeTTL[int, User](5*time.Second))
+func WithNegativeTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(l *lru[K, V]) {
+		if ttl > 0 {
+			l.negativeTTL = ttl
+		}
+	}
+}
+
+// WithRejectWhenFull configures the cache to reject new keys while it is full instead of evicting
+// its least recently used items, for caches backing admission control where a silent eviction is wrong.
+// Expired items are still removed to make room. Set drops rejected items silently, SetE reports them with ErrFull,
+// and rejections are counted in Stats. Updates of cached keys are always accepted, unless their new cost
+// does not fit in the maximum cost of the cache.
+//
+// Example usage:
+//
+//	tickets := lru.New[string, Ticket](1000, lru.WithRejectWhenFull[string, Ticket]())
+//	if err := tickets.SetE(requestID, ticket); err != nil {
+//		return err // lru.ErrFull
+//	}
+func WithRejectWhenFull[K comparable, V any]() Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.rejectWhenFull = true
+	}
+}
+
+// WithMemoryPressure configures the cache to evict items ahead of capacity when the memory use of the process
+// approaches its limit, to keep load spikes from running it out of memory. A background goroutine checks the memory use
+// the Go runtime holds against GOMEMLIMIT at the configured interval, and whenever it is over the configured limit,
+// or DefaultMemoryThreshold of GOMEMLIMIT when no limit is configured, it evicts the configured fraction
+// of the least recently used items. Without a configured limit or GOMEMLIMIT, nothing is evicted.
+// Caches created with NewUnlocked ignore it, since the goroutine would race with the one owning the cache.
+//
+// Example usage:
+//
+//	cache := lru.New[string, []byte](1_000_000, lru.WithMemoryPressure[string, []byte](lru.MemoryPressure{Fraction: 0.25}))
+//	defer cache.Close()
+func WithMemoryPressure[K comparable, V any](config MemoryPressure) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.memoryPressure = &config
+	}
+}
+
+// WithWriteBehind configures caches created with NewBacked to write to their store asynchronously.
+// Set and Delete update the cache immediately and queue the write, which a pool of workers flushes
+// to the store in batches, retrying failed writes. Pending writes of the same key are coalesced.
+// Flush waits for the queued writes and reports the ones which were dropped after their last retry,
+// Close waits for the queued writes as well.
+//
+// Example usage:
+//
+//	users := lru.NewBacked[int, User](100, userStore, lru.WithWriteBehind[int, User](lru.WriteBehind{Workers: 4}))
+//	defer users.Flush(ctx)
+func WithWriteBehind[K comparable, V any](config WriteBehind) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.writeBehind = &config
+	}
+}
+
+// WithPersistence configures the cache to survive restarts by saving it to the file at the provided path.
+// The cache is restored from the file when it is created, saved every interval and saved once more when it is closed.
+// A non-positive interval only saves the cache when it is closed.
+//
+// Every save writes a snapshot in the format of Save to a temporary file in the same directory
+// and renames it over the file, so a crash never leaves a partially written file behind.
+// Files which cannot be read or written are ignored, the cache then starts empty or keeps the previous file.
+//
+// Example usage:
+//
+//	cache := lru.New[string, string](1000, lru.WithPersistence[string, string]("/var/lib/app/cache.gob", time.Minute))
+//	defer cache.Close()
+func WithPersistence[K comparable, V any](path string, interval time.Duration) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.persistPath = path
+		l.persistInterval = interval
+	}
+}
+
+// WithInvalidator configures the cache to keep in sync with its instances in other processes.
+// Every Del is broadcast with the provided invalidator, and keys deleted by the other instances are removed locally,
+// reported as deleted. Broadcasting is best effort: errors of the invalidator are ignored,
+// and a failed subscription is retried in the background until the cache is closed.
+//
+// Example usage:
+//
+//	cache := lru.New[string, User](1000, lru.WithInvalidator[string, User](lruredis.NewInvalidator[string](client, "users")))
+//	defer cache.Close()
+func WithInvalidator[K comparable, V any](inv Invalidator[K]) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.invalidator = inv
+	}
+}
+
+// DefaultAccessBufferSize is the number of accesses recorded between drains when WithAccessBuffer is given
+// a non-positive size.
+const DefaultAccessBufferSize = 64
+
+// WithAccessBuffer trades exact recency for read throughput. Hits of Get, GetOrSet, GetOrCompute and Load
+// only take the read lock and record the access into a lock-free buffer of the provided size,
+// which is applied to the recency order in a batch once it fills up, or before an item is evicted.
+//
+// Until the buffer is drained, the order seen by Keys, All and the other iterators lags behind the latest reads,
+// and accesses made while the buffer is being drained may be dropped, so eviction order is approximate under load.
+//
+// Example usage:
+//
+//	cache := lru.New[string, []byte](10000, lru.WithAccessBuffer[string, []byte](lru.DefaultAccessBufferSize))
+func WithAccessBuffer[K comparable, V any](size int) Option[K, V] {
+	return func(l *lru[K, V]) {
+		if size <= 0 {
+			size = DefaultAccessBufferSize
+		}
+		l.accesses = newAccessBuffer[K, V](size)
+	}
+}
+
+// WithHasher configures the Hasher picking the shard of every key in caches created with NewSharded,
+// and the queue of every key in write-behind mode, instead of the default one returned by NewHasher.
+// Custom key types should provide one, as the default falls back to hashing their formatted representation.
+//
+// Example usage:
+//
+//	cache := lru.NewSharded[Point, string](10000, 16, lru.WithHasher[Point, string](lru.HasherFunc[Point](func(p Point) uint64 {
+//		return uint64(p.X)<<32 | uint64(uint32(p.Y))
+//	})))
+func WithHasher[K comparable, V any](h Hasher[K]) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.hasher = h
+	}
+}
+
+// WithKeyTransform configures a function normalizing every key passed to the cache, such as lowercasing
+// or trimming string keys, so that keys differing only in form share one item.
+// Keys are stored, returned by Keys, iterators and snapshots, and handed to callbacks and loaders in their normalized form.
+// The function must be deterministic and idempotent, as keys may be normalized more than once.
+//
+// Example usage:
+//
+//	cache := lru.New[string, User](1000, lru.WithKeyTransform[string, User](strings.ToLower))
+//	cache.Set("Alice", alice)
+//	user, ok := cache.Get("ALICE") // returns alice
+func WithKeyTransform[K comparable, V any](fn func(K) K) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.keyTransform = fn
+	}
+}
+
+// WithSlidingTTL configures items stored without an explicit TTL to expire once they have not been read for the provided duration.
+// Every hit of Get, GetOrSet, GetOrCompute or Load pushes the expiry of the item back to the duration from now,
+// so items such as sessions live for as long as they are used. Peek and GetEntry do not extend it.
+// It takes precedence over WithDefaultTTL. Items stored with SetWithTTL or SetWithExpiry start with their explicit TTL,
+// which reads extend the same way once less than the sliding TTL remains.
+//
+// With WithAccessBuffer, expiries are extended when the buffered accesses are applied.
+//
+// Example usage:
+//
+//	sessions := lru.NewWithExpiry[string, Session](10000, lru.WithSlidingTTL[string, Session](30*time.Minute))
+func WithSlidingTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(l *lru[K, V]) {
+		if ttl > 0 {
+			l.slidingTTL = ttl
+		}
+	}
+}
+
+// WithClock configures the Clock the cache reads the time from, to stamp and expire items and to schedule its cleaner,
+// instead of the system clock. It is meant for tests, which can drive expiry deterministically with a FakeClock.
+//
+// Example usage:
+//
+//	clock := lru.NewFakeClock(time.Now())
+//	cache := lru.NewWithExpiry[string, string](10, lru.WithClock[string, string](clock))
+func WithClock[K comparable, V any](clock Clock) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.clock = clock
+	}
+}
+
+// WithWatermarks configures the cache to evict in batches instead of one item per Set once it is full.
+// When the number of items reaches the high watermark, the least recently used items are evicted
+// until the number of items falls to the low watermark, so the next Sets do not evict at all.
+// Both watermarks are shares of the size between 0 and 1, the low one being at most the high one.
+// A high watermark of 1 keeps using the whole capacity.
+// The maximum cost of caches created with NewWithCost is still enforced item by item.
+//
+// Example usage:
+//
+//	// evict down to 90% of the capacity when it is full
+//	cache := lru.New[string, []byte](10000, lru.WithWatermarks[string, []byte](1, 0.9))
+func WithWatermarks[K comparable, V any](high, low float64) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.highWatermark = clampRatio(high)
+		l.lowWatermark = min(clampRatio(low), l.highWatermark)
+	}
+}
+
+// WithDisposer configures the cache to release every value which leaves the cache with the provided disposer,
+// whether it was evicted, expired, deleted or purged, and every value overwritten by a Set of the same key
+// with a different value. Values still cached when the cache is closed are not disposed of,
+// nor is a value set again for its own key. As values demoted from the first tier of NewTiered
+// leave that tier, configure the disposer on the second tier only.
+//
+// Unlike the callback of WithOnEvict, the disposer runs once the cache lock is released,
+// on the goroutine whose operation removed the value, so it may block or call back into the cache.
+//
+// Example usage:
+//
+//	conns := lru.New[string, *Conn](100, lru.WithDisposer[string](func(c *Conn) {
+//		c.Release()
+//	}))
+func WithDisposer[K comparable, V any](fn Disposer[V]) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.disposer = fn
+	}
+}
+
+// WithAutoClose configures the cache to close every value implementing io.Closer which leaves the cache,
+// like WithDisposer, so pooled connections or open files are not leaked. Errors returned by Close are ignored.
+//
+// Example usage:
+//
+//	files := lru.New[string, *os.File](100, lru.WithAutoClose[string, *os.File]())
+func WithAutoClose[K comparable, V any]() Option[K, V] {
+	return WithDisposer[K](closeValue[V])
+}
+
+// WithHotKeys configures the cache to track the provided number of keys looked up the most often with Get, GetE or Load,
+// reported by TopKeys. Frequencies are estimated with a fixed-size frequency sketch which favours recent traffic,
+// and every lookup takes the mutex of the tracker, so it is meant for diagnosing hot keys rather than left always on.
+//
+// Example usage:
+//
+//	cache := lru.New[string, []byte](10000, lru.WithHotKeys[string, []byte](10))
+func WithHotKeys[K comparable, V any](n int) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.hotKeysSize = n
+	}
+}
+
+// WithDemoteFn configures the cache to hand every item evicted to make room for other items, for capacity or cost,
+// to the provided function, such as to push it into a colder tier. Unlike the callback of WithOnEvict,
+// it is not called for items which expire, are deleted, replaced or taken with RemoveOldest, and it runs
+// on a dedicated worker goroutine outside the cache lock, one item at a time in eviction order,
+// so a slow function does not add to the latency of Set. Items evicted faster than the function handles them are queued.
+// Items still queued when the cache is closed are dropped.
+//
+// Example usage:
+//
+//	cache := lru.New[string, []byte](10000, lru.WithDemoteFn(func(key string, value []byte) {
+//		disk.Set(key, value)
+//	}))
+func WithDemoteFn[K comparable, V any](fn DemoteFunc[K, V]) Option[K, V] {
+	return func(l *lru[K, V]) {
+		l.demoteFn = fn
+	}
+}
@@ -0,0 +1,119 @@
+// Package otel instruments caches with OpenTelemetry metrics.
+package otel
+
+import (
+	"context"
+	"time"
+
+	"github.com/vhndaree/lru"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Cache wraps an lru.LRU and records OpenTelemetry metrics for its operations.
+// Lookups increment the hit or miss counter and every instrumented operation records its latency,
+// all tagged with the cache name the wrapper was created with.
+// Methods which are not instrumented are passed straight through to the wrapped cache.
+type Cache[K comparable, V any] struct {
+	lru.LRU[K, V]
+
+	hits    metric.Int64Counter     // Number of lookups which found the key.
+	misses  metric.Int64Counter     // Number of lookups which did not find the key.
+	latency metric.Float64Histogram // Duration of cache operations in seconds.
+	name    attribute.KeyValue      // Attribute identifying the cache.
+}
+
+// Wrap instruments the provided cache with metrics created from the provided meter,
+// tagging every measurement with the provided cache name.
+//
+// Example usage:
+//
+//	cache, err := otel.Wrap(lru.New[string, string](100), provider.Meter("my-service"), "sessions")
+func Wrap[K comparable, V any](cache lru.LRU[K, V], meter metric.Meter, name string) (*Cache[K, V], error) {
+	hits, err := meter.Int64Counter("lru.cache.hits",
+		metric.WithDescription("Number of cache lookups which found the key."))
+	if err != nil {
+		return nil, err
+	}
+
+	misses, err := meter.Int64Counter("lru.cache.misses",
+		metric.WithDescription("Number of cache lookups which did not find the key."))
+	if err != nil {
+		return nil, err
+	}
+
+	latency, err := meter.Float64Histogram("lru.cache.operation.duration",
+		metric.WithDescription("Duration of cache operations."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cache[K, V]{
+		LRU:     cache,
+		hits:    hits,
+		misses:  misses,
+		latency: latency,
+		name:    attribute.String("cache.name", name),
+	}, nil
+}
+
+// Get retrieves the value associated with the provided key, recording a hit or a miss.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	defer c.observe("get", time.Now())
+
+	value, found := c.LRU.Get(key)
+	c.lookup(found)
+
+	return value, found
+}
+
+// GetOrSet returns the existing value for the key or stores the provided one, recording a hit or a miss.
+func (c *Cache[K, V]) GetOrSet(key K, value V) (V, bool) {
+	defer c.observe("get_or_set", time.Now())
+
+	actual, loaded := c.LRU.GetOrSet(key, value)
+	c.lookup(loaded)
+
+	return actual, loaded
+}
+
+// GetOrCompute returns the existing value for the key or stores the result of fn, recording a hit or a miss.
+func (c *Cache[K, V]) GetOrCompute(key K, fn func() V) (V, bool) {
+	defer c.observe("get_or_compute", time.Now())
+
+	actual, loaded := c.LRU.GetOrCompute(key, fn)
+	c.lookup(loaded)
+
+	return actual, loaded
+}
+
+// Set adds or updates a key-value pair in the wrapped cache, recording the latency of the operation.
+func (c *Cache[K, V]) Set(key K, value V) {
+	defer c.observe("set", time.Now())
+
+	c.LRU.Set(key, value)
+}
+
+// Del removes the key-value pair associated with the provided key, recording the latency of the operation.
+func (c *Cache[K, V]) Del(key K) bool {
+	defer c.observe("del", time.Now())
+
+	return c.LRU.Del(key)
+}
+
+// lookup increments the hit or miss counter.
+func (c *Cache[K, V]) lookup(found bool) {
+	if found {
+		c.hits.Add(context.Background(), 1, metric.WithAttributes(c.name))
+		return
+	}
+
+	c.misses.Add(context.Background(), 1, metric.WithAttributes(c.name))
+}
+
+// observe records the time elapsed since start for the provided operation.
+func (c *Cache[K, V]) observe(operation string, start time.Time) {
+	c.latency.Record(context.Background(), time.Since(start).Seconds(),
+		metric.WithAttributes(c.name, attribute.String("cache.operation", operation)))
+}
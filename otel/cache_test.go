@@ -0,0 +1,58 @@
+package otel
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/vhndaree/lru"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("should record hits, misses and latency", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		cache, err := Wrap(lru.New[int, int](2), provider.Meter("test"), "test")
+		if err != nil {
+			t.Fatalf("Expected no error; Actual = %v", err)
+		}
+
+		cache.Set(1, 1)
+		cache.Get(1)
+		cache.Get(2)
+		cache.GetOrSet(2, 2)
+
+		var rm metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &rm); err != nil {
+			t.Fatalf("Expected no error; Actual = %v", err)
+		}
+
+		actual := map[string]int64{}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				switch data := m.Data.(type) {
+				case metricdata.Sum[int64]:
+					for _, dp := range data.DataPoints {
+						actual[m.Name] += dp.Value
+					}
+				case metricdata.Histogram[float64]:
+					for _, dp := range data.DataPoints {
+						actual[m.Name] += int64(dp.Count)
+					}
+				}
+			}
+		}
+
+		expected := map[string]int64{
+			"lru.cache.hits":               1,
+			"lru.cache.misses":             2,
+			"lru.cache.operation.duration": 4,
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("Expected %v; Actual = %v", expected, actual)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package lru
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshotter is a cache which can be saved to and restored from a snapshot.
+type snapshotter interface {
+	Save(w io.Writer) error
+	Restore(r io.Reader) error
+}
+
+// persistence periodically saves a cache to a file, see WithPersistence.
+type persistence struct {
+	path     string        // File the cache is saved to.
+	interval time.Duration // How often the cache is saved, zero to only save it on close.
+	done     chan struct{} // Closed to stop the background goroutine.
+	stopped  chan struct{} // Closed once the background goroutine exited.
+}
+
+// startPersistence restores the provided cache from the file at the provided path, if it exists,
+// and starts a background goroutine saving the cache to that file at the provided interval.
+// A file which cannot be read or decoded is ignored and the cache starts empty.
+func startPersistence(c snapshotter, path string, interval time.Duration) *persistence {
+	restoreFile(c, path)
+
+	p := &persistence{
+		path:     path,
+		interval: interval,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go p.run(c)
+
+	return p
+}
+
+// run saves the provided cache at every interval until the persistence is stopped.
+func (p *persistence) run(c snapshotter) {
+	defer close(p.stopped)
+
+	if p.interval <= 0 {
+		<-p.done
+		return
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+
+		saveFile(c, p.path)
+	}
+}
+
+// stop stops the background goroutine and saves the provided cache one last time.
+func (p *persistence) stop(c snapshotter) {
+	close(p.done)
+	<-p.stopped
+
+	saveFile(c, p.path)
+}
+
+// saveFile atomically replaces the file at the provided path with a snapshot of the provided cache,
+// by writing the snapshot to a temporary file in the same directory and renaming it over the file.
+func saveFile(c snapshotter, path string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	w := bufio.NewWriter(f)
+	if err := c.Save(w); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// restoreFile restores the provided cache from the file at the provided path.
+// A missing file is not an error.
+func restoreFile(c snapshotter, path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return c.Restore(bufio.NewReader(f))
+}
+
+// startPersistence starts periodically saving the LRU cache when it was created with WithPersistence.
+// It is called once the cache is fully configured, so the restored items honour its limits.
+func (l *lru[K, V]) startPersistence() {
+	if l.persistPath == "" {
+		return
+	}
+
+	l.persistence = startPersistence(l, l.persistPath, l.persistInterval)
+}
@@ -0,0 +1,88 @@
+package lru
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPersistence(t *testing.T) {
+	t.Run("should restore the cache saved on close", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache.gob")
+
+		l := New[int, int](3, WithPersistence[int, int](path, 0))
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Close()
+
+		restored := New[int, int](3, WithPersistence[int, int](path, 0))
+		defer restored.Close()
+
+		if !reflect.DeepEqual([]int{2, 1}, restored.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{2, 1}, restored.Keys())
+		}
+	})
+
+	t.Run("should save the cache periodically", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache.gob")
+
+		l := NewWithExpiry[int, int](3, WithPersistence[int, int](path, 10*time.Millisecond))
+		defer l.Close()
+		l.Set(1, 1)
+
+		time.Sleep(30 * time.Millisecond)
+
+		restored := New[int, int](3)
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Expected nil; Actual = %v", err)
+		}
+		defer f.Close()
+
+		if err := restored.Restore(f); err != nil {
+			t.Errorf("Expected nil; Actual = %v", err)
+		}
+
+		if !reflect.DeepEqual([]int{1}, restored.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{1}, restored.Keys())
+		}
+
+		// no temporary files are left behind
+		l.Close()
+		entries, _ := os.ReadDir(filepath.Dir(path))
+		if !reflect.DeepEqual(1, len(entries)) {
+			t.Errorf("Expected 1; Actual = %v", len(entries))
+		}
+	})
+
+	t.Run("should start empty from a corrupt file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache.gob")
+		os.WriteFile(path, []byte("corrupt"), 0o600)
+
+		l := New[int, int](3, WithPersistence[int, int](path, 0))
+		defer l.Close()
+
+		if !reflect.DeepEqual(0, l.Len()) {
+			t.Errorf("Expected 0; Actual = %v", l.Len())
+		}
+	})
+
+	t.Run("should persist sharded caches as a whole", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache.gob")
+
+		l := NewSharded[int, int](100, 4, WithPersistence[int, int](path, 0))
+		for i := 0; i < 10; i++ {
+			l.Set(i, i)
+		}
+		l.Close()
+
+		restored := NewSharded[int, int](100, 4, WithPersistence[int, int](path, 0))
+		defer restored.Close()
+
+		if !reflect.DeepEqual(10, restored.Len()) {
+			t.Errorf("Expected 10; Actual = %v", restored.Len())
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package lru
+
+// Pin protects the item stored for the provided key from leaving the cache on its own:
+// it is skipped by capacity and cost eviction, never expires and is left alone by the expiry cleaner.
+// Explicit removals, such as Del or Purge, still remove it. Pinning a pinned item has no effect.
+// It returns false if the key is not found in the cache.
+//
+// Eviction looks past pinned items for the least recently used unpinned one,
+// so pinning is meant for a few items, such as configuration entries.
+// When every item is pinned, the cache grows past its capacity until items are unpinned,
+// whereas items which do not fit within the maximum cost besides the pinned ones are evicted right away.
+//
+// Example usage:
+//
+//	cache.Set("config", config)
+//	cache.Pin("config")
+func (l *lru[K, V]) Pin(key K) bool {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	c, ok := l.lookup(key)
+	if !ok {
+		return false
+	}
+
+	if !c.pinned {
+		c.pinned = true
+		l.stats.Pinned++
+		l.track(c)
+	}
+
+	return true
+}
+
+// Unpin lets the item stored for the provided key be evicted and expire again.
+// An item whose TTL passed while it was pinned expires right away.
+// It returns false if the key is not found in the cache or is not pinned.
+func (l *lru[K, V]) Unpin(key K) bool {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	c, ok := l.cache[key]
+	if !ok || !c.pinned {
+		return false
+	}
+
+	c.pinned = false
+	l.stats.Pinned--
+	l.track(c)
+
+	return true
+}
+
+// Pin protects the item stored for the provided key in its shard from leaving the cache on its own.
+func (s *sharded[K, V]) Pin(key K) bool {
+	return s.shard(key).Pin(key)
+}
+
+// Unpin lets the item stored for the provided key in its shard be evicted and expire again.
+func (s *sharded[K, V]) Unpin(key K) bool {
+	return s.shard(key).Unpin(key)
+}
+
+// Pin protects the item stored for the provided key from leaving the cache on its own.
+// An item found in the second tier is promoted into the first tier, where it stays until it is unpinned.
+func (t *tiered[K, V]) Pin(key K) bool {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	if value, ok := t.cold.Peek(key); ok && !t.hot.Contains(key) {
+		t.promote(key, value)
+	}
+
+	return t.hot.Pin(key)
+}
+
+// Unpin lets the item stored for the provided key in the first tier be demoted and expire again.
+func (t *tiered[K, V]) Unpin(key K) bool {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	return t.hot.Unpin(key)
+}
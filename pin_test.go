@@ -0,0 +1,131 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPin(t *testing.T) {
+	t.Run("should skip pinned items on eviction", func(t *testing.T) {
+		l := New[int, int](3)
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+
+		if !l.Pin(1) {
+			t.Fatalf("Expected key 1 to be pinned")
+		}
+		l.Set(4, 4)
+		l.Set(5, 5)
+
+		if !reflect.DeepEqual([]int{5, 4, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{5, 4, 1}, l.Keys())
+		}
+
+		key, _, _ := l.GetOldest()
+		if !reflect.DeepEqual(4, key) {
+			t.Errorf("Expected %v; Actual = %v", 4, key)
+		}
+	})
+
+	t.Run("should grow past the capacity when every item is pinned", func(t *testing.T) {
+		l := New[int, int](2)
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Pin(1)
+		l.Pin(2)
+
+		l.Set(3, 3)
+		if !reflect.DeepEqual(3, l.Len()) {
+			t.Errorf("Expected 3; Actual = %v", l.Len())
+		}
+
+		l.Unpin(1)
+		l.Unpin(2)
+		l.Set(4, 4)
+		if !reflect.DeepEqual([]int{4, 3}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{4, 3}, l.Keys())
+		}
+	})
+
+	t.Run("should not evict pinned items for cost", func(t *testing.T) {
+		l := NewWithCost[int, int](10, 10)
+		l.SetWithCost(1, 1, 3)
+		l.SetWithCost(2, 2, 3)
+		l.Pin(1)
+		l.SetWithCost(3, 3, 6)
+
+		if !reflect.DeepEqual([]int{3, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 1}, l.Keys())
+		}
+
+		l.SetWithCost(4, 4, 8)
+		if !reflect.DeepEqual([]int{1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{1}, l.Keys())
+		}
+	})
+
+	t.Run("should not expire pinned items until they are unpinned", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		l := newLRU[int, int](3, true, []Option[int, int]{WithClock[int, int](clock)})
+
+		l.SetWithTTL(1, 1, time.Minute)
+		l.SetWithTTL(2, 2, time.Minute)
+		l.Pin(1)
+		clock.Advance(time.Hour)
+		l.removeExpired()
+
+		if !reflect.DeepEqual([]int{1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{1}, l.Keys())
+		}
+
+		l.Unpin(1)
+		if _, ok := l.Get(1); ok {
+			t.Errorf("Expected key 1 to expire once unpinned")
+		}
+	})
+
+	t.Run("should report pinned items in stats", func(t *testing.T) {
+		l := NewSharded[int, int](10, 2)
+		for i := 0; i < 4; i++ {
+			l.Set(i, i)
+			l.Pin(i)
+		}
+		l.Pin(0)
+		l.Unpin(1)
+		l.Del(2)
+
+		if !reflect.DeepEqual(uint64(2), l.Stats().Pinned) {
+			t.Errorf("Expected 2; Actual = %v", l.Stats().Pinned)
+		}
+
+		l.Purge()
+		if !reflect.DeepEqual(uint64(0), l.Stats().Pinned) {
+			t.Errorf("Expected 0; Actual = %v", l.Stats().Pinned)
+		}
+	})
+
+	t.Run("should report missing and unpinned keys", func(t *testing.T) {
+		l := New[int, int](3)
+		l.Set(1, 1)
+
+		if l.Pin(2) || l.Unpin(1) || l.Unpin(2) {
+			t.Errorf("Expected false for missing and unpinned keys")
+		}
+	})
+
+	t.Run("should keep pinned items in the first tier", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](1), New[int, int](10))
+		defer l.Close()
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Pin(1)
+		l.Set(3, 3)
+
+		if _, ok := l.(*tiered[int, int]).hot.Peek(1); !ok {
+			t.Errorf("Expected key 1 in the first tier")
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package lru
+
+import "time"
+
+// Priority is the eviction priority of an item: capacity and cost eviction pick the least recently used item
+// of the lowest priority present in the cache, and only evict items of higher priorities once none is left.
+type Priority int
+
+const (
+	// PriorityLow marks items which are cheap to recompute, evicted before any other item.
+	PriorityLow Priority = iota - 1
+
+	// PriorityNormal is the priority of items stored without one, such as with Set.
+	PriorityNormal
+
+	// PriorityHigh marks items which are expensive to recompute, only evicted once no other item is left.
+	PriorityHigh
+)
+
+// String returns the name of the priority.
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// SetWithPriority adds or updates a key-value pair in the LRU cache like Set, with the provided eviction priority,
+// so capacity and cost eviction prefer victims of lower priorities before touching items of higher ones.
+// Priorities outside of PriorityLow and PriorityHigh are clamped. The priority replaces that of a previous value
+// stored for the key; Set and the other setters store values with PriorityNormal.
+// GetOldest and RemoveOldest follow the same order as eviction.
+//
+// Eviction looks past the items of higher priorities for the least recently used item of the lowest one,
+// so a few low priority items among many others make eviction slower.
+//
+// Example usage:
+//
+//	cache.SetWithPriority("report:2024", report, lru.PriorityHigh)
+//	cache.SetWithPriority("thumbnail:42", thumbnail, lru.PriorityLow)
+func (l *lru[K, V]) SetWithPriority(key K, value V, priority Priority) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	var expiry time.Time
+	l.set(key, value, expiry)
+
+	if c, ok := l.cache[key]; ok {
+		l.prioritize(c, min(max(priority, PriorityLow), PriorityHigh))
+	}
+}
+
+// prioritize changes the priority of the item, keeping the number of items of every priority up to date.
+func (l *lru[K, V]) prioritize(c *cache[K, V], priority Priority) {
+	l.priorities[c.priority-PriorityLow]--
+	c.priority = priority
+	l.priorities[c.priority-PriorityLow]++
+}
+
+// victim returns the least recently used item of the lowest priority which is neither pinned nor leased, or nil if there is none.
+func (l *lru[K, V]) victim() *cache[K, V] {
+	// most caches only hold items of the normal priority
+	if l.priorities[PriorityNormal-PriorityLow] == l.length {
+		c := l.back()
+		for c != nil && c.held() {
+			c = l.prev(c)
+		}
+
+		return c
+	}
+
+	for p := PriorityLow; p <= PriorityHigh; p++ {
+		if l.priorities[p-PriorityLow] == 0 {
+			continue
+		}
+
+		for c := l.back(); c != nil; c = l.prev(c) {
+			if !c.held() && c.priority == p {
+				return c
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetWithPriority adds or updates a key-value pair in the shard responsible for the key with the provided priority.
+func (s *sharded[K, V]) SetWithPriority(key K, value V, priority Priority) {
+	s.shard(key).SetWithPriority(key, value, priority)
+}
+
+// SetWithPriority adds or updates a key-value pair in the first tier with the provided priority,
+// which decides the order in which items are demoted into the second tier.
+// Items keep their priority until they are demoted, the second tier stores them like Set.
+func (t *tiered[K, V]) SetWithPriority(key K, value V, priority Priority) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	t.makeRoom(key)
+	t.hot.SetWithPriority(key, value, priority)
+}
@@ -0,0 +1,100 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPriority(t *testing.T) {
+	t.Run("should evict lower priorities first", func(t *testing.T) {
+		l := New[int, int](3)
+		l.SetWithPriority(1, 1, PriorityHigh)
+		l.Set(2, 2)
+		l.SetWithPriority(3, 3, PriorityLow)
+
+		l.Set(4, 4)
+		if !reflect.DeepEqual([]int{4, 2, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{4, 2, 1}, l.Keys())
+		}
+
+		l.Set(5, 5)
+		if !reflect.DeepEqual([]int{5, 4, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{5, 4, 1}, l.Keys())
+		}
+
+		key, _, _ := l.GetOldest()
+		if !reflect.DeepEqual(4, key) {
+			t.Errorf("Expected %v; Actual = %v", 4, key)
+		}
+	})
+
+	t.Run("should evict high priorities once no other item is left", func(t *testing.T) {
+		l := New[int, int](2)
+		l.SetWithPriority(1, 1, PriorityHigh)
+		l.SetWithPriority(2, 2, PriorityHigh)
+		l.Get(1)
+
+		l.SetWithPriority(3, 3, PriorityHigh)
+		if !reflect.DeepEqual([]int{3, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 1}, l.Keys())
+		}
+	})
+
+	t.Run("should reset the priority on Set", func(t *testing.T) {
+		l := New[int, int](2)
+		l.SetWithPriority(1, 1, PriorityHigh)
+		l.Set(1, 10)
+		l.Set(2, 2)
+
+		l.Set(3, 3)
+		if !reflect.DeepEqual([]int{3, 2}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 2}, l.Keys())
+		}
+	})
+
+	t.Run("should clamp priorities and skip pinned items", func(t *testing.T) {
+		l := New[int, int](3)
+		l.SetWithPriority(1, 1, Priority(-5))
+		l.SetWithPriority(2, 2, PriorityLow)
+		l.Set(3, 3)
+		l.Pin(1)
+
+		l.Set(4, 4)
+		if !reflect.DeepEqual([]int{4, 3, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{4, 3, 1}, l.Keys())
+		}
+	})
+
+	t.Run("should keep counting priorities across removals", func(t *testing.T) {
+		l := New[int, int](2).(*lru[int, int])
+		l.SetWithPriority(1, 1, PriorityLow)
+		l.SetWithPriority(2, 2, PriorityHigh)
+		l.Del(1)
+		l.Purge()
+		l.Set(3, 3)
+
+		if !reflect.DeepEqual([3]int{0, 1, 0}, l.priorities) {
+			t.Errorf("Expected %v; Actual = %v", [3]int{0, 1, 0}, l.priorities)
+		}
+	})
+
+	t.Run("should demote lower priorities first from the first tier", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](2), New[int, int](10))
+		defer l.Close()
+
+		l.SetWithPriority(1, 1, PriorityHigh)
+		l.Set(2, 2)
+		l.Set(3, 3)
+
+		hot := l.(*tiered[int, int]).hot
+		if !reflect.DeepEqual([]int{3, 1}, hot.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 1}, hot.Keys())
+		}
+	})
+
+	t.Run("should name priorities", func(t *testing.T) {
+		if !reflect.DeepEqual("high", PriorityHigh.String()) {
+			t.Errorf("Expected %v; Actual = %v", "high", PriorityHigh.String())
+		}
+	})
+}
@@ -0,0 +1,73 @@
+// Package prometheus exposes the statistics of a cache as Prometheus metrics.
+package prometheus
+
+import (
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/vhndaree/lru"
+)
+
+// Source is the subset of cache methods read by the Collector.
+// Every cache created by the lru package satisfies it.
+type Source interface {
+	Len() int
+	Cap() int
+	Stats() lru.Stats
+}
+
+// Collector is a prometheus.Collector reporting the statistics of a single cache.
+// Every metric carries a "cache" label holding the name the collector was created with,
+// so several caches can be registered side by side.
+type Collector struct {
+	source      Source     // Cache whose statistics are reported.
+	hits        *prom.Desc // Number of lookups which found the key.
+	misses      *prom.Desc // Number of lookups which did not find the key.
+	evictions   *prom.Desc // Number of items removed to make room for other items.
+	expirations *prom.Desc // Number of items removed because their TTL passed.
+	length      *prom.Desc // Current number of items in the cache.
+	capacity    *prom.Desc // Maximum number of items the cache can hold.
+}
+
+// NewCollector creates a Collector reporting the statistics of the provided cache under the provided name.
+//
+// Example usage:
+//
+//	cache := lru.New[string, string](100)
+//	prometheus.MustRegister(lruprometheus.NewCollector("sessions", cache))
+func NewCollector(name string, source Source) *Collector {
+	labels := prom.Labels{"cache": name}
+	desc := func(metric, help string) *prom.Desc {
+		return prom.NewDesc(prom.BuildFQName("lru", "cache", metric), help, nil, labels)
+	}
+
+	return &Collector{
+		source:      source,
+		hits:        desc("hits_total", "Number of cache lookups which found the key."),
+		misses:      desc("misses_total", "Number of cache lookups which did not find the key."),
+		evictions:   desc("evictions_total", "Number of items removed to make room for other items."),
+		expirations: desc("expirations_total", "Number of items removed because their TTL passed."),
+		length:      desc("length", "Current number of items in the cache."),
+		capacity:    desc("capacity", "Maximum number of items the cache can hold, 0 when unbounded."),
+	}
+}
+
+// Describe sends the descriptors of every metric reported by the collector.
+func (c *Collector) Describe(ch chan<- *prom.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.evictions
+	ch <- c.expirations
+	ch <- c.length
+	ch <- c.capacity
+}
+
+// Collect reads the current statistics of the cache and sends them as metrics.
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	stats := c.source.Stats()
+
+	ch <- prom.MustNewConstMetric(c.hits, prom.CounterValue, float64(stats.Hits))
+	ch <- prom.MustNewConstMetric(c.misses, prom.CounterValue, float64(stats.Misses))
+	ch <- prom.MustNewConstMetric(c.evictions, prom.CounterValue, float64(stats.Evictions))
+	ch <- prom.MustNewConstMetric(c.expirations, prom.CounterValue, float64(stats.Expirations))
+	ch <- prom.MustNewConstMetric(c.length, prom.GaugeValue, float64(c.source.Len()))
+	ch <- prom.MustNewConstMetric(c.capacity, prom.GaugeValue, float64(c.source.Cap()))
+}
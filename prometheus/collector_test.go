@@ -0,0 +1,46 @@
+package prometheus
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/vhndaree/lru"
+)
+
+func TestCollector(t *testing.T) {
+	t.Run("should report cache statistics", func(t *testing.T) {
+		cache := lru.New[int, int](2)
+
+		cache.Set(1, 1)
+		cache.Set(2, 2)
+		cache.Set(3, 3)
+		cache.Get(3)
+		cache.Get(1)
+
+		expected := `
+# HELP lru_cache_capacity Maximum number of items the cache can hold, 0 when unbounded.
+# TYPE lru_cache_capacity gauge
+lru_cache_capacity{cache="test"} 2
+# HELP lru_cache_evictions_total Number of items removed to make room for other items.
+# TYPE lru_cache_evictions_total counter
+lru_cache_evictions_total{cache="test"} 1
+# HELP lru_cache_expirations_total Number of items removed because their TTL passed.
+# TYPE lru_cache_expirations_total counter
+lru_cache_expirations_total{cache="test"} 0
+# HELP lru_cache_hits_total Number of cache lookups which found the key.
+# TYPE lru_cache_hits_total counter
+lru_cache_hits_total{cache="test"} 1
+# HELP lru_cache_length Current number of items in the cache.
+# TYPE lru_cache_length gauge
+lru_cache_length{cache="test"} 2
+# HELP lru_cache_misses_total Number of cache lookups which did not find the key.
+# TYPE lru_cache_misses_total counter
+lru_cache_misses_total{cache="test"} 1
+`
+
+		if err := testutil.CollectAndCompare(NewCollector("test", cache), strings.NewReader(expected)); err != nil {
+			t.Errorf("Expected matching metrics; Actual = %v", err)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+// Package rate limits the rate of events per key, such as requests per client, with token buckets kept in an LRU cache.
+package rate
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vhndaree/lru"
+)
+
+// bucket is the token bucket of a single key.
+type bucket struct {
+	tokens     float64   // Tokens left, up to the burst.
+	last       time.Time // When the tokens were last refilled.
+	sync.Mutex           // Mutex guarding the bucket.
+}
+
+// Limiter limits the rate of events of every key with a token bucket: each key may spend up to burst events at once,
+// and regains tokens at the configured rate. Buckets are kept in an LRU cache bounded to a number of keys,
+// and expire once they have been idle long enough to refill completely, as a new bucket is then equivalent.
+//
+// When more keys are active than the cache holds, the least recently used buckets are evicted
+// and their keys start again with a full bucket, which makes the limiter more permissive under pressure.
+type Limiter[K comparable] struct {
+	cache lru.LRUWithExpiry[K, *bucket] // Buckets by key.
+	rate  float64                       // Tokens regained per second.
+	burst float64                       // Maximum number of tokens of a bucket.
+	now   func() time.Time              // Returns the current time of the clock refilling the buckets.
+}
+
+// Option configures optional behaviour of a Limiter at construction time.
+type Option func(*options)
+
+// options holds the optional behaviour of a Limiter.
+type options struct {
+	clock lru.Clock // Clock refilling the buckets and expiring idle ones, nil for the system clock.
+}
+
+// WithClock configures the clock of the Limiter, such as an lru.FakeClock in tests, instead of the system clock.
+func WithClock(clock lru.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// New creates a Limiter allowing every key rate events per second with bursts of up to burst events,
+// keeping the buckets of up to size keys. Optional behaviour can be configured by passing one or more Option values.
+// Call Close once the limiter is no longer needed to stop the cleaner of idle buckets.
+//
+// Example usage:
+//
+//	limiter := rate.New[string](100000, 10, 20)
+//	defer limiter.Close()
+//	if !limiter.Allow(clientIP) {
+//		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+//		return
+//	}
+func New[K comparable](size int, rate float64, burst int, opts ...Option) *Limiter[K] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	now := time.Now
+	var cacheOpts []lru.Option[K, *bucket]
+	if o.clock != nil {
+		now = o.clock.Now
+		cacheOpts = append(cacheOpts, lru.WithClock[K, *bucket](o.clock))
+	}
+	if rate > 0 {
+		refill := time.Duration(float64(burst) / rate * float64(time.Second))
+		cacheOpts = append(cacheOpts, lru.WithSlidingTTL[K, *bucket](max(refill, time.Millisecond)))
+	}
+
+	return &Limiter[K]{
+		cache: lru.NewWithExpiry[K, *bucket](size, cacheOpts...),
+		rate:  rate,
+		burst: float64(burst),
+		now:   now,
+	}
+}
+
+// Allow reports whether an event of the provided key may happen now, spending a token of its bucket if so.
+func (l *Limiter[K]) Allow(key K) bool {
+	return l.AllowN(key, 1)
+}
+
+// AllowN reports whether n events of the provided key may happen now, spending n tokens of its bucket if so.
+// Nothing is spent when fewer than n tokens are left.
+func (l *Limiter[K]) AllowN(key K, n int) bool {
+	b, ok := l.cache.Get(key)
+	if !ok {
+		b, _ = l.cache.Compute(key, func(old *bucket, exists bool) (*bucket, bool) {
+			if exists {
+				return old, true
+			}
+			return &bucket{tokens: l.burst, last: l.now()}, true
+		})
+	}
+	if b == nil {
+		// a cache which cannot hold anything gives every event a new bucket
+		b = &bucket{tokens: l.burst, last: l.now()}
+	}
+
+	b.Mutex.Lock()
+	defer b.Mutex.Unlock()
+
+	now := l.now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(l.burst, b.tokens+elapsed.Seconds()*l.rate)
+		b.last = now
+	}
+
+	if b.tokens < float64(n) {
+		return false
+	}
+
+	b.tokens -= float64(n)
+	return true
+}
+
+// Tokens returns the number of tokens left in the bucket of the provided key, without spending any.
+// Keys without a bucket have a full one.
+func (l *Limiter[K]) Tokens(key K) float64 {
+	b, ok := l.cache.Peek(key)
+	if !ok {
+		return l.burst
+	}
+
+	b.Mutex.Lock()
+	defer b.Mutex.Unlock()
+
+	elapsed := max(l.now().Sub(b.last), 0)
+	return min(l.burst, b.tokens+elapsed.Seconds()*l.rate)
+}
+
+// Reset gives the provided key a full bucket again.
+func (l *Limiter[K]) Reset(key K) {
+	l.cache.Del(key)
+}
+
+// Len returns the number of keys whose bucket is currently kept.
+func (l *Limiter[K]) Len() int {
+	return l.cache.Len()
+}
+
+// Close stops the cleaner of idle buckets. It is safe to call Close more than once.
+func (l *Limiter[K]) Close() {
+	l.cache.Close()
+}
@@ -0,0 +1,94 @@
+package rate
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vhndaree/lru"
+)
+
+func TestLimiter(t *testing.T) {
+	t.Run("should allow bursts and refill at the rate", func(t *testing.T) {
+		clock := lru.NewFakeClock(time.Now())
+		l := New[string](10, 2, 3, WithClock(clock))
+		defer l.Close()
+
+		for i := 0; i < 3; i++ {
+			if !l.Allow("a") {
+				t.Errorf("Expected event %v to be allowed", i)
+			}
+		}
+		if l.Allow("a") {
+			t.Errorf("Expected the burst to be exhausted")
+		}
+		if !l.Allow("b") {
+			t.Errorf("Expected other keys to be allowed")
+		}
+
+		clock.Advance(500 * time.Millisecond)
+		if !l.Allow("a") || l.Allow("a") {
+			t.Errorf("Expected a single token to be regained")
+		}
+	})
+
+	t.Run("should spend nothing when too few tokens are left", func(t *testing.T) {
+		clock := lru.NewFakeClock(time.Now())
+		l := New[int](10, 1, 5, WithClock(clock))
+		defer l.Close()
+
+		if !l.AllowN(1, 4) || l.AllowN(1, 2) {
+			t.Errorf("Expected only the first events to be allowed")
+		}
+		if !reflect.DeepEqual(1.0, l.Tokens(1)) {
+			t.Errorf("Expected %v; Actual = %v", 1.0, l.Tokens(1))
+		}
+
+		l.Reset(1)
+		if !reflect.DeepEqual(5.0, l.Tokens(1)) {
+			t.Errorf("Expected %v; Actual = %v", 5.0, l.Tokens(1))
+		}
+	})
+
+	t.Run("should drop buckets once they refilled", func(t *testing.T) {
+		clock := lru.NewFakeClock(time.Now())
+		l := New[int](10, 1, 2, WithClock(clock))
+		defer l.Close()
+
+		l.Allow(1)
+		clock.Advance(time.Second)
+		l.Allow(1)
+		if !reflect.DeepEqual(1, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 1, l.Len())
+		}
+
+		clock.Advance(3 * time.Second)
+		if !reflect.DeepEqual(2.0, l.Tokens(1)) || !reflect.DeepEqual(0, l.Len()) {
+			t.Errorf("Expected the idle bucket to expire; Actual = %v", l.Len())
+		}
+	})
+
+	t.Run("should limit concurrent events", func(t *testing.T) {
+		l := New[int](10, 0, 10)
+		defer l.Close()
+
+		var wg sync.WaitGroup
+		var allowed atomic.Int32
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if l.Allow(1) {
+					allowed.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if !reflect.DeepEqual(int32(10), allowed.Load()) {
+			t.Errorf("Expected %v; Actual = %v", 10, allowed.Load())
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package lru
+
+// ReadOnly is a read-only view of a cache, which can be handed to code such as plugins or request handlers
+// without letting it modify the cache. It is obtained with AsReadOnly.
+type ReadOnly[K comparable, V any] interface {
+	// Contains checks if the provided key is present in the cache.
+	Contains(key K) bool
+
+	// Get retrieves the value associated with the provided key, promoting it like the Get of the cache.
+	Get(key K) (value V, found bool)
+
+	// Peek retrieves the value associated with the provided key without updating the order of items in the cache.
+	Peek(key K) (value V, found bool)
+
+	// Len returns the number of items currently stored in the cache.
+	Len() int
+
+	// Keys returns a snapshot of the keys in the cache,
+	// ordered from the most recently used to the least recently used.
+	Keys() []K
+}
+
+// readOnly is the read-only view of a cache returned by AsReadOnly.
+// The cache is held in an unexported field, so it cannot be recovered with a type assertion.
+type readOnly[K comparable, V any] struct {
+	cache ReadOnly[K, V] // Cache the view reads from.
+}
+
+// Contains checks if the provided key is present in the cache.
+func (r readOnly[K, V]) Contains(key K) bool {
+	return r.cache.Contains(key)
+}
+
+// Get retrieves the value associated with the provided key from the cache.
+func (r readOnly[K, V]) Get(key K) (V, bool) {
+	return r.cache.Get(key)
+}
+
+// Peek retrieves the value associated with the provided key from the cache without promoting it.
+func (r readOnly[K, V]) Peek(key K) (V, bool) {
+	return r.cache.Peek(key)
+}
+
+// Len returns the number of items currently stored in the cache.
+func (r readOnly[K, V]) Len() int {
+	return r.cache.Len()
+}
+
+// Keys returns a snapshot of the keys in the cache.
+func (r readOnly[K, V]) Keys() []K {
+	return r.cache.Keys()
+}
+
+// AsReadOnly returns a read-only view of the LRU cache, exposing only Get, Peek, Contains, Len and Keys.
+// The view reads the cache itself rather than a copy, so it observes later changes; Get still promotes items
+// and counts hits and misses like the Get of the cache.
+//
+// Example usage:
+//
+//	plugin.Init(cache.AsReadOnly())
+func (l *lru[K, V]) AsReadOnly() ReadOnly[K, V] {
+	return readOnly[K, V]{cache: l}
+}
+
+// AsReadOnly returns a read-only view of the sharded cache.
+func (s *sharded[K, V]) AsReadOnly() ReadOnly[K, V] {
+	return readOnly[K, V]{cache: s}
+}
+
+// AsReadOnly returns a read-only view of both tiers.
+func (t *tiered[K, V]) AsReadOnly() ReadOnly[K, V] {
+	return readOnly[K, V]{cache: t}
+}
@@ -0,0 +1,41 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAsReadOnly(t *testing.T) {
+	t.Run("should read the cache", func(t *testing.T) {
+		l := New[int, int](3)
+		l.Set(1, 1)
+		l.Set(2, 2)
+
+		r := l.AsReadOnly()
+		if value, ok := r.Get(1); !ok || !reflect.DeepEqual(1, value) {
+			t.Errorf("Expected %v; Actual = %v %v", 1, value, ok)
+		}
+		if !reflect.DeepEqual([]int{1, 2}, r.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{1, 2}, r.Keys())
+		}
+
+		l.Set(3, 3)
+		if value, ok := r.Peek(3); !ok || !reflect.DeepEqual(3, value) || !r.Contains(3) || !reflect.DeepEqual(3, r.Len()) {
+			t.Errorf("Expected the view to observe later changes; Actual = %v", r.Keys())
+		}
+	})
+
+	t.Run("should not expose the cache", func(t *testing.T) {
+		for _, l := range []LRU[int, int]{New[int, int](3), NewSharded[int, int](8, 2), NewTiered[int, int](New[int, int](1), New[int, int](2))} {
+			l.Set(1, 1)
+
+			r := l.AsReadOnly()
+			if _, ok := r.(interface{ Del(key int) bool }); ok {
+				t.Errorf("Expected the view not to delete")
+			}
+			if !r.Contains(1) {
+				t.Errorf("Expected %v to be found", 1)
+			}
+		}
+	})
+}
@@ -0,0 +1,80 @@
+// Package redis broadcasts the deletions of a cache between processes through Redis pub/sub.
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// message is a deletion broadcast on the channel.
+type message[K comparable] struct {
+	Source string `json:"source"` // Identifier of the Invalidator which broadcast the deletion.
+	Key    K      `json:"key"`    // Deleted key.
+}
+
+// Invalidator is an lru.Invalidator publishing deletions to a Redis channel and subscribing to the deletions of other processes.
+// Keys are encoded as JSON, so every process must use the same key type.
+type Invalidator[K comparable] struct {
+	client  goredis.UniversalClient // Client of the Redis server.
+	channel string                  // Channel the deletions are published to.
+	id      string                  // Random identifier telling the deletions of this Invalidator apart.
+}
+
+// NewInvalidator creates an Invalidator broadcasting deletions on the provided Redis channel.
+// Every instance of a cache must use the same channel, and distinct caches distinct channels.
+//
+// Example usage:
+//
+//	client := goredis.NewClient(&goredis.Options{Addr: "localhost:6379"})
+//	cache := lru.New[string, User](1000, lru.WithInvalidator[string, User](lruredis.NewInvalidator[string](client, "users")))
+func NewInvalidator[K comparable](client goredis.UniversalClient, channel string) *Invalidator[K] {
+	id := make([]byte, 16)
+	rand.Read(id)
+
+	return &Invalidator[K]{client: client, channel: channel, id: hex.EncodeToString(id)}
+}
+
+// Invalidate publishes the deletion of the provided key on the channel.
+func (i *Invalidator[K]) Invalidate(ctx context.Context, key K) error {
+	data, err := json.Marshal(message[K]{Source: i.id, Key: key})
+	if err != nil {
+		return err
+	}
+
+	return i.client.Publish(ctx, i.channel, data).Err()
+}
+
+// Subscribe calls fn with every key deleted by another Invalidator on the channel,
+// until the context is done or the subscription fails.
+// Messages which cannot be decoded are skipped.
+func (i *Invalidator[K]) Subscribe(ctx context.Context, fn func(key K)) error {
+	sub := i.client.Subscribe(ctx, i.channel)
+	defer sub.Close()
+
+	// wait for the subscription to be confirmed, so no deletion published afterwards is missed
+	if _, err := sub.Receive(ctx); err != nil {
+		return err
+	}
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			var m message[K]
+			if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil || m.Source == i.id {
+				continue
+			}
+			fn(m.Key)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/vhndaree/lru"
+)
+
+func TestInvalidator(t *testing.T) {
+	t.Run("should invalidate keys deleted by other instances", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+		defer client.Close()
+
+		a := lru.New[string, int](10, lru.WithInvalidator[string, int](NewInvalidator[string](client, "test")))
+		defer a.Close()
+		b := lru.New[string, int](10, lru.WithInvalidator[string, int](NewInvalidator[string](client, "test")))
+		defer b.Close()
+
+		// wait for both subscriptions
+		for server.PubSubNumSub("test")["test"] < 2 {
+			time.Sleep(time.Millisecond)
+		}
+
+		a.Set("k", 1)
+		b.Set("k", 1)
+		a.Del("k")
+
+		// a's own deletion is not reported back, so the value it set again survives
+		a.Set("k", 2)
+
+		deadline := time.Now().Add(time.Second)
+		_, ok := b.Peek("k")
+		for ok && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+			_, ok = b.Peek("k")
+		}
+
+		if !reflect.DeepEqual(false, ok) {
+			t.Errorf("Expected false; Actual = %v", ok)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		actual, _ := a.Peek("k")
+		if !reflect.DeepEqual(2, actual) {
+			t.Errorf("Expected 2; Actual = %v", actual)
+		}
+	})
+}
@@ -0,0 +1,277 @@
+package lru
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultSampleSize is the number of items a sampled cache compares to pick the one to evict.
+const DefaultSampleSize = 5
+
+// sampledItem represents an item in the sampled cache.
+type sampledItem[K comparable, V any] struct {
+	key      K             // Key associated with the cache item.
+	value    V             // Value associated with the cache item.
+	index    int           // Index of the item in the items slice.
+	accessed atomic.Uint64 // Tick of the last access to the item.
+}
+
+// sampled represents a cache approximating LRU by sampling, like Redis does.
+// Items are not kept in any order: every access stamps the item with a logical clock tick, under the read lock,
+// and when the cache is full a few random items are sampled and the least recently used of them is evicted.
+type sampled[K comparable, V any] struct {
+	cache        map[K]*sampledItem[K, V] // Map storing cached items.
+	items        []*sampledItem[K, V]     // Dense slice of the cached items, to sample them in constant time.
+	size         int                      // Maximum number of items the cache can hold.
+	samples      int                      // Number of items sampled to pick the one to evict.
+	tick         atomic.Uint64            // Logical clock stamping the accesses.
+	hits         atomic.Uint64            // Number of lookups which found the key.
+	misses       atomic.Uint64            // Number of lookups which did not find the key.
+	stats        Stats                    // Usage counters of the cache other than hits and misses.
+	sync.RWMutex                          // Lock for concurrent access, read-locked by lookups.
+}
+
+// Contains checks if the provided key is present in the sampled cache.
+// The function does not affect the cache's state or modify any data.
+func (s *sampled[K, V]) Contains(key K) bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	_, ok := s.cache[key]
+	return ok
+}
+
+// Set adds or updates a key-value pair in the sampled cache.
+// If the cache is full, the least recently used of a random sample of items is evicted to make room for a new key.
+func (s *sampled[K, V]) Set(key K, value V) {
+	s.Lock()
+	defer s.Unlock()
+
+	if c, ok := s.cache[key]; ok {
+		c.value = value
+		c.accessed.Store(s.tick.Add(1))
+		return
+	}
+
+	if s.size < 0 {
+		return
+	}
+
+	// the item of the evicted key is reused for the new one
+	var c *sampledItem[K, V]
+	if s.size != Unbounded && len(s.items) >= s.size {
+		c = s.evict()
+	}
+	if c == nil {
+		c = &sampledItem[K, V]{}
+	}
+
+	c.key, c.value, c.index = key, value, len(s.items)
+	c.accessed.Store(s.tick.Add(1))
+	s.items = append(s.items, c)
+	s.cache[key] = c
+}
+
+// Get retrieves the value associated with the provided key from the sampled cache,
+// stamping it as the most recently used item. Only the read lock is held,
+// so concurrent reads do not contend with each other.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (s *sampled[K, V]) Get(key K) (V, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	c, ok := s.cache[key]
+	if !ok {
+		s.misses.Add(1)
+
+		var emptyVal V
+		return emptyVal, false
+	}
+
+	s.hits.Add(1)
+	c.accessed.Store(s.tick.Add(1))
+
+	return c.value, true
+}
+
+// Peek retrieves the value associated with the provided key from the sampled cache
+// without stamping it as used.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (s *sampled[K, V]) Peek(key K) (V, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if c, ok := s.cache[key]; ok {
+		return c.value, true
+	}
+
+	var emptyVal V
+	return emptyVal, false
+}
+
+// Del removes the key-value pair associated with the provided key from the sampled cache.
+// If the key is found and the removal is successful, the function returns true.
+// If the key is not found, it returns false.
+func (s *sampled[K, V]) Del(key K) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	c, ok := s.cache[key]
+	if !ok {
+		return false
+	}
+
+	s.remove(c)
+
+	return true
+}
+
+// Len returns the number of items currently stored in the sampled cache.
+func (s *sampled[K, V]) Len() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return len(s.items)
+}
+
+// Cap returns the maximum number of items the sampled cache can hold.
+func (s *sampled[K, V]) Cap() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.size
+}
+
+// Purge removes all key-value pairs from the sampled cache, leaving it empty.
+func (s *sampled[K, V]) Purge() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.cache = map[K]*sampledItem[K, V]{}
+	s.items = nil
+}
+
+// Resize changes the maximum number of items the sampled cache can hold.
+// If the new size is smaller than the current number of items,
+// sampled items are evicted until the cache fits.
+func (s *sampled[K, V]) Resize(size int) {
+	s.Lock()
+	defer s.Unlock()
+
+	for size != Unbounded && len(s.items) > max(size, 0) {
+		s.evict()
+	}
+
+	s.size = size
+}
+
+// Keys returns a snapshot of the keys in the sampled cache,
+// ordered from the most recently used to the least recently used.
+func (s *sampled[K, V]) Keys() []K {
+	keys, _ := s.snapshot()
+	return keys
+}
+
+// Values returns a snapshot of the values in the sampled cache, in the same order as Keys.
+func (s *sampled[K, V]) Values() []V {
+	_, values := s.snapshot()
+	return values
+}
+
+// All returns an iterator over the key-value pairs in the sampled cache,
+// ordered from the most recently used to the least recently used.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (s *sampled[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		forward(s.snapshot())(yield)
+	}
+}
+
+// Backward returns an iterator over the key-value pairs in the sampled cache,
+// ordered from the least recently used to the most recently used.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (s *sampled[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		backward(s.snapshot())(yield)
+	}
+}
+
+// snapshot returns a snapshot of the keys and values in the sampled cache,
+// ordered from the most recently used to the least recently used by their access ticks.
+func (s *sampled[K, V]) snapshot() ([]K, []V) {
+	s.RLock()
+	defer s.RUnlock()
+
+	items := slices.Clone(s.items)
+	slices.SortFunc(items, func(a, b *sampledItem[K, V]) int {
+		return cmp.Compare(b.accessed.Load(), a.accessed.Load())
+	})
+
+	keys := make([]K, 0, len(items))
+	values := make([]V, 0, len(items))
+	for _, c := range items {
+		keys = append(keys, c.key)
+		values = append(values, c.value)
+	}
+
+	return keys, values
+}
+
+// Stats returns a snapshot of the usage counters of the sampled cache.
+func (s *sampled[K, V]) Stats() Stats {
+	s.RLock()
+	defer s.RUnlock()
+
+	return Stats{
+		Hits:      s.hits.Load(),
+		Misses:    s.misses.Load(),
+		Evictions: s.stats.Evictions,
+	}
+}
+
+// Close is a no-op, the sampled cache owns no background goroutines.
+func (s *sampled[K, V]) Close() {}
+
+// evict removes the least recently used of a random sample of items and returns it, or nil if the cache is empty.
+// Items may be sampled more than once, caches holding no more items than the sample size are scanned entirely.
+func (s *sampled[K, V]) evict() *sampledItem[K, V] {
+	if len(s.items) == 0 {
+		return nil
+	}
+
+	var victim *sampledItem[K, V]
+	if len(s.items) <= s.samples {
+		for _, c := range s.items {
+			if victim == nil || c.accessed.Load() < victim.accessed.Load() {
+				victim = c
+			}
+		}
+	} else {
+		for range s.samples {
+			c := s.items[rand.IntN(len(s.items))]
+			if victim == nil || c.accessed.Load() < victim.accessed.Load() {
+				victim = c
+			}
+		}
+	}
+
+	s.stats.Evictions++
+	s.remove(victim)
+
+	return victim
+}
+
+// remove deletes the provided item, moving the last item of the slice into its place.
+func (s *sampled[K, V]) remove(c *sampledItem[K, V]) {
+	last := s.items[len(s.items)-1]
+	s.items[c.index] = last
+	last.index = c.index
+	s.items[len(s.items)-1] = nil
+	s.items = s.items[:len(s.items)-1]
+
+	delete(s.cache, c.key)
+}
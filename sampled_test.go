@@ -0,0 +1,105 @@
+package lru
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSampled(t *testing.T) {
+	t.Run("should evict the least recently used item of the sample", func(t *testing.T) {
+		l := NewSampled[int, int](3, 3)
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+		l.Get(1)
+
+		l.Set(4, 4)
+
+		expected := []int{4, 1, 3}
+		if !reflect.DeepEqual(expected, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+		}
+		if !reflect.DeepEqual(uint64(1), l.Stats().Evictions) {
+			t.Errorf("Expected %v; Actual = %v", 1, l.Stats().Evictions)
+		}
+	})
+
+	t.Run("should mostly evict old items", func(t *testing.T) {
+		l := NewSampled[int, int](100, DefaultSampleSize)
+		for i := range 100 {
+			l.Set(i, i)
+		}
+		for i := 50; i < 100; i++ {
+			l.Get(i)
+		}
+
+		for i := 100; i < 125; i++ {
+			l.Set(i, i)
+		}
+
+		recent := 0
+		for i := 50; i < 100; i++ {
+			if l.Contains(i) {
+				recent++
+			}
+		}
+		if recent < 40 {
+			t.Errorf("Expected most recently read items to survive; Actual = %v of 50", recent)
+		}
+		if !reflect.DeepEqual(100, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 100, l.Len())
+		}
+	})
+
+	t.Run("should update, delete and resize", func(t *testing.T) {
+		l := NewSampled[int, string](4, 0)
+		l.Set(1, "one")
+		l.Set(2, "two")
+		l.Set(3, "three")
+		l.Set(1, "uno")
+
+		value, _ := l.Peek(1)
+		if !reflect.DeepEqual("uno", value) {
+			t.Errorf("Expected %v; Actual = %v", "uno", value)
+		}
+
+		if !l.Del(2) || l.Del(2) {
+			t.Errorf("Expected key 2 to be deleted once")
+		}
+		if !reflect.DeepEqual([]string{"uno", "three"}, l.Values()) {
+			t.Errorf("Expected %v; Actual = %v", []string{"uno", "three"}, l.Values())
+		}
+
+		l.Resize(1)
+		if !reflect.DeepEqual([]int{1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{1}, l.Keys())
+		}
+
+		l.Purge()
+		if !reflect.DeepEqual(0, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 0, l.Len())
+		}
+	})
+
+	t.Run("should be safe for concurrent use", func(t *testing.T) {
+		l := NewSampled[int, int](100, DefaultSampleSize)
+
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range 1000 {
+					l.Set(i*1000+j, j)
+					l.Get(i*1000 + j/2)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if !reflect.DeepEqual(100, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 100, l.Len())
+		}
+	})
+}
@@ -2,11 +2,19 @@ package lru
 
 import "time"
 
+// DefaultCleanupInterval is how often the cleaner looks for expired items
+// unless another interval is configured with WithCleanupInterval.
+const DefaultCleanupInterval = 5 * time.Second
+
 // startCleaner starts a background goroutine to clean expired items from the LRU cache.
 // If the cache was initialized with expiry support, this function will periodically check
 // for items with expired TTL (Time To Live) and remove them from the cache.
+// Items are kept in a min-heap ordered by expiry time, so each run only visits the items that are due.
+// Items stored without a TTL never expire.
 // The cleaner runs asynchronously and is meant to be started once when the cache is created.
 //
+// The cleaner exits once the cache is closed.
+//
 // It is safe to call this function even if the cache was not initialized with expiry support.
 // In that case, this function will have no effect.
 func (l *lru[K, V]) startCleaner() {
@@ -14,20 +22,29 @@ func (l *lru[K, V]) startCleaner() {
 		return
 	}
 
+	l.done = make(chan struct{})
+
+	var clock Clock = systemClock{}
+	if l.clock != nil {
+		clock = l.clock
+	}
+
+	// the ticker is created before the cleaner starts, so it counts from the creation of the cache
+	ticker := clock.NewTicker(l.cleanupInterval)
+
 	go func() {
-		ticker := time.NewTicker(5 * time.Second)
 		defer ticker.Stop()
 
-		for range ticker.C {
-			l.Mutex.Lock()
-
-			for h := l.head; h != nil; h = h.next {
-				if h.ttl.Before(time.Now()) {
-					l.del(h.key)
-				}
+		for {
+			select {
+			case <-l.done:
+				return
+			case <-ticker.C():
 			}
 
-			l.Mutex.Unlock()
+			l.locker.Lock()
+			l.removeExpired()
+			l.locker.Unlock()
 		}
 	}()
 }
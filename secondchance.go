@@ -0,0 +1,294 @@
+package lru
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// secondChanceSlot is a slot of the ring of a second-chance cache.
+type secondChanceSlot[K comparable, V any] struct {
+	key        K           // Key associated with the cache item.
+	value      V           // Value associated with the cache item.
+	used       bool        // Flag set while the slot holds an item.
+	referenced atomic.Bool // Reference bit set when the item is read, cleared when the hand passes over it.
+}
+
+// secondChance represents a CLOCK, or second-chance, cache approximating LRU.
+// Items live in a ring of slots swept by a hand. Reads only set the reference bit of the item,
+// under the read lock, so they never reorder anything. To make room, the hand clears the reference bits
+// it passes over and evicts the first item which was not referenced since the previous sweep.
+type secondChance[K comparable, V any] struct {
+	cache        map[K]int                // Index of the slot of every cached item.
+	slots        []secondChanceSlot[K, V] // Ring of slots swept by the hand.
+	free         []int                    // Indexes of the slots emptied by Del.
+	hand         int                      // Index of the next slot considered for eviction.
+	size         int                      // Maximum number of items the cache can hold.
+	hits         atomic.Uint64            // Number of lookups which found the key.
+	misses       atomic.Uint64            // Number of lookups which did not find the key.
+	stats        Stats                    // Usage counters of the cache other than hits and misses.
+	sync.RWMutex                          // Lock for concurrent access, read-locked by lookups.
+}
+
+// Contains checks if the provided key is present in the second-chance cache.
+// The function does not affect the cache's state or modify any data.
+func (s *secondChance[K, V]) Contains(key K) bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	_, ok := s.cache[key]
+	return ok
+}
+
+// Set adds or updates a key-value pair in the second-chance cache.
+// Updating an existing key counts as an access and sets its reference bit.
+// If the cache is full, the hand sweeps the ring to evict an item which was not referenced recently.
+func (s *secondChance[K, V]) Set(key K, value V) {
+	s.Lock()
+	defer s.Unlock()
+
+	if i, ok := s.cache[key]; ok {
+		s.slots[i].value = value
+		s.slots[i].referenced.Store(true)
+		return
+	}
+
+	if s.size < 0 {
+		return
+	}
+
+	var i int
+	switch {
+	case s.size != Unbounded && len(s.cache) >= s.size:
+		i = s.evict()
+	case len(s.free) > 0:
+		i = s.free[len(s.free)-1]
+		s.free = s.free[:len(s.free)-1]
+	default:
+		s.slots = append(s.slots, secondChanceSlot[K, V]{})
+		i = len(s.slots) - 1
+	}
+
+	s.slots[i].key, s.slots[i].value, s.slots[i].used = key, value, true
+	s.slots[i].referenced.Store(false)
+	s.cache[key] = i
+}
+
+// Get retrieves the value associated with the provided key from the second-chance cache,
+// setting its reference bit so the next sweep of the hand spares it.
+// Only the read lock is held, so concurrent reads do not contend with each other.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (s *secondChance[K, V]) Get(key K) (V, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	i, ok := s.cache[key]
+	if !ok {
+		s.misses.Add(1)
+
+		var emptyVal V
+		return emptyVal, false
+	}
+
+	s.hits.Add(1)
+	if !s.slots[i].referenced.Load() {
+		s.slots[i].referenced.Store(true)
+	}
+
+	return s.slots[i].value, true
+}
+
+// Peek retrieves the value associated with the provided key from the second-chance cache
+// without setting its reference bit.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (s *secondChance[K, V]) Peek(key K) (V, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if i, ok := s.cache[key]; ok {
+		return s.slots[i].value, true
+	}
+
+	var emptyVal V
+	return emptyVal, false
+}
+
+// Del removes the key-value pair associated with the provided key from the second-chance cache.
+// If the key is found and the removal is successful, the function returns true.
+// If the key is not found, it returns false.
+func (s *secondChance[K, V]) Del(key K) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	i, ok := s.cache[key]
+	if !ok {
+		return false
+	}
+
+	s.clear(i)
+	s.free = append(s.free, i)
+
+	return true
+}
+
+// Len returns the number of items currently stored in the second-chance cache.
+func (s *secondChance[K, V]) Len() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return len(s.cache)
+}
+
+// Cap returns the maximum number of items the second-chance cache can hold.
+func (s *secondChance[K, V]) Cap() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.size
+}
+
+// Purge removes all key-value pairs from the second-chance cache, leaving it empty.
+func (s *secondChance[K, V]) Purge() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.cache = map[K]int{}
+	s.slots = nil
+	s.free = nil
+	s.hand = 0
+}
+
+// Resize changes the maximum number of items the second-chance cache can hold.
+// If the new size is smaller than the current number of items,
+// the hand sweeps the ring to evict items until the cache fits, then the ring is compacted.
+func (s *secondChance[K, V]) Resize(size int) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.size = size
+	if size == Unbounded || len(s.cache) <= size {
+		return
+	}
+
+	for len(s.cache) > max(size, 0) {
+		s.free = append(s.free, s.evict())
+	}
+
+	slots := make([]secondChanceSlot[K, V], 0, len(s.cache))
+	for _, i := range s.order() {
+		slot := &s.slots[i]
+		s.cache[slot.key] = len(slots)
+		slots = append(slots, secondChanceSlot[K, V]{key: slot.key, value: slot.value, used: true})
+		slots[len(slots)-1].referenced.Store(slot.referenced.Load())
+	}
+	s.slots = slots
+	s.free = nil
+	s.hand = 0
+}
+
+// Keys returns a snapshot of the keys in the second-chance cache,
+// ordered from the slot behind the hand backwards, which approximates the order
+// from the most recently inserted item to the least recently inserted one.
+func (s *secondChance[K, V]) Keys() []K {
+	keys, _ := s.items()
+	return keys
+}
+
+// Values returns a snapshot of the values in the second-chance cache, in the same order as Keys.
+func (s *secondChance[K, V]) Values() []V {
+	_, values := s.items()
+	return values
+}
+
+// All returns an iterator over the key-value pairs in the second-chance cache, in the same order as Keys.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (s *secondChance[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		forward(s.items())(yield)
+	}
+}
+
+// Backward returns an iterator over the key-value pairs in the second-chance cache, in the reverse order of Keys.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (s *secondChance[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		backward(s.items())(yield)
+	}
+}
+
+// items returns a snapshot of the keys and values in the second-chance cache, in the same order as Keys.
+func (s *secondChance[K, V]) items() ([]K, []V) {
+	s.RLock()
+	defer s.RUnlock()
+
+	keys := make([]K, 0, len(s.cache))
+	values := make([]V, 0, len(s.cache))
+	for _, i := range s.order() {
+		keys = append(keys, s.slots[i].key)
+		values = append(values, s.slots[i].value)
+	}
+
+	return keys, values
+}
+
+// Stats returns a snapshot of the usage counters of the second-chance cache.
+func (s *secondChance[K, V]) Stats() Stats {
+	s.RLock()
+	defer s.RUnlock()
+
+	return Stats{
+		Hits:      s.hits.Load(),
+		Misses:    s.misses.Load(),
+		Evictions: s.stats.Evictions,
+	}
+}
+
+// Close is a no-op, the second-chance cache owns no background goroutines.
+func (s *secondChance[K, V]) Close() {}
+
+// order returns the indexes of the used slots, from the slot behind the hand backwards.
+func (s *secondChance[K, V]) order() []int {
+	out := make([]int, 0, len(s.cache))
+	for n := 1; n <= len(s.slots); n++ {
+		i := (s.hand - n + len(s.slots)) % len(s.slots)
+		if s.slots[i].used {
+			out = append(out, i)
+		}
+	}
+
+	return out
+}
+
+// evict sweeps the hand over the ring, clearing the reference bits it passes over,
+// until it reaches an item which was not referenced since the previous sweep.
+// That item is evicted and the index of its emptied slot is returned.
+// The hand moves past the emptied slot, so the item stored there next is the last one considered.
+func (s *secondChance[K, V]) evict() int {
+	for {
+		i := s.hand
+		s.hand = (s.hand + 1) % len(s.slots)
+
+		slot := &s.slots[i]
+		if !slot.used {
+			continue
+		}
+		if slot.referenced.Swap(false) {
+			continue
+		}
+
+		s.stats.Evictions++
+		s.clear(i)
+
+		return i
+	}
+}
+
+// clear empties the slot with the provided index, forgetting its item.
+func (s *secondChance[K, V]) clear(i int) {
+	delete(s.cache, s.slots[i].key)
+
+	var emptyKey K
+	var emptyVal V
+	s.slots[i].key, s.slots[i].value, s.slots[i].used = emptyKey, emptyVal, false
+	s.slots[i].referenced.Store(false)
+}
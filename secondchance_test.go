@@ -0,0 +1,130 @@
+package lru
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSecondChance(t *testing.T) {
+	t.Run("should evict items which were not read", func(t *testing.T) {
+		l := NewSecondChance[int, int](3)
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+		l.Get(1)
+
+		l.Set(4, 4)
+
+		if l.Contains(2) {
+			t.Errorf("Expected key 2 to be evicted")
+		}
+		for _, key := range []int{1, 3, 4} {
+			if !l.Contains(key) {
+				t.Errorf("Expected key %v to be present", key)
+			}
+		}
+		if !reflect.DeepEqual(uint64(1), l.Stats().Evictions) {
+			t.Errorf("Expected %v; Actual = %v", 1, l.Stats().Evictions)
+		}
+	})
+
+	t.Run("should give every item a second chance", func(t *testing.T) {
+		l := NewSecondChance[int, int](2)
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Get(1)
+		l.Get(2)
+
+		l.Set(3, 3)
+
+		if l.Contains(1) || !l.Contains(2) || !l.Contains(3) {
+			t.Errorf("Expected key 1 to be evicted; Actual = %v", l.Keys())
+		}
+	})
+
+	t.Run("should reuse the slots of deleted items", func(t *testing.T) {
+		l := NewSecondChance[int, int](2)
+		l.Set(1, 1)
+		l.Set(2, 2)
+
+		if !l.Del(1) || l.Del(1) {
+			t.Errorf("Expected key 1 to be deleted once")
+		}
+		l.Set(3, 3)
+
+		if !l.Contains(2) || !l.Contains(3) {
+			t.Errorf("Expected keys 2 and 3 to be present; Actual = %v", l.Keys())
+		}
+		if !reflect.DeepEqual(uint64(0), l.Stats().Evictions) {
+			t.Errorf("Expected %v; Actual = %v", 0, l.Stats().Evictions)
+		}
+	})
+
+	t.Run("should update and peek without referencing", func(t *testing.T) {
+		l := NewSecondChance[int, string](2)
+		l.Set(1, "one")
+		l.Set(1, "uno")
+		l.Set(2, "two")
+
+		value, _ := l.Peek(2)
+		if !reflect.DeepEqual("two", value) {
+			t.Errorf("Expected %v; Actual = %v", "two", value)
+		}
+
+		l.Set(3, "three")
+		if l.Contains(2) {
+			t.Errorf("Expected key 2 to be evicted")
+		}
+
+		value, _ = l.Get(1)
+		if !reflect.DeepEqual("uno", value) {
+			t.Errorf("Expected %v; Actual = %v", "uno", value)
+		}
+	})
+
+	t.Run("should shrink on resize", func(t *testing.T) {
+		l := NewSecondChance[int, int](4)
+		for i := 1; i <= 4; i++ {
+			l.Set(i, i)
+		}
+		l.Get(3)
+
+		l.Resize(2)
+
+		if !reflect.DeepEqual(2, l.Len()) || !l.Contains(3) {
+			t.Errorf("Expected key 3 to survive; Actual = %v", l.Keys())
+		}
+
+		l.Set(5, 5)
+		if !reflect.DeepEqual(2, l.Len()) || !l.Contains(5) {
+			t.Errorf("Expected key 5 to be stored; Actual = %v", l.Keys())
+		}
+	})
+
+	t.Run("should purge and be safe for concurrent use", func(t *testing.T) {
+		l := NewSecondChance[int, int](100)
+
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range 1000 {
+					l.Set(i*1000+j, j)
+					l.Get(i*1000 + j/2)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if !reflect.DeepEqual(100, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 100, l.Len())
+		}
+
+		l.Purge()
+		if !reflect.DeepEqual(0, l.Len()) || !reflect.DeepEqual(0, len(l.Keys())) {
+			t.Errorf("Expected an empty cache")
+		}
+	})
+}
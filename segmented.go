@@ -0,0 +1,268 @@
+package lru
+
+import (
+	"iter"
+	"sync"
+	"time"
+)
+
+// DefaultProtectedRatio is the share of a segmented LRU cache reserved for items accessed more than once.
+const DefaultProtectedRatio = 0.80
+
+// segmented represents a segmented LRU (SLRU) cache.
+// New items land in a probationary segment and are promoted to a protected segment when they are accessed again.
+// When the protected segment outgrows its share of the size, its least recently used item is demoted back
+// to the head of the probationary segment, and evictions always come from the probationary segment first,
+// so a single scan over many keys cannot flush the frequently used items.
+type segmented[K comparable, V any] struct {
+	size           int        // Maximum number of items the cache can hold.
+	protectedRatio float64    // Share of the size reserved for the protected segment.
+	protectedSize  int        // Maximum number of items in the protected segment.
+	probation      *lru[K, V] // Probationary LRU segment of items seen once.
+	protected      *lru[K, V] // Protected LRU segment of items seen more than once.
+	stats          Stats      // Usage counters of the cache.
+	sync.Mutex                // Mutex for concurrent access.
+}
+
+// Contains checks if the provided key is present in the segmented cache.
+// The function does not affect the cache's state or modify any data.
+func (s *segmented[K, V]) Contains(key K) bool {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.protected.Contains(key) || s.probation.Contains(key)
+}
+
+// Set adds or updates a key-value pair in the segmented cache.
+// Updating a key that is already cached counts as an access and promotes it to the protected segment.
+// New keys enter the probationary segment.
+func (s *segmented[K, V]) Set(key K, value V) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if c, ok := s.protected.cache[key]; ok {
+		c.value = value
+		s.protected.moveToFront(c)
+		return
+	}
+
+	if s.probation.Contains(key) {
+		s.probation.del(key)
+		s.promote(key, value)
+		return
+	}
+
+	if s.size <= 0 {
+		return
+	}
+
+	var expiry time.Time
+	s.ensureSpace()
+	s.probation.set(key, value, expiry)
+}
+
+// Get retrieves the value associated with the provided key from the segmented cache.
+// A hit in the probationary segment promotes the item to the protected segment,
+// a hit in the protected segment moves it to the head of that segment.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (s *segmented[K, V]) Get(key K) (V, bool) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if c, ok := s.protected.cache[key]; ok {
+		s.stats.Hits++
+		s.protected.moveToFront(c)
+		return c.value, true
+	}
+
+	if c, ok := s.probation.cache[key]; ok {
+		value := c.value
+		s.stats.Hits++
+		s.probation.del(key)
+		s.promote(key, value)
+		return value, true
+	}
+
+	s.stats.Misses++
+
+	var emptyVal V
+	return emptyVal, false
+}
+
+// Peek retrieves the value associated with the provided key from the segmented cache
+// without promoting it.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (s *segmented[K, V]) Peek(key K) (V, bool) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if c, ok := s.protected.cache[key]; ok {
+		return c.value, true
+	}
+
+	if c, ok := s.probation.cache[key]; ok {
+		return c.value, true
+	}
+
+	var emptyVal V
+	return emptyVal, false
+}
+
+// Del removes the key-value pair associated with the provided key from the segmented cache.
+// If the key is found and the removal is successful, the function returns true.
+// If the key is not found, it returns false.
+func (s *segmented[K, V]) Del(key K) bool {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.protected.del(key) || s.probation.del(key)
+}
+
+// Len returns the number of items currently stored in the segmented cache.
+func (s *segmented[K, V]) Len() int {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.probation.length + s.protected.length
+}
+
+// Cap returns the maximum number of items the segmented cache can hold.
+func (s *segmented[K, V]) Cap() int {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.size
+}
+
+// Purge removes all key-value pairs from the segmented cache.
+func (s *segmented[K, V]) Purge() {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	s.probation.purge()
+	s.protected.purge()
+}
+
+// Resize changes the maximum number of items the segmented cache can hold,
+// keeping the configured protected ratio.
+// If the new size is smaller than the current number of items,
+// items are evicted following the SLRU policy until the cache fits.
+func (s *segmented[K, V]) Resize(size int) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	s.resize(size)
+	s.demote()
+	for s.probation.length+s.protected.length > max(s.size, 0) {
+		s.evict()
+	}
+}
+
+// Keys returns a snapshot of the keys in the segmented cache.
+// Keys of the protected segment come first, followed by the keys of the probationary segment,
+// each ordered from the most recently used to the least recently used.
+func (s *segmented[K, V]) Keys() []K {
+	keys, _ := s.items()
+	return keys
+}
+
+// Values returns a snapshot of the values in the segmented cache, in the same order as Keys.
+func (s *segmented[K, V]) Values() []V {
+	_, values := s.items()
+	return values
+}
+
+// All returns an iterator over the key-value pairs in the segmented cache, in the same order as Keys.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (s *segmented[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		forward(s.items())(yield)
+	}
+}
+
+// Backward returns an iterator over the key-value pairs in the segmented cache, in the reverse order of Keys.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (s *segmented[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		backward(s.items())(yield)
+	}
+}
+
+// items returns a snapshot of the keys and values in the segmented cache, in the same order as Keys.
+func (s *segmented[K, V]) items() ([]K, []V) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	keys := make([]K, 0, s.probation.length+s.protected.length)
+	values := make([]V, 0, s.probation.length+s.protected.length)
+	for _, l := range []*lru[K, V]{s.protected, s.probation} {
+		for h := l.front(); h != nil; h = l.next(h) {
+			keys = append(keys, h.key)
+			values = append(values, h.value)
+		}
+	}
+
+	return keys, values
+}
+
+// Stats returns a snapshot of the usage counters of the segmented cache.
+func (s *segmented[K, V]) Stats() Stats {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.stats
+}
+
+// Close is a no-op, the segmented cache owns no background goroutines.
+func (s *segmented[K, V]) Close() {}
+
+// resize updates the total size and the derived size of the protected segment.
+// The segments themselves are unbounded, the segmented cache evicts on their behalf.
+func (s *segmented[K, V]) resize(size int) {
+	s.size = size
+	s.protectedSize = int(float64(size) * s.protectedRatio)
+	s.probation.size = Unbounded
+	s.protected.size = Unbounded
+}
+
+// promote stores the provided key-value pair at the head of the protected segment,
+// demoting the least recently used items of the protected segment which no longer fit.
+func (s *segmented[K, V]) promote(key K, value V) {
+	var expiry time.Time
+	s.protected.set(key, value, expiry)
+	s.demote()
+}
+
+// demote moves the least recently used items of the protected segment to the head of the probationary segment
+// until the protected segment fits its size.
+func (s *segmented[K, V]) demote() {
+	var expiry time.Time
+	for s.protected.length > s.protectedSize {
+		c := s.protected.back()
+		key, value := c.key, c.value
+		s.protected.del(key)
+		s.probation.set(key, value, expiry)
+	}
+}
+
+// ensureSpace evicts one item when the cache is full.
+func (s *segmented[K, V]) ensureSpace() {
+	if s.probation.length+s.protected.length < s.size {
+		return
+	}
+
+	s.evict()
+}
+
+// evict removes the least recently used item of the probationary segment,
+// or of the protected segment when the probationary one is empty.
+func (s *segmented[K, V]) evict() {
+	s.stats.Evictions++
+
+	if s.probation.length > 0 {
+		s.probation.del(s.probation.back().key)
+		return
+	}
+
+	s.protected.del(s.protected.back().key)
+}
@@ -0,0 +1,101 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSegmented(t *testing.T) {
+	t.Run("should promote items accessed twice", func(t *testing.T) {
+		l := NewSegmented[int, string](4, DefaultProtectedRatio)
+
+		l.Set(1, "one")
+		l.Set(2, "two")
+
+		value, ok := l.Get(1)
+		if !ok || !reflect.DeepEqual("one", value) {
+			t.Errorf("Expected %v; Actual = %v", "one", value)
+		}
+
+		s := l.(*segmented[int, string])
+		if !s.protected.Contains(1) || !s.probation.Contains(2) {
+			t.Errorf("Expected key 1 to be protected and key 2 to be probationary")
+		}
+	})
+
+	t.Run("should resist scans", func(t *testing.T) {
+		l := NewSegmented[int, int](4, 0.5)
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Get(1)
+		l.Get(2)
+
+		for i := 10; i < 20; i++ {
+			l.Set(i, i)
+		}
+
+		expected := []int{2, 1, 19, 18}
+		if !reflect.DeepEqual(expected, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+		}
+		if !reflect.DeepEqual(uint64(8), l.Stats().Evictions) {
+			t.Errorf("Expected %v; Actual = %v", 8, l.Stats().Evictions)
+		}
+	})
+
+	t.Run("should demote the least recently used protected item", func(t *testing.T) {
+		l := NewSegmented[int, int](4, 0.5)
+
+		for i := 1; i <= 3; i++ {
+			l.Set(i, i)
+			l.Get(i)
+		}
+
+		expected := []int{3, 2, 1}
+		if !reflect.DeepEqual(expected, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+		}
+
+		s := l.(*segmented[int, int])
+		if !s.probation.Contains(1) || !reflect.DeepEqual(2, s.protected.Len()) {
+			t.Errorf("Expected key 1 to be demoted")
+		}
+	})
+
+	t.Run("should shrink on resize", func(t *testing.T) {
+		l := NewSegmented[int, int](4, 0.5)
+
+		for i := 1; i <= 4; i++ {
+			l.Set(i, i)
+		}
+		l.Get(1)
+		l.Get(2)
+
+		l.Resize(2)
+
+		expected := []int{2, 1}
+		if !reflect.DeepEqual(expected, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+		}
+		if !reflect.DeepEqual(2, l.Cap()) {
+			t.Errorf("Expected %v; Actual = %v", 2, l.Cap())
+		}
+	})
+
+	t.Run("should update and delete items", func(t *testing.T) {
+		l := NewSegmented[int, int](4, DefaultProtectedRatio)
+
+		l.Set(1, 1)
+		l.Set(1, 10)
+
+		value, _ := l.Peek(1)
+		if !reflect.DeepEqual(10, value) {
+			t.Errorf("Expected %v; Actual = %v", 10, value)
+		}
+
+		if !l.Del(1) || l.Contains(1) || !reflect.DeepEqual(0, l.Len()) {
+			t.Errorf("Expected key 1 to be deleted")
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package lru
+
+import (
+	"fmt"
+	"time"
+)
+
+// Serialized represents a Least Recently Used (LRU) cache storing its values encoded with a Codec.
+// Set encodes the value and Get decodes a fresh copy, so callers never share a value with the cache,
+// and the cost of every item is the size of its encoding, so the cache can be bounded by bytes.
+type Serialized[K comparable, V any] struct {
+	cache *lru[K, []byte] // Cache holding the encoded values.
+	codec Codec           // Codec encoding the values.
+}
+
+// NewSerialized creates a new instance of a Least Recently Used (LRU) cache with the specified size,
+// storing its values encoded with the provided codec, or with JSONCodec if it is nil.
+// The cache is also bounded by the total size of the encoded values, unless maxBytes is zero;
+// a WithSizer option replaces the size of the encoding as the cost of the items.
+// Optional behaviour, such as a default TTL, can be configured by passing one or more Option values.
+//
+// Example usage:
+//
+//	users := lru.NewSerialized[string, User](10000, 64<<20, lru.GobCodec{})
+//	defer users.Close()
+//	if err := users.Set(user.ID, user); err != nil {
+//		return err
+//	}
+func NewSerialized[K comparable, V any](size int, maxBytes int64, codec Codec, opts ...Option[K, []byte]) *Serialized[K, V] {
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+
+	out := &Serialized[K, V]{
+		cache: newLRU(size, true, opts),
+		codec: codec,
+	}
+	out.cache.maxCost = maxBytes
+	if out.cache.sizer == nil {
+		out.cache.sizer = func(data []byte) int64 {
+			return int64(len(data))
+		}
+	}
+
+	out.cache.startCleaner()
+	out.cache.startPersistence()
+	out.cache.startInvalidation()
+
+	return out
+}
+
+// Contains checks if the provided key is present in the serialized cache.
+func (s *Serialized[K, V]) Contains(key K) bool {
+	return s.cache.Contains(key)
+}
+
+// Set encodes the provided value and stores it under the provided key like the Set of an LRU cache.
+// If the value cannot be encoded, the error of the codec is returned and the cache is left untouched.
+// ErrFull is returned if the value was not stored, such as when its encoding alone exceeds the byte budget.
+func (s *Serialized[K, V]) Set(key K, value V) error {
+	data, err := s.encode(value)
+	if err != nil {
+		return err
+	}
+
+	var expiry time.Time
+	return s.store(key, data, expiry)
+}
+
+// SetWithTTL encodes the provided value and stores it under the provided key like Set,
+// expiring it after the provided TTL. Like Set, it returns ErrFull if the value was not stored.
+func (s *Serialized[K, V]) SetWithTTL(key K, value V, ttl time.Duration) error {
+	data, err := s.encode(value)
+	if err != nil {
+		return err
+	}
+
+	return s.store(key, data, s.cache.now().Add(ttl))
+}
+
+// Get retrieves and decodes the value associated with the provided key, promoting it like the Get of an LRU cache.
+// A miss is reported like GetE, as ErrExpired if the TTL of the item has passed or ErrNotFound otherwise.
+// If the stored value cannot be decoded, the error of the codec is returned.
+func (s *Serialized[K, V]) Get(key K) (V, error) {
+	data, err := s.cache.GetE(key)
+	if err != nil {
+		var emptyVal V
+		return emptyVal, err
+	}
+
+	return s.decode(data)
+}
+
+// Peek retrieves and decodes the value associated with the provided key like Get,
+// without updating the order of items in the cache. A miss is reported as ErrNotFound.
+func (s *Serialized[K, V]) Peek(key K) (V, error) {
+	data, ok := s.cache.Peek(key)
+	if !ok {
+		var emptyVal V
+		return emptyVal, ErrNotFound
+	}
+
+	return s.decode(data)
+}
+
+// Del removes the value associated with the provided key from the serialized cache.
+// It returns false if the key is not found.
+func (s *Serialized[K, V]) Del(key K) bool {
+	return s.cache.Del(key)
+}
+
+// Len returns the number of items currently stored in the serialized cache.
+func (s *Serialized[K, V]) Len() int {
+	return s.cache.Len()
+}
+
+// Bytes returns the total size of the encoded values currently stored in the serialized cache,
+// or their total cost when a WithSizer option is configured.
+func (s *Serialized[K, V]) Bytes() int64 {
+	return s.cache.Cost()
+}
+
+// Keys returns a snapshot of the keys in the serialized cache,
+// ordered from the most recently used to the least recently used.
+func (s *Serialized[K, V]) Keys() []K {
+	return s.cache.Keys()
+}
+
+// Purge removes all items from the serialized cache, leaving it empty.
+func (s *Serialized[K, V]) Purge() {
+	s.cache.Purge()
+}
+
+// Stats returns a snapshot of the usage counters of the serialized cache.
+func (s *Serialized[K, V]) Stats() Stats {
+	return s.cache.Stats()
+}
+
+// Close stops any background goroutine owned by the serialized cache, such as the expiry cleaner.
+// It is safe to call Close more than once; the cache must not be used after Close.
+func (s *Serialized[K, V]) Close() {
+	s.cache.Close()
+}
+
+// store stores the provided encoding under the provided key, expiring it at the provided time unless it is zero,
+// and returns ErrFull if the cache did not keep it.
+func (s *Serialized[K, V]) store(key K, data []byte, expiry time.Time) error {
+	key = s.cache.canonical(key)
+
+	s.cache.locker.Lock()
+	defer s.cache.locker.Unlock()
+
+	if !s.cache.set(key, data, expiry) {
+		return ErrFull
+	}
+
+	return nil
+}
+
+// encode returns the encoding of the provided value.
+func (s *Serialized[K, V]) encode(value V) ([]byte, error) {
+	data, err := s.codec.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("lru: encode value: %w", err)
+	}
+
+	return data, nil
+}
+
+// decode returns a new value decoded from the provided encoding.
+func (s *Serialized[K, V]) decode(data []byte) (V, error) {
+	var value V
+	if err := s.codec.Unmarshal(data, &value); err != nil {
+		var emptyVal V
+		return emptyVal, fmt.Errorf("lru: decode value: %w", err)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,96 @@
+package lru
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type profile struct {
+	Name string
+	Tags []string
+}
+
+func TestSerialized(t *testing.T) {
+	t.Run("should return copies of the stored values", func(t *testing.T) {
+		s := NewSerialized[string, profile](2, 0, nil)
+		defer s.Close()
+
+		tags := []string{"admin"}
+		if err := s.Set("alice", profile{Name: "alice", Tags: tags}); err != nil {
+			t.Fatalf("Expected no error; Actual = %v", err)
+		}
+		tags[0] = "guest"
+
+		value, err := s.Get("alice")
+		if err != nil || !reflect.DeepEqual(profile{Name: "alice", Tags: []string{"admin"}}, value) {
+			t.Errorf("Expected %v; Actual = %v %v", "admin", value, err)
+		}
+
+		value.Tags[0] = "owner"
+		if again, _ := s.Peek("alice"); !reflect.DeepEqual([]string{"admin"}, again.Tags) {
+			t.Errorf("Expected %v; Actual = %v", []string{"admin"}, again.Tags)
+		}
+	})
+
+	t.Run("should report misses and codec errors", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		s := NewSerialized[string, any](2, 0, GobCodec{}, WithClock[string, []byte](clock))
+		defer s.Close()
+
+		if _, err := s.Get("missing"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected %v; Actual = %v", ErrNotFound, err)
+		}
+
+		s.SetWithTTL("key", 1, time.Minute)
+		clock.Advance(time.Hour)
+		if _, err := s.Get("key"); !errors.Is(err, ErrExpired) {
+			t.Errorf("Expected %v; Actual = %v", ErrExpired, err)
+		}
+
+		if err := s.Set("func", func() {}); err == nil || s.Contains("func") {
+			t.Errorf("Expected an encoding error; Actual = %v", err)
+		}
+	})
+
+	t.Run("should bound the cache by the size of the encoded values", func(t *testing.T) {
+		s := NewSerialized[int, string](Unbounded, 10, nil)
+		defer s.Close()
+
+		s.Set(1, "abc") // "abc" with its quotes
+		s.Set(2, "def")
+		s.Set(3, "ghi")
+
+		if !reflect.DeepEqual([]int{3, 2}, s.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 2}, s.Keys())
+		}
+		if !reflect.DeepEqual(int64(10), s.Bytes()) {
+			t.Errorf("Expected %v; Actual = %v", 10, s.Bytes())
+		}
+
+		s.Del(3)
+		if !reflect.DeepEqual(int64(5), s.Bytes()) {
+			t.Errorf("Expected %v; Actual = %v", 5, s.Bytes())
+		}
+	})
+
+	t.Run("should report values larger than the byte budget", func(t *testing.T) {
+		s := NewSerialized[int, string](Unbounded, 10, nil)
+		defer s.Close()
+
+		if err := s.Set(1, "abcdefghij"); !errors.Is(err, ErrFull) {
+			t.Errorf("Expected %v; Actual = %v", ErrFull, err)
+		}
+		if err := s.SetWithTTL(2, "abcdefghij", time.Minute); !errors.Is(err, ErrFull) {
+			t.Errorf("Expected %v; Actual = %v", ErrFull, err)
+		}
+		if err := s.Set(3, "abc"); err != nil {
+			t.Errorf("Expected no error; Actual = %v", err)
+		}
+
+		if !reflect.DeepEqual([]int{3}, s.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3}, s.Keys())
+		}
+	})
+}
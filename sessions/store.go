@@ -0,0 +1,186 @@
+// Package sessions keeps HTTP sessions in memory, in an LRU cache expiring sessions once they have been idle for a while,
+// which suits small services running a single instance.
+package sessions
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/vhndaree/lru"
+)
+
+// DefaultCookieName is the name of the cookie carrying the session ID, unless another name is configured with WithCookie.
+const DefaultCookieName = "session"
+
+// ErrNoSession is returned by Get when the request carries no session cookie, or its session expired or was destroyed.
+var ErrNoSession = errors.New("sessions: no session")
+
+// Codec serializes the values of sessions, which are stored encoded so callers never share them with the store.
+type Codec = lru.Codec
+
+// JSONCodec is a Codec encoding values with encoding/json. It is the default Codec of a Store.
+type JSONCodec = lru.JSONCodec
+
+// GobCodec is a Codec encoding values with encoding/gob, for values JSON cannot encode.
+type GobCodec = lru.GobCodec
+
+// Session is an HTTP session along with its values.
+type Session[T any] struct {
+	ID     string // Random ID of the session carried by the cookie, empty until the session is first saved.
+	Values T      // Values of the session.
+}
+
+// Store keeps sessions whose values have type T in memory.
+type Store[T any] struct {
+	cache  lru.LRUWithExpiry[string, []byte] // Encoded values by session ID.
+	codec  Codec                             // Codec serializing the values.
+	cookie http.Cookie                       // Template of the session cookie.
+}
+
+// Option configures optional behaviour of a Store at construction time.
+type Option func(*options)
+
+// options holds the optional behaviour of a Store.
+type options struct {
+	codec  Codec       // Codec serializing the values.
+	cookie http.Cookie // Template of the session cookie.
+	clock  lru.Clock   // Clock expiring the sessions, nil for the system clock.
+}
+
+// WithCodec configures the Codec serializing the values of the sessions instead of JSONCodec.
+func WithCodec(codec Codec) Option {
+	return func(o *options) {
+		o.codec = codec
+	}
+}
+
+// WithCookie configures the session cookie from the provided template, such as its name, path, domain
+// or SameSite mode. The value and the expiry of the template are ignored. An empty name keeps DefaultCookieName.
+func WithCookie(cookie http.Cookie) Option {
+	return func(o *options) {
+		if cookie.Name == "" {
+			cookie.Name = DefaultCookieName
+		}
+		o.cookie = cookie
+	}
+}
+
+// WithClock configures the clock expiring the sessions, such as an lru.FakeClock in tests, instead of the system clock.
+func WithClock(clock lru.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// New creates a Store keeping up to size sessions, each of which expires once it has been idle for the provided TTL:
+// every Get or Save of a session pushes its expiry back. When the store is full, the least recently used sessions are dropped.
+// The session cookie is HTTP-only, secure and scoped to the whole site unless configured otherwise with WithCookie,
+// and lasts as long as the browser session, the TTL being enforced by the store.
+// Optional behaviour can be configured by passing one or more Option values.
+// Call Close once the store is no longer needed to stop its expiry cleaner.
+//
+// Example usage:
+//
+//	store := sessions.New[User](10000, 30*time.Minute)
+//	defer store.Close()
+func New[T any](size int, ttl time.Duration, opts ...Option) *Store[T] {
+	o := options{
+		codec:  JSONCodec{},
+		cookie: http.Cookie{Name: DefaultCookieName, Path: "/", HttpOnly: true, Secure: true, SameSite: http.SameSiteLaxMode},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cacheOpts := []lru.Option[string, []byte]{lru.WithSlidingTTL[string, []byte](ttl)}
+	if o.clock != nil {
+		cacheOpts = append(cacheOpts, lru.WithClock[string, []byte](o.clock))
+	}
+
+	return &Store[T]{
+		cache:  lru.NewWithExpiry[string, []byte](size, cacheOpts...),
+		codec:  o.codec,
+		cookie: o.cookie,
+	}
+}
+
+// Get returns the session of the provided request, identified by its session cookie.
+// It returns ErrNoSession if the request carries no session cookie or its session expired,
+// along with an empty session whose first Save creates a new session.
+func (s *Store[T]) Get(r *http.Request) (Session[T], error) {
+	cookie, err := r.Cookie(s.cookie.Name)
+	if err != nil {
+		return Session[T]{}, ErrNoSession
+	}
+
+	data, ok := s.cache.Get(cookie.Value)
+	if !ok {
+		return Session[T]{}, ErrNoSession
+	}
+
+	out := Session[T]{ID: cookie.Value}
+	if err := s.codec.Unmarshal(data, &out.Values); err != nil {
+		return Session[T]{}, fmt.Errorf("sessions: decode session: %w", err)
+	}
+
+	return out, nil
+}
+
+// Save stores the values of the provided session and sets the session cookie on the response.
+// A session without an ID gets a new random one, which is returned; save sessions before writing the response body.
+func (s *Store[T]) Save(w http.ResponseWriter, session Session[T]) (string, error) {
+	data, err := s.codec.Marshal(session.Values)
+	if err != nil {
+		return "", fmt.Errorf("sessions: encode session: %w", err)
+	}
+
+	if session.ID == "" {
+		if session.ID, err = newID(); err != nil {
+			return "", err
+		}
+	}
+
+	s.cache.Set(session.ID, data)
+
+	cookie := s.cookie
+	cookie.Value = session.ID
+	http.SetCookie(w, &cookie)
+
+	return session.ID, nil
+}
+
+// Destroy removes the session of the provided request, if any, and expires the session cookie on the response,
+// such as when the user signs out.
+func (s *Store[T]) Destroy(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(s.cookie.Name); err == nil {
+		s.cache.Del(cookie.Value)
+	}
+
+	cookie := s.cookie
+	cookie.MaxAge = -1
+	http.SetCookie(w, &cookie)
+}
+
+// Len returns the number of sessions currently stored.
+func (s *Store[T]) Len() int {
+	return s.cache.Len()
+}
+
+// Close stops the expiry cleaner of the store. It is safe to call Close more than once.
+func (s *Store[T]) Close() {
+	s.cache.Close()
+}
+
+// newID returns a new random session ID.
+func newID() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("sessions: generate session ID: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,117 @@
+package sessions
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vhndaree/lru"
+)
+
+type user struct {
+	Name  string
+	Roles map[int]string
+}
+
+// request returns a request carrying the cookies set on the provided response.
+func request(w *httptest.ResponseRecorder) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, c := range w.Result().Cookies() {
+		r.AddCookie(c)
+	}
+
+	return r
+}
+
+func TestStore(t *testing.T) {
+	t.Run("should save and get sessions", func(t *testing.T) {
+		s := New[user](10, time.Hour)
+		defer s.Close()
+
+		if _, err := s.Get(httptest.NewRequest(http.MethodGet, "/", nil)); !errors.Is(err, ErrNoSession) {
+			t.Errorf("Expected %v; Actual = %v", ErrNoSession, err)
+		}
+
+		w := httptest.NewRecorder()
+		id, err := s.Save(w, Session[user]{Values: user{Name: "alice"}})
+		if err != nil || id == "" {
+			t.Fatalf("Expected a new session ID; Actual = %v %v", id, err)
+		}
+
+		cookie := w.Result().Cookies()[0]
+		if !reflect.DeepEqual([]any{DefaultCookieName, id, true, true}, []any{cookie.Name, cookie.Value, cookie.HttpOnly, cookie.Secure}) {
+			t.Errorf("Expected a secure session cookie; Actual = %v", cookie)
+		}
+
+		session, err := s.Get(request(w))
+		if err != nil || !reflect.DeepEqual(Session[user]{ID: id, Values: user{Name: "alice"}}, session) {
+			t.Errorf("Expected %v; Actual = %v %v", "alice", session, err)
+		}
+	})
+
+	t.Run("should not share values with callers", func(t *testing.T) {
+		s := New[user](10, time.Hour, WithCodec(GobCodec{}))
+		defer s.Close()
+
+		w := httptest.NewRecorder()
+		roles := map[int]string{1: "admin"}
+		s.Save(w, Session[user]{Values: user{Name: "alice", Roles: roles}})
+		roles[1] = "guest"
+
+		session, _ := s.Get(request(w))
+		session.Values.Roles[2] = "owner"
+
+		again, _ := s.Get(request(w))
+		if !reflect.DeepEqual(map[int]string{1: "admin"}, again.Values.Roles) {
+			t.Errorf("Expected %v; Actual = %v", map[int]string{1: "admin"}, again.Values.Roles)
+		}
+	})
+
+	t.Run("should expire idle sessions", func(t *testing.T) {
+		clock := lru.NewFakeClock(time.Now())
+		s := New[user](10, time.Minute, WithClock(clock), WithCookie(http.Cookie{Name: "sid", Path: "/app"}))
+		defer s.Close()
+
+		w := httptest.NewRecorder()
+		s.Save(w, Session[user]{Values: user{Name: "alice"}})
+		if cookie := w.Result().Cookies()[0]; !reflect.DeepEqual([]string{"sid", "/app"}, []string{cookie.Name, cookie.Path}) {
+			t.Errorf("Expected %v; Actual = %v", []string{"sid", "/app"}, []string{cookie.Name, cookie.Path})
+		}
+
+		clock.Advance(50 * time.Second)
+		if _, err := s.Get(request(w)); err != nil {
+			t.Errorf("Expected the session to be found; Actual = %v", err)
+		}
+
+		clock.Advance(50 * time.Second)
+		if _, err := s.Get(request(w)); err != nil {
+			t.Errorf("Expected the session to be kept alive by use; Actual = %v", err)
+		}
+
+		clock.Advance(2 * time.Minute)
+		if _, err := s.Get(request(w)); !errors.Is(err, ErrNoSession) {
+			t.Errorf("Expected %v; Actual = %v", ErrNoSession, err)
+		}
+	})
+
+	t.Run("should destroy sessions", func(t *testing.T) {
+		s := New[user](10, time.Hour)
+		defer s.Close()
+
+		w := httptest.NewRecorder()
+		s.Save(w, Session[user]{Values: user{Name: "alice"}})
+
+		d := httptest.NewRecorder()
+		s.Destroy(d, request(w))
+
+		if cookie := d.Result().Cookies()[0]; cookie.MaxAge >= 0 {
+			t.Errorf("Expected the cookie to be expired; Actual = %v", cookie)
+		}
+		if _, err := s.Get(request(w)); !errors.Is(err, ErrNoSession) || !reflect.DeepEqual(0, s.Len()) {
+			t.Errorf("Expected %v; Actual = %v", ErrNoSession, err)
+		}
+	})
+}
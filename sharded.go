@@ -0,0 +1,260 @@
+package lru
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// sharded represents an LRU cache split into independent shards.
+// Every key is hashed to exactly one shard and each shard is guarded by its own lock,
+// so goroutines working on keys in different shards never contend with each other.
+type sharded[K comparable, V any] struct {
+	shards           []*lru[K, V]       // Independent LRU caches holding a subset of the keys.
+	hasher           Hasher[K]          // Hasher picking the shard of every key.
+	persistence      *persistence       // Background goroutine saving the cache, nil when persistence is disabled.
+	stopInvalidation context.CancelFunc // Cancels the subscription to the deletions of other instances.
+}
+
+// shard returns the shard responsible for the provided key.
+func (s *sharded[K, V]) shard(key K) *lru[K, V] {
+	return s.shards[s.index(key)]
+}
+
+// index returns the index of the shard responsible for the provided key.
+func (s *sharded[K, V]) index(key K) int {
+	return int(s.hasher.Hash(s.shards[0].canonical(key)) % uint64(len(s.shards)))
+}
+
+// now returns the current time according to the clock shared by the shards.
+func (s *sharded[K, V]) now() time.Time {
+	return s.shards[0].now()
+}
+
+// Contains checks if the provided key is present in the sharded cache.
+func (s *sharded[K, V]) Contains(key K) bool {
+	return s.shard(key).Contains(key)
+}
+
+// Set adds or updates a key-value pair in the shard responsible for the key.
+// Only the least recently used item of that shard is evicted when the shard is full.
+func (s *sharded[K, V]) Set(key K, value V) {
+	s.shard(key).Set(key, value)
+}
+
+// SetE adds or updates a key-value pair in the shard responsible for the key like Set,
+// returning ErrFull if the item was not stored, such as a new key while the shard is full
+// and the cache was created with WithRejectWhenFull.
+func (s *sharded[K, V]) SetE(key K, value V) error {
+	return s.shard(key).SetE(key, value)
+}
+
+// SetNX stores the provided key-value pair in the shard responsible for the key, provided the key is missing.
+func (s *sharded[K, V]) SetNX(key K, value V) bool {
+	return s.shard(key).SetNX(key, value)
+}
+
+// GetOrSet returns the existing value for the key if present, otherwise it stores the provided value.
+func (s *sharded[K, V]) GetOrSet(key K, value V) (V, bool) {
+	return s.shard(key).GetOrSet(key, value)
+}
+
+// GetOrCompute returns the existing value for the key if present, otherwise it stores the result of fn.
+func (s *sharded[K, V]) GetOrCompute(key K, fn func() V) (V, bool) {
+	return s.shard(key).GetOrCompute(key, fn)
+}
+
+// Get retrieves the value associated with the provided key and promotes it within its shard.
+func (s *sharded[K, V]) Get(key K) (V, bool) {
+	return s.shard(key).Get(key)
+}
+
+// GetE retrieves the value associated with the provided key from its shard, reporting a miss as an error.
+func (s *sharded[K, V]) GetE(key K) (V, error) {
+	return s.shard(key).GetE(key)
+}
+
+// Peek retrieves the value associated with the provided key without promoting it.
+func (s *sharded[K, V]) Peek(key K) (V, bool) {
+	return s.shard(key).Peek(key)
+}
+
+// GetOldest returns the least recently used key-value pair of the first non-empty shard.
+// As recency is only tracked per shard, the pair is not necessarily the oldest of the whole cache.
+func (s *sharded[K, V]) GetOldest() (K, V, bool) {
+	for _, sh := range s.shards {
+		if key, value, ok := sh.GetOldest(); ok {
+			return key, value, true
+		}
+	}
+
+	var emptyKey K
+	var emptyVal V
+	return emptyKey, emptyVal, false
+}
+
+// RemoveOldest removes the least recently used key-value pair of the first non-empty shard and returns it.
+// As recency is only tracked per shard, the pair is not necessarily the oldest of the whole cache.
+func (s *sharded[K, V]) RemoveOldest() (K, V, bool) {
+	for _, sh := range s.shards {
+		if key, value, ok := sh.RemoveOldest(); ok {
+			return key, value, true
+		}
+	}
+
+	var emptyKey K
+	var emptyVal V
+	return emptyKey, emptyVal, false
+}
+
+// Del removes the key-value pair associated with the provided key from its shard.
+func (s *sharded[K, V]) Del(key K) bool {
+	return s.shard(key).Del(key)
+}
+
+// Len returns the number of items currently stored across all shards.
+func (s *sharded[K, V]) Len() int {
+	out := 0
+	for _, sh := range s.shards {
+		out += sh.Len()
+	}
+
+	return out
+}
+
+// Cap returns the maximum number of items the sharded cache can hold across all shards.
+func (s *sharded[K, V]) Cap() int {
+	out := 0
+	for _, sh := range s.shards {
+		out += sh.Cap()
+	}
+
+	return out
+}
+
+// Purge removes all key-value pairs from every shard.
+func (s *sharded[K, V]) Purge() {
+	for _, sh := range s.shards {
+		sh.Purge()
+	}
+}
+
+// Resize changes the total capacity of the sharded cache, spreading it evenly across the shards.
+// The number of shards does not change, so a bounded cache keeps a capacity of at least one item per shard.
+func (s *sharded[K, V]) Resize(size int) {
+	for i, n := range shardSizes(size, len(s.shards)) {
+		s.shards[i].Resize(n)
+	}
+}
+
+// Keys returns a snapshot of the keys in the sharded cache.
+// Keys are ordered from the most recently used to the least recently used within each shard,
+// but there is no recency order across shards.
+func (s *sharded[K, V]) Keys() []K {
+	var out []K
+	for _, sh := range s.shards {
+		out = append(out, sh.Keys()...)
+	}
+
+	return out
+}
+
+// Values returns a snapshot of the values in the sharded cache,
+// in the same order as the keys returned by Keys.
+func (s *sharded[K, V]) Values() []V {
+	var out []V
+	for _, sh := range s.shards {
+		out = append(out, sh.Values()...)
+	}
+
+	return out
+}
+
+// All returns an iterator over the key-value pairs in the sharded cache, in the same order as Keys.
+// Each shard is snapshotted under its own lock when iteration reaches it.
+func (s *sharded[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, sh := range s.shards {
+			for key, value := range sh.All() {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the key-value pairs in the sharded cache, in the reverse order of All.
+// Each shard is snapshotted under its own lock when iteration reaches it.
+func (s *sharded[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := len(s.shards) - 1; i >= 0; i-- {
+			for key, value := range s.shards[i].Backward() {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Stats returns the sum of the usage counters of every shard, along with their breakdown by shard in Shards.
+// Each shard is read in turn, so the breakdown is not a consistent snapshot of the whole cache.
+func (s *sharded[K, V]) Stats() Stats {
+	var out Stats
+	shards := make([]ShardStats, len(s.shards))
+	for i, sh := range s.shards {
+		st := sh.Stats()
+		out = out.add(st)
+		shards[i] = ShardStats{
+			Len:         sh.Len(),
+			Hits:        st.Hits,
+			Misses:      st.Misses,
+			Evictions:   st.Evictions,
+			Expirations: st.Expirations,
+		}
+	}
+	out.Shards = shards
+
+	return out
+}
+
+// Events returns the channel on which events of every shard are delivered
+// when the cache was created with WithEvents. It returns nil otherwise.
+func (s *sharded[K, V]) Events() <-chan Event[K, V] {
+	return s.shards[0].Events()
+}
+
+// Close stops any background goroutine owned by the shards.
+// A cache created with WithPersistence is saved one last time.
+func (s *sharded[K, V]) Close() {
+	if s.persistence != nil {
+		s.persistence.stop(s)
+		s.persistence = nil
+	}
+
+	if s.stopInvalidation != nil {
+		s.stopInvalidation()
+	}
+
+	for _, sh := range s.shards {
+		sh.Close()
+	}
+}
+
+// shardSizes splits size into n shard capacities that differ by at most one.
+// Every shard of a bounded cache holds at least one item, since a capacity of zero would make it Unbounded.
+func shardSizes(size, n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = size / n
+		if i < size%n {
+			out[i]++
+		}
+		if size > 0 && out[i] == 0 {
+			out[i] = 1
+		}
+	}
+
+	return out
+}
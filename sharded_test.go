@@ -0,0 +1,131 @@
+package lru
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSharded(t *testing.T) {
+	t.Run("should spread capacity across shards", func(t *testing.T) {
+		l := NewSharded[int, int](10, 4).(*sharded[int, int])
+
+		actual := []int{}
+		for _, sh := range l.shards {
+			actual = append(actual, sh.Cap())
+		}
+
+		expected := []int{3, 3, 2, 2}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("Expected %v; Actual = %v", expected, actual)
+		}
+
+		if !reflect.DeepEqual(10, l.Cap()) {
+			t.Errorf("Expected 10; Actual = %v", l.Cap())
+		}
+	})
+
+	t.Run("should stay bounded when resized below the number of shards", func(t *testing.T) {
+		l := NewSharded[int, int](16, 8)
+		l.Resize(3)
+
+		for i := 0; i < 1000; i++ {
+			l.Set(i, i)
+		}
+
+		if !reflect.DeepEqual(8, l.Cap()) || l.Len() > l.Cap() {
+			t.Errorf("Expected at most %v items; Actual = %v", 8, l.Len())
+		}
+	})
+
+	t.Run("should route keys to a single shard", func(t *testing.T) {
+		l := NewSharded[string, int](64, 4)
+
+		for i := 0; i < 32; i++ {
+			l.Set(fmt.Sprintf("%d", i), i)
+		}
+
+		for i := 0; i < 32; i++ {
+			actual, ok := l.Get(fmt.Sprintf("%d", i))
+			if !ok || actual != i {
+				t.Errorf("Expected %v; Actual = %v", i, actual)
+			}
+		}
+
+		if !reflect.DeepEqual(32, len(l.Keys())) {
+			t.Errorf("Expected 32; Actual = %v", len(l.Keys()))
+		}
+
+		if !l.Del("1") || l.Contains("1") {
+			t.Errorf("Expected key 1 to be deleted")
+		}
+
+		l.Purge()
+		if !reflect.DeepEqual(0, l.Len()) {
+			t.Errorf("Expected 0; Actual = %v", l.Len())
+		}
+	})
+
+	t.Run("should break stats down by shard", func(t *testing.T) {
+		l := NewSharded[int, int](100, 4).(*sharded[int, int])
+		for i := 0; i < 20; i++ {
+			l.Set(i, i)
+		}
+
+		hot := l.index(3)
+		for i := 0; i < 5; i++ {
+			l.Get(3)
+		}
+		l.Get(-1)
+
+		stats := l.Stats()
+		if !reflect.DeepEqual(4, len(stats.Shards)) {
+			t.Fatalf("Expected 4; Actual = %v", len(stats.Shards))
+		}
+
+		length, misses := 0, uint64(0)
+		for _, sh := range stats.Shards {
+			length += sh.Len
+			misses += sh.Misses
+		}
+		if !reflect.DeepEqual(20, length) || !reflect.DeepEqual(uint64(1), misses) {
+			t.Errorf("Expected %v %v; Actual = %v %v", 20, 1, length, misses)
+		}
+		if !reflect.DeepEqual(uint64(5), stats.Shards[hot].Hits) {
+			t.Errorf("Expected 5; Actual = %v", stats.Shards[hot].Hits)
+		}
+		if !reflect.DeepEqual(hot, stats.Hottest()) {
+			t.Errorf("Expected %v; Actual = %v", hot, stats.Hottest())
+		}
+		if !reflect.DeepEqual(-1, New[int, int](1).Stats().Hottest()) {
+			t.Errorf("Expected -1; Actual = %v", New[int, int](1).Stats().Hottest())
+		}
+	})
+
+	t.Run("should clamp shard count to size", func(t *testing.T) {
+		l := NewSharded[int, int](2, 8).(*sharded[int, int])
+
+		if !reflect.DeepEqual(2, len(l.shards)) {
+			t.Errorf("Expected 2; Actual = %v", len(l.shards))
+		}
+	})
+
+	t.Run("should handle concurrency", func(t *testing.T) {
+		l := NewSharded[int, int](100, 8)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				l.Set(i, i)
+			}(i)
+			go func(i int) {
+				defer wg.Done()
+				l.Get(i)
+			}(i)
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,178 @@
+package lru
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"time"
+)
+
+// entry is a key-value pair as written to a snapshot.
+// Fields are exported for encoding/gob; values holding interfaces require their concrete types to be registered with gob.Register.
+type entry[K comparable, V any] struct {
+	Key       K             // Key of the item.
+	Value     V             // Value of the item.
+	TTL       time.Duration // Remaining time-to-live of the item in snapshots written before ExpiresAt, zero otherwise.
+	ExpiresAt time.Time     // Expiry time of the item, zero when it never expires.
+	Cost      int64         // Cost of the item.
+}
+
+// Save writes a snapshot of the LRU cache to the provided writer, encoded with encoding/gob.
+// The snapshot holds every key-value pair along with its expiry time and cost,
+// ordered from the least recently used to the most recently used. Expired items are left out.
+func (l *lru[K, V]) Save(w io.Writer) error {
+	return encodeSnapshot(w, l.snapshot())
+}
+
+// Restore reads a snapshot written by Save from the provided reader and stores its key-value pairs in the LRU cache,
+// preserving their recency order, expiry times and costs, so items keep expiring at the same time
+// however long the snapshot was stored. Items which expired since the snapshot was taken are left out.
+// Restored items are more recently used than the items already in the cache.
+// Nothing is stored if the snapshot cannot be decoded.
+func (l *lru[K, V]) Restore(r io.Reader) error {
+	entries, err := decodeSnapshot[K, V](r)
+	if err != nil {
+		return err
+	}
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	for _, e := range entries {
+		l.restore(e)
+	}
+
+	return nil
+}
+
+// snapshot returns the unexpired items of the LRU cache, ordered from the least recently used to the most recently used.
+func (l *lru[K, V]) snapshot() []entry[K, V] {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	out := make([]entry[K, V], 0, l.length)
+	for t := l.back(); t != nil; t = l.prev(t) {
+		if l.stale(t) {
+			continue
+		}
+
+		out = append(out, entry[K, V]{Key: t.key, Value: t.value, ExpiresAt: t.ttl, Cost: t.cost})
+	}
+
+	return out
+}
+
+// restore stores the provided snapshot entry as the most recently used item, unless it expired.
+func (l *lru[K, V]) restore(e entry[K, V]) {
+	expiry := e.ExpiresAt
+	if expiry.IsZero() && e.TTL > 0 {
+		expiry = l.now().Add(e.TTL)
+	}
+
+	if !expiry.IsZero() && !expiry.After(l.now()) {
+		return
+	}
+
+	l.setWithCost(e.Key, e.Value, expiry, e.Cost)
+}
+
+// encodeSnapshot writes the number of entries followed by every entry to the provided writer.
+func encodeSnapshot[K comparable, V any](w io.Writer, entries []entry[K, V]) error {
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(len(entries)); err != nil {
+		return fmt.Errorf("lru: encode snapshot: %w", err)
+	}
+
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("lru: encode snapshot: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// decodeSnapshot reads the entries written by encodeSnapshot from the provided reader.
+func decodeSnapshot[K comparable, V any](r io.Reader) ([]entry[K, V], error) {
+	dec := gob.NewDecoder(r)
+
+	var n int
+	if err := dec.Decode(&n); err != nil {
+		return nil, fmt.Errorf("lru: decode snapshot: %w", err)
+	}
+
+	out := make([]entry[K, V], 0, min(n, 1024))
+	for i := 0; i < n; i++ {
+		var e entry[K, V]
+		if err := dec.Decode(&e); err != nil {
+			return nil, fmt.Errorf("lru: decode snapshot: %w", err)
+		}
+		out = append(out, e)
+	}
+
+	return out, nil
+}
+
+// Save writes a snapshot of every shard to the provided writer, encoded with encoding/gob.
+// Items are ordered from the least recently used to the most recently used within each shard.
+func (s *sharded[K, V]) Save(w io.Writer) error {
+	var entries []entry[K, V]
+	for _, sh := range s.shards {
+		entries = append(entries, sh.snapshot()...)
+	}
+
+	return encodeSnapshot(w, entries)
+}
+
+// Restore reads a snapshot written by Save from the provided reader
+// and stores every key-value pair in the shard responsible for its key, preserving the recency order within each shard.
+// Nothing is stored if the snapshot cannot be decoded.
+func (s *sharded[K, V]) Restore(r io.Reader) error {
+	entries, err := decodeSnapshot[K, V](r)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		sh := s.shard(e.Key)
+		sh.locker.Lock()
+		sh.restore(e)
+		sh.locker.Unlock()
+	}
+
+	return nil
+}
+
+// Save writes a snapshot of both tiers to the provided writer, encoded with encoding/gob.
+// Items are ordered from the oldest item of the second tier to the most recently used item of the first tier.
+// The TTLs and costs of the tiers are not saved.
+func (t *tiered[K, V]) Save(w io.Writer) error {
+	keys, values := t.items()
+
+	entries := make([]entry[K, V], len(keys))
+	for i := range keys {
+		n := len(keys) - 1 - i
+		entries[i] = entry[K, V]{Key: keys[n], Value: values[n], Cost: 1}
+	}
+
+	return encodeSnapshot(w, entries)
+}
+
+// Restore reads a snapshot written by Save from the provided reader and sets every key-value pair in order,
+// so the most recently used items end up in the first tier and the older ones are demoted into the second tier.
+// Nothing is stored if the snapshot cannot be decoded.
+func (t *tiered[K, V]) Restore(r io.Reader) error {
+	entries, err := decodeSnapshot[K, V](r)
+	if err != nil {
+		return err
+	}
+
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	for _, e := range entries {
+		t.set(e.Key, e.Value, nil)
+	}
+
+	return nil
+}
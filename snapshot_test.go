@@ -0,0 +1,134 @@
+package lru
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Run("should restore items in recency order", func(t *testing.T) {
+		l := New[string, int](3)
+		l.Set("a", 1)
+		l.Set("b", 2)
+		l.Set("c", 3)
+		l.Get("a")
+
+		var buf bytes.Buffer
+		if err := l.Save(&buf); err != nil {
+			t.Errorf("Expected nil; Actual = %v", err)
+		}
+
+		restored := New[string, int](3)
+		if err := restored.Restore(&buf); err != nil {
+			t.Errorf("Expected nil; Actual = %v", err)
+		}
+
+		if !reflect.DeepEqual(l.Keys(), restored.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", l.Keys(), restored.Keys())
+		}
+
+		if !reflect.DeepEqual(l.Values(), restored.Values()) {
+			t.Errorf("Expected %v; Actual = %v", l.Values(), restored.Values())
+		}
+	})
+
+	t.Run("should restore remaining TTLs and costs", func(t *testing.T) {
+		l := NewWithCost[int, int](3, 10)
+		l.SetWithCost(1, 1, 4)
+		l.SetWithCost(2, 2, 5)
+
+		e := NewWithExpiry[int, int](3)
+		defer e.Close()
+		e.SetWithTTL(1, 1, 20*time.Millisecond)
+		e.SetWithTTL(2, 2, time.Hour)
+		e.Set(3, 3)
+
+		var costs, ttls bytes.Buffer
+		l.Save(&costs)
+		e.Save(&ttls)
+
+		restoredCosts := NewWithCost[int, int](3, 10)
+		restoredCosts.Restore(&costs)
+
+		if !reflect.DeepEqual(int64(9), restoredCosts.Cost()) {
+			t.Errorf("Expected 9; Actual = %v", restoredCosts.Cost())
+		}
+
+		restoredTTLs := NewWithExpiry[int, int](3)
+		defer restoredTTLs.Close()
+		restoredTTLs.Restore(&ttls)
+		time.Sleep(30 * time.Millisecond)
+
+		_, ok := restoredTTLs.Get(1)
+		if !reflect.DeepEqual(false, ok) {
+			t.Errorf("Expected false; Actual = %v", ok)
+		}
+
+		_, ok = restoredTTLs.Get(2)
+		if !reflect.DeepEqual(true, ok) {
+			t.Errorf("Expected true; Actual = %v", ok)
+		}
+	})
+
+	t.Run("should restore expiry times rather than TTLs", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		l := NewWithExpiry[int, int](3, WithClock[int, int](clock))
+		defer l.Close()
+		l.SetWithTTL(1, 1, time.Minute)
+		l.SetWithTTL(2, 2, time.Hour)
+		l.SetWithTTL(3, 3, 0)
+
+		var buf bytes.Buffer
+		l.Save(&buf)
+		clock.Advance(30 * time.Minute)
+
+		restored := NewWithExpiry[int, int](3, WithClock[int, int](clock))
+		defer restored.Close()
+		restored.Restore(&buf)
+
+		if !reflect.DeepEqual([]int{2}, restored.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{2}, restored.Keys())
+		}
+		if ttl, _ := restored.GetTTL(2); !reflect.DeepEqual(30*time.Minute, ttl) {
+			t.Errorf("Expected %v; Actual = %v", 30*time.Minute, ttl)
+		}
+	})
+
+	t.Run("should restore sharded caches", func(t *testing.T) {
+		l := NewSharded[int, int](100, 4)
+		for i := 0; i < 8; i++ {
+			l.Set(i, i)
+		}
+
+		var buf bytes.Buffer
+		l.Save(&buf)
+
+		restored := NewSharded[int, int](100, 4)
+		restored.Restore(&buf)
+
+		if !reflect.DeepEqual(l.Len(), restored.Len()) {
+			t.Errorf("Expected %v; Actual = %v", l.Len(), restored.Len())
+		}
+	})
+
+	t.Run("should not store anything from a corrupt snapshot", func(t *testing.T) {
+		l := New[int, int](3)
+		l.Set(1, 1)
+		l.Set(2, 2)
+
+		var buf bytes.Buffer
+		l.Save(&buf)
+
+		restored := New[int, int](3)
+		err := restored.Restore(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+		if err == nil {
+			t.Errorf("Expected an error; Actual = %v", err)
+		}
+
+		if !reflect.DeepEqual(0, restored.Len()) {
+			t.Errorf("Expected 0; Actual = %v", restored.Len())
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package lru
+
+// Stats holds counters describing how a cache has been used since it was created.
+type Stats struct {
+	Hits        uint64 // Number of lookups which found the key.
+	Misses      uint64 // Number of lookups which did not find the key.
+	Evictions   uint64 // Number of items removed to make room for other items.
+	Expirations uint64 // Number of items removed because their TTL passed.
+	Pinned      uint64 // Number of items currently pinned with Pin, which are protected from eviction and expiry.
+	Rejections  uint64 // Number of writes rejected because the cache was full, see WithRejectWhenFull.
+
+	Shards []ShardStats // Breakdown of a sharded cache by shard, ordered by shard index, nil for other caches.
+}
+
+// ShardStats holds the counters of a single shard of a sharded cache,
+// which help to detect keys hashing unevenly and to tune the number of shards.
+type ShardStats struct {
+	Len         int    // Number of items currently stored in the shard.
+	Hits        uint64 // Number of lookups in the shard which found the key.
+	Misses      uint64 // Number of lookups in the shard which did not find the key.
+	Evictions   uint64 // Number of items of the shard removed to make room for other items.
+	Expirations uint64 // Number of items of the shard removed because their TTL passed.
+}
+
+// Hottest returns the index of the shard which served the most lookups, hits and misses alike,
+// or -1 if the stats are not those of a sharded cache.
+func (s Stats) Hottest() int {
+	hottest := -1
+	var most uint64
+	for i, sh := range s.Shards {
+		if lookups := sh.Hits + sh.Misses; hottest < 0 || lookups > most {
+			hottest, most = i, lookups
+		}
+	}
+
+	return hottest
+}
+
+// add returns the sum of the counters of both stats, without any shard breakdown.
+func (s Stats) add(other Stats) Stats {
+	return Stats{
+		Hits:        s.Hits + other.Hits,
+		Misses:      s.Misses + other.Misses,
+		Evictions:   s.Evictions + other.Evictions,
+		Expirations: s.Expirations + other.Expirations,
+		Pinned:      s.Pinned + other.Pinned,
+		Rejections:  s.Rejections + other.Rejections,
+	}
+}
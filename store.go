@@ -0,0 +1,116 @@
+package lru
+
+import (
+	"context"
+	"time"
+)
+
+// Store is an external system backing a cache, such as Redis or a database.
+// Get returns an error when the key is missing, which the cache reports as a miss.
+type Store[K comparable, V any] interface {
+	// Get reads the value associated with the provided key from the store.
+	Get(ctx context.Context, key K) (V, error)
+
+	// Set writes the provided key-value pair to the store.
+	Set(ctx context.Context, key K, value V) error
+
+	// Delete removes the provided key from the store.
+	Delete(ctx context.Context, key K) error
+}
+
+// backed represents a Least Recently Used (LRU) cache in front of a Store.
+// Reads fall back to the store on a miss and writes go to the store before the cache,
+// or are queued for the store in write-behind mode.
+type backed[K comparable, V any] struct {
+	*lru[K, V]               // Cache holding the recently used values, loading misses from the store.
+	store      Store[K, V]   // Store the cache writes to.
+	writer     *writer[K, V] // Writer queueing writes for the store in write-behind mode, nil for write-through.
+}
+
+// Set writes the provided key-value pair to the store and, once the store accepted it, to the cache.
+// If the store fails, its error is returned and the cache is left untouched.
+// In write-behind mode, the write is queued for the store instead.
+func (b *backed[K, V]) Set(ctx context.Context, key K, value V) error {
+	key = b.canonical(key)
+
+	if err := b.setStore(ctx, key, value); err != nil {
+		return err
+	}
+
+	b.lru.locker.Lock()
+	defer b.lru.locker.Unlock()
+
+	var expiry time.Time
+	b.lru.set(key, value, expiry)
+
+	return nil
+}
+
+// Delete removes the provided key from the store and from the cache.
+// If the store fails, its error is returned and the cache is left untouched.
+// In write-behind mode, the deletion is queued for the store instead.
+func (b *backed[K, V]) Delete(ctx context.Context, key K) error {
+	key = b.canonical(key)
+
+	if err := b.deleteStore(ctx, key); err != nil {
+		return err
+	}
+
+	b.lru.Del(key)
+
+	return nil
+}
+
+// Flush waits until every write queued so far in write-behind mode reached the store, or the context is done.
+// It returns the errors of the writes dropped after their last retry since the previous Flush.
+// In write-through mode, there is nothing to flush and it returns nil immediately.
+func (b *backed[K, V]) Flush(ctx context.Context) error {
+	if b.writer == nil {
+		return nil
+	}
+
+	return b.writer.flush(ctx)
+}
+
+// Close waits for the writes queued in write-behind mode, then stops any background goroutine owned by the cache.
+// It is safe to call Close more than once; the cache must not be used after Close.
+func (b *backed[K, V]) Close() {
+	if b.writer != nil {
+		b.writer.close()
+	}
+
+	b.lru.Close()
+}
+
+// load reads the value of the provided key, from its pending write in write-behind mode or from the store.
+func (b *backed[K, V]) load(ctx context.Context, key K) (V, error) {
+	if b.writer != nil {
+		if value, deleted, ok := b.writer.lookup(key); ok {
+			if deleted {
+				return value, ErrNotFound
+			}
+			return value, nil
+		}
+	}
+
+	return b.store.Get(ctx, key)
+}
+
+// setStore writes the provided key-value pair to the store, or queues the write in write-behind mode.
+func (b *backed[K, V]) setStore(ctx context.Context, key K, value V) error {
+	if b.writer != nil {
+		return b.writer.enqueue(key, value, false)
+	}
+
+	return b.store.Set(ctx, key, value)
+}
+
+// deleteStore removes the provided key from the store, or queues the deletion in write-behind mode.
+func (b *backed[K, V]) deleteStore(ctx context.Context, key K) error {
+	if b.writer != nil {
+		var emptyVal V
+		return b.writer.enqueue(key, emptyVal, true)
+	}
+
+	return b.store.Delete(ctx, key)
+}
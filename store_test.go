@@ -0,0 +1,253 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+var errNotFound = errors.New("not found")
+
+// mapStore is an in-memory Store used to observe what a backed cache reads and writes.
+type mapStore[K comparable, V any] struct {
+	data   map[K]V
+	reads  int
+	writes int
+	fails  int
+	err    error
+	sync.Mutex
+}
+
+func newMapStore[K comparable, V any]() *mapStore[K, V] {
+	return &mapStore[K, V]{data: map[K]V{}}
+}
+
+func (s *mapStore[K, V]) Get(_ context.Context, key K) (V, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	s.reads++
+	value, ok := s.data[key]
+	if !ok {
+		return value, errNotFound
+	}
+
+	return value, nil
+}
+
+func (s *mapStore[K, V]) Set(_ context.Context, key K, value V) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	s.writes++
+	if s.fails > 0 {
+		s.fails--
+		return errors.New("transient")
+	}
+	if s.err != nil {
+		return s.err
+	}
+	s.data[key] = value
+
+	return nil
+}
+
+func (s *mapStore[K, V]) Delete(_ context.Context, key K) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	delete(s.data, key)
+
+	return nil
+}
+
+func TestBacked(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	t.Run("should write through to the store", func(t *testing.T) {
+		store := newMapStore[int, int]()
+		l := NewBacked[int, int](3, store)
+		defer l.Close()
+
+		if err := l.Set(ctx, 1, 10); err != nil {
+			t.Errorf("Expected nil; Actual = %v", err)
+		}
+
+		if !reflect.DeepEqual(map[int]int{1: 10}, store.data) {
+			t.Errorf("Expected %v; Actual = %v", map[int]int{1: 10}, store.data)
+		}
+
+		actual, ok := l.Get(1)
+		if !ok || actual != 10 {
+			t.Errorf("Expected 10; Actual = %v", actual)
+		}
+
+		if !reflect.DeepEqual(0, store.reads) {
+			t.Errorf("Expected 0; Actual = %v", store.reads)
+		}
+	})
+
+	t.Run("should fall back to the store on a miss", func(t *testing.T) {
+		store := newMapStore[int, int]()
+		store.data[1] = 10
+		l := NewBacked[int, int](3, store)
+		defer l.Close()
+
+		actual, err := l.Load(ctx, 1)
+		if err != nil || actual != 10 {
+			t.Errorf("Expected 10; Actual = %v, %v", actual, err)
+		}
+
+		_, err = l.Load(ctx, 2)
+		if !errors.Is(err, errNotFound) {
+			t.Errorf("Expected %v; Actual = %v", errNotFound, err)
+		}
+
+		l.Get(1)
+		if !reflect.DeepEqual(2, store.reads) {
+			t.Errorf("Expected 2; Actual = %v", store.reads)
+		}
+	})
+
+	t.Run("should leave the cache untouched when the store fails", func(t *testing.T) {
+		store := newMapStore[int, int]()
+		l := NewBacked[int, int](3, store)
+		defer l.Close()
+
+		l.Set(ctx, 1, 10)
+		store.err = errBoom
+
+		if err := l.Set(ctx, 1, 20); !errors.Is(err, errBoom) {
+			t.Errorf("Expected %v; Actual = %v", errBoom, err)
+		}
+
+		if err := l.Delete(ctx, 1); !errors.Is(err, errBoom) {
+			t.Errorf("Expected %v; Actual = %v", errBoom, err)
+		}
+
+		actual, _ := l.Peek(1)
+		if !reflect.DeepEqual(10, actual) {
+			t.Errorf("Expected 10; Actual = %v", actual)
+		}
+	})
+
+	t.Run("should delete from the store and the cache", func(t *testing.T) {
+		store := newMapStore[int, int]()
+		l := NewBacked[int, int](3, store)
+		defer l.Close()
+
+		l.Set(ctx, 1, 10)
+		l.Delete(ctx, 1)
+
+		if !reflect.DeepEqual(false, l.Contains(1)) {
+			t.Errorf("Expected false; Actual = %v", l.Contains(1))
+		}
+
+		if !reflect.DeepEqual(map[int]int{}, store.data) {
+			t.Errorf("Expected %v; Actual = %v", map[int]int{}, store.data)
+		}
+	})
+
+	t.Run("should write to the store asynchronously in write-behind mode", func(t *testing.T) {
+		store := newMapStore[int, int]()
+		l := NewBacked[int, int](1, store, WithWriteBehind[int, int](WriteBehind{Workers: 2}))
+		defer l.Close()
+
+		l.Set(ctx, 1, 10)
+		l.Set(ctx, 2, 20)
+		l.Delete(ctx, 2)
+
+		// the pending write of the evicted key is still visible
+		actual, err := l.Load(ctx, 1)
+		if err != nil || actual != 10 {
+			t.Errorf("Expected 10; Actual = %v, %v", actual, err)
+		}
+
+		_, err = l.Load(ctx, 2)
+		if err == nil {
+			t.Errorf("Expected an error; Actual = %v", err)
+		}
+
+		if err := l.Flush(ctx); err != nil {
+			t.Errorf("Expected nil; Actual = %v", err)
+		}
+
+		store.Mutex.Lock()
+		defer store.Mutex.Unlock()
+
+		if !reflect.DeepEqual(map[int]int{1: 10}, store.data) {
+			t.Errorf("Expected %v; Actual = %v", map[int]int{1: 10}, store.data)
+		}
+	})
+
+	t.Run("should retry failed writes in write-behind mode", func(t *testing.T) {
+		store := newMapStore[int, int]()
+		store.fails = 2
+		l := NewBacked[int, int](3, store, WithWriteBehind[int, int](WriteBehind{RetryDelay: time.Millisecond}))
+		defer l.Close()
+
+		l.Set(ctx, 1, 10)
+
+		if err := l.Flush(ctx); err != nil {
+			t.Errorf("Expected nil; Actual = %v", err)
+		}
+
+		if !reflect.DeepEqual(map[int]int{1: 10}, store.data) {
+			t.Errorf("Expected %v; Actual = %v", map[int]int{1: 10}, store.data)
+		}
+	})
+
+	t.Run("should report dropped writes on flush in write-behind mode", func(t *testing.T) {
+		store := newMapStore[int, int]()
+		store.err = errBoom
+		l := NewBacked[int, int](3, store, WithWriteBehind[int, int](WriteBehind{Retries: -1}))
+		defer l.Close()
+
+		if err := l.Set(ctx, 1, 10); err != nil {
+			t.Errorf("Expected nil; Actual = %v", err)
+		}
+
+		if err := l.Flush(ctx); !errors.Is(err, errBoom) {
+			t.Errorf("Expected %v; Actual = %v", errBoom, err)
+		}
+
+		if err := l.Flush(ctx); err != nil {
+			t.Errorf("Expected nil; Actual = %v", err)
+		}
+	})
+
+	t.Run("should coalesce writes of the same key in write-behind mode", func(t *testing.T) {
+		store := newMapStore[int, int]()
+		store.Mutex.Lock()
+		l := NewBacked[int, int](3, store, WithWriteBehind[int, int](WriteBehind{}))
+
+		// the first write blocks in the store while the next ones are queued
+		l.Set(ctx, 1, 1)
+		time.Sleep(10 * time.Millisecond)
+		for i := 2; i <= 10; i++ {
+			l.Set(ctx, 1, i)
+		}
+		store.Mutex.Unlock()
+
+		l.Close()
+
+		if !reflect.DeepEqual(2, store.writes) {
+			t.Errorf("Expected 2; Actual = %v", store.writes)
+		}
+
+		if !reflect.DeepEqual(map[int]int{1: 10}, store.data) {
+			t.Errorf("Expected %v; Actual = %v", map[int]int{1: 10}, store.data)
+		}
+
+		if err := l.Set(ctx, 1, 11); !errors.Is(err, ErrClosed) {
+			t.Errorf("Expected %v; Actual = %v", ErrClosed, err)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package lru
+
+import "time"
+
+// Swap stores the provided value for the provided key like Set and returns the value it replaced, if any.
+// The existed result is true if the key was present.
+//
+// Example usage:
+//
+//	old, existed := cache.Swap("config", next)
+func (l *lru[K, V]) Swap(key K, value V) (V, bool) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	var old V
+	c, existed := l.lookup(key)
+	if existed {
+		old = c.value
+	}
+
+	var expiry time.Time
+	l.set(key, value, expiry)
+
+	return old, existed
+}
+
+// CompareAndSwapFunc stores the provided new value for the provided key like Set,
+// provided the key is present and its current value equals the provided old value according to the provided function.
+// It reports whether the value was swapped, which allows building optimistic update loops on top of Get.
+// Use CompareAndSwap for comparable values.
+//
+// Example usage:
+//
+//	for {
+//		old, _ := cache.Get(key)
+//		if cache.CompareAndSwapFunc(key, old, update(old), bytes.Equal) {
+//			break
+//		}
+//	}
+func (l *lru[K, V]) CompareAndSwapFunc(key K, old, value V, equal func(a, b V) bool) bool {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	c, ok := l.lookup(key)
+	if !ok || !equal(c.value, old) {
+		return false
+	}
+
+	var expiry time.Time
+	l.set(key, value, expiry)
+
+	return true
+}
+
+// CompareAndSwap stores the provided new value for the provided key in the provided cache,
+// provided the key is present and its current value equals the provided old value.
+// It reports whether the value was swapped. See CompareAndSwapFunc for values which are not comparable.
+//
+// Example usage:
+//
+//	for {
+//		old, _ := counters.Get(key)
+//		if lru.CompareAndSwap(counters, key, old, old+1) {
+//			break
+//		}
+//	}
+func CompareAndSwap[K, V comparable](cache interface {
+	CompareAndSwapFunc(key K, old, value V, equal func(a, b V) bool) bool
+}, key K, old, value V) bool {
+	return cache.CompareAndSwapFunc(key, old, value, func(a, b V) bool {
+		return a == b
+	})
+}
+
+// Swap stores the provided value in the shard responsible for the key and returns the value it replaced, if any.
+func (s *sharded[K, V]) Swap(key K, value V) (V, bool) {
+	return s.shard(key).Swap(key, value)
+}
+
+// CompareAndSwapFunc swaps the value stored for the provided key in the shard responsible for it,
+// provided its current value equals the provided old value according to the provided function.
+func (s *sharded[K, V]) CompareAndSwapFunc(key K, old, value V, equal func(a, b V) bool) bool {
+	return s.shard(key).CompareAndSwapFunc(key, old, value, equal)
+}
+
+// Swap stores the provided value in the first tier like Set and returns the value it replaced in either tier, if any.
+func (t *tiered[K, V]) Swap(key K, value V) (V, bool) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	old, existed := t.peek(key)
+	t.set(key, value, nil)
+
+	return old, existed
+}
+
+// CompareAndSwapFunc stores the provided new value in the first tier like Set,
+// provided the key is present in either tier and its current value equals the provided old value
+// according to the provided function.
+func (t *tiered[K, V]) CompareAndSwapFunc(key K, old, value V, equal func(a, b V) bool) bool {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	current, ok := t.peek(key)
+	if !ok || !equal(current, old) {
+		return false
+	}
+
+	t.set(key, value, nil)
+
+	return true
+}
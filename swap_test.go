@@ -0,0 +1,104 @@
+package lru
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSwap(t *testing.T) {
+	caches := map[string]func() LRU[string, int]{
+		"lru":     func() LRU[string, int] { return New[string, int](10) },
+		"sharded": func() LRU[string, int] { return NewSharded[string, int](100, 4) },
+		"tiered":  func() LRU[string, int] { return NewTiered[string, int](New[string, int](2), New[string, int](10)) },
+	}
+
+	for name, newCache := range caches {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should return the replaced value", func(t *testing.T) {
+				l := newCache()
+				defer l.Close()
+
+				old, existed := l.Swap("a", 1)
+				if existed || !reflect.DeepEqual(0, old) {
+					t.Errorf("Expected %v; Actual = %v", 0, old)
+				}
+
+				old, existed = l.Swap("a", 2)
+				if !existed || !reflect.DeepEqual(1, old) {
+					t.Errorf("Expected %v; Actual = %v", 1, old)
+				}
+
+				value, _ := l.Get("a")
+				if !reflect.DeepEqual(2, value) {
+					t.Errorf("Expected %v; Actual = %v", 2, value)
+				}
+			})
+
+			t.Run("should only swap the expected value", func(t *testing.T) {
+				l := newCache()
+				defer l.Close()
+
+				if CompareAndSwap(l, "a", 0, 1) {
+					t.Errorf("Expected missing key a not to be swapped")
+				}
+
+				l.Set("a", 1)
+				if CompareAndSwap(l, "a", 2, 3) {
+					t.Errorf("Expected key a not to be swapped")
+				}
+				if !CompareAndSwap(l, "a", 1, 3) {
+					t.Errorf("Expected key a to be swapped")
+				}
+
+				value, _ := l.Peek("a")
+				if !reflect.DeepEqual(3, value) {
+					t.Errorf("Expected %v; Actual = %v", 3, value)
+				}
+			})
+
+			t.Run("should not lose optimistic updates", func(t *testing.T) {
+				l := newCache()
+				defer l.Close()
+
+				l.Set("counter", 0)
+
+				var wg sync.WaitGroup
+				for range 50 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for {
+							old, _ := l.Peek("counter")
+							if CompareAndSwap(l, "counter", old, old+1) {
+								return
+							}
+						}
+					}()
+				}
+				wg.Wait()
+
+				value, _ := l.Peek("counter")
+				if !reflect.DeepEqual(50, value) {
+					t.Errorf("Expected %v; Actual = %v", 50, value)
+				}
+			})
+		})
+	}
+
+	t.Run("should compare values with the provided function", func(t *testing.T) {
+		l := New[string, []int](10)
+		l.Set("a", []int{1})
+
+		if !l.CompareAndSwapFunc("a", []int{1}, []int{2}, func(a, b []int) bool {
+			return reflect.DeepEqual(a, b)
+		}) {
+			t.Errorf("Expected key a to be swapped")
+		}
+
+		value, _ := l.Peek("a")
+		if !reflect.DeepEqual([]int{2}, value) {
+			t.Errorf("Expected %v; Actual = %v", []int{2}, value)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package lru
+
+import (
+	"slices"
+	"time"
+)
+
+// tagged is implemented by the caches supporting tags, used by the tiered cache to carry tags between its tiers.
+type tagged[K comparable, V any] interface {
+	SetWithTags(key K, value V, tags ...string)
+	GetEntry(key K) (Entry[K, V], bool)
+	InvalidateTag(tag string) int
+}
+
+// SetWithTags adds or updates a key-value pair in the LRU cache like Set, attaching the provided tags to it,
+// so it can later be removed along with every other item carrying one of them by InvalidateTag.
+// The tags replace those of a previous value stored for the key; Set and the other setters store values without tags.
+//
+// Example usage:
+//
+//	cache.SetWithTags("user:42:profile", profile, "user:42")
+//	cache.SetWithTags("user:42:orders", orders, "user:42", "orders")
+func (l *lru[K, V]) SetWithTags(key K, value V, tags ...string) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	var expiry time.Time
+	l.set(key, value, expiry)
+
+	if c, ok := l.cache[key]; ok {
+		l.tag(c, tags)
+	}
+}
+
+// InvalidateTag removes every item carrying the provided tag from the LRU cache and returns how many were removed.
+// The removals are reported as deletions and, like Del, broadcast with the invalidator configured with WithInvalidator.
+//
+// Example usage:
+//
+//	cache.InvalidateTag("user:42")
+func (l *lru[K, V]) InvalidateTag(tag string) int {
+	l.locker.Lock()
+
+	keys := make([]K, 0, len(l.tags[tag]))
+	for key := range l.tags[tag] {
+		keys = append(keys, key)
+	}
+
+	for _, key := range keys {
+		l.del(key)
+	}
+
+	l.locker.Unlock()
+
+	for _, key := range keys {
+		l.broadcast(key)
+	}
+
+	return len(keys)
+}
+
+// tag attaches the provided tags to the item, indexing it under every one of them.
+// Duplicate tags are attached once.
+func (l *lru[K, V]) tag(c *cache[K, V], tags []string) {
+	if len(tags) == 0 {
+		return
+	}
+
+	if l.tags == nil {
+		l.tags = map[string]map[K]struct{}{}
+	}
+
+	c.tags = slices.Compact(slices.Sorted(slices.Values(tags)))
+	for _, t := range c.tags {
+		keys, ok := l.tags[t]
+		if !ok {
+			keys = map[K]struct{}{}
+			l.tags[t] = keys
+		}
+		keys[c.key] = struct{}{}
+	}
+}
+
+// untag detaches every tag from the item, dropping the tags no other item carries from the index.
+func (l *lru[K, V]) untag(c *cache[K, V]) {
+	for _, t := range c.tags {
+		delete(l.tags[t], c.key)
+		if len(l.tags[t]) == 0 {
+			delete(l.tags, t)
+		}
+	}
+
+	c.tags = nil
+}
+
+// SetWithTags adds or updates a key-value pair in the shard responsible for the key, attaching the provided tags to it.
+func (s *sharded[K, V]) SetWithTags(key K, value V, tags ...string) {
+	s.shard(key).SetWithTags(key, value, tags...)
+}
+
+// InvalidateTag removes every item carrying the provided tag from every shard and returns how many were removed.
+func (s *sharded[K, V]) InvalidateTag(tag string) int {
+	out := 0
+	for _, sh := range s.shards {
+		out += sh.InvalidateTag(tag)
+	}
+
+	return out
+}
+
+// SetWithTags adds or updates a key-value pair in the first tier, attaching the provided tags to it.
+// Tags move along with the item between the tiers, provided the second tier supports tags as well.
+func (t *tiered[K, V]) SetWithTags(key K, value V, tags ...string) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	t.set(key, value, tags)
+}
+
+// InvalidateTag removes every item carrying the provided tag from both tiers and returns how many were removed.
+// Items of a second tier which does not support tags are not removed.
+func (t *tiered[K, V]) InvalidateTag(tag string) int {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	out := t.hot.InvalidateTag(tag)
+	if cold, ok := t.cold.(tagged[K, V]); ok {
+		out += cold.InvalidateTag(tag)
+	}
+
+	return out
+}
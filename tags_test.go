@@ -0,0 +1,96 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	t.Run("should remove every item carrying the tag", func(t *testing.T) {
+		l := New[string, int](10)
+		l.SetWithTags("profile", 1, "user:42")
+		l.SetWithTags("orders", 2, "user:42", "orders")
+		l.SetWithTags("other", 3, "user:7", "orders")
+		l.Set("plain", 4)
+
+		if actual := l.InvalidateTag("user:42"); !reflect.DeepEqual(2, actual) {
+			t.Errorf("Expected 2; Actual = %v", actual)
+		}
+
+		if !reflect.DeepEqual([]string{"plain", "other"}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []string{"plain", "other"}, l.Keys())
+		}
+
+		if actual := l.InvalidateTag("user:42"); !reflect.DeepEqual(0, actual) {
+			t.Errorf("Expected 0; Actual = %v", actual)
+		}
+	})
+
+	t.Run("should replace the tags of updated items", func(t *testing.T) {
+		l := New[string, int](10).(*lru[string, int])
+		l.SetWithTags("a", 1, "x", "y", "x")
+		l.SetWithTags("a", 2, "z")
+		l.SetWithTags("b", 1, "x")
+		l.Set("b", 2)
+
+		if e, _ := l.GetEntry("a"); !reflect.DeepEqual([]string{"z"}, e.Tags) {
+			t.Errorf("Expected %v; Actual = %v", []string{"z"}, e.Tags)
+		}
+
+		if l.InvalidateTag("x") != 0 || l.InvalidateTag("y") != 0 {
+			t.Errorf("Expected replaced tags to be dropped; Actual = %v", l.Keys())
+		}
+
+		if !reflect.DeepEqual(1, len(l.tags)) {
+			t.Errorf("Expected 1; Actual = %v", l.tags)
+		}
+	})
+
+	t.Run("should drop evicted and deleted items from the index", func(t *testing.T) {
+		l := New[int, int](1).(*lru[int, int])
+		l.SetWithTags(1, 1, "a")
+		l.SetWithTags(2, 2, "b")
+		l.Del(2)
+
+		if !reflect.DeepEqual(0, len(l.tags)) {
+			t.Errorf("Expected 0; Actual = %v", l.tags)
+		}
+	})
+
+	t.Run("should invalidate tags across shards", func(t *testing.T) {
+		l := NewSharded[int, int](100, 4)
+		for i := 0; i < 20; i++ {
+			if i%2 == 0 {
+				l.SetWithTags(i, i, "even")
+			} else {
+				l.Set(i, i)
+			}
+		}
+
+		if actual := l.InvalidateTag("even"); !reflect.DeepEqual(10, actual) {
+			t.Errorf("Expected 10; Actual = %v", actual)
+		}
+
+		if !reflect.DeepEqual(10, l.Len()) {
+			t.Errorf("Expected 10; Actual = %v", l.Len())
+		}
+	})
+
+	t.Run("should keep tags of items moving between tiers", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](1), New[int, int](10))
+		defer l.Close()
+
+		l.SetWithTags(1, 1, "a")
+		l.SetWithTags(2, 2, "b")
+		l.SetWithTags(3, 3, "a")
+		l.Get(1)
+
+		if actual := l.InvalidateTag("a"); !reflect.DeepEqual(2, actual) {
+			t.Errorf("Expected 2; Actual = %v", actual)
+		}
+
+		if !reflect.DeepEqual([]int{2}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{2}, l.Keys())
+		}
+	})
+}
@@ -0,0 +1,449 @@
+package lru
+
+import (
+	"iter"
+	"sync"
+	"time"
+)
+
+// tiered represents a two-tier cache.
+// Items evicted from the first, hot tier are demoted into the second, cold tier,
+// and items found in the second tier are promoted back into the first one.
+type tiered[K comparable, V any] struct {
+	hot        LRU[K, V]   // First tier holding the most recently used items.
+	cold       Cache[K, V] // Second tier holding the items demoted from the first tier.
+	hits       uint64      // Number of lookups which found the key in either tier.
+	misses     uint64      // Number of lookups which found the key in neither tier.
+	frozen     bool        // Flag set between Freeze and Thaw, while nothing is demoted.
+	sync.Mutex             // Mutex serializing moves between the tiers.
+}
+
+// Contains checks if the provided key is present in either tier.
+func (t *tiered[K, V]) Contains(key K) bool {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	return t.hot.Contains(key) || t.cold.Contains(key)
+}
+
+// Set adds or updates a key-value pair in the first tier.
+// If the first tier is full, its least recently used item is demoted into the second tier.
+func (t *tiered[K, V]) Set(key K, value V) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	t.set(key, value, nil)
+}
+
+// SetE adds or updates a key-value pair in the first tier like Set, returning ErrFull if the first tier did not store it.
+// Room is made in the first tier by demoting its least recently used item, so only items which cannot fit at all are rejected.
+func (t *tiered[K, V]) SetE(key K, value V) error {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	t.makeRoom(key)
+	return t.hot.SetE(key, value)
+}
+
+// SetNX stores the provided key-value pair in the first tier like Set, provided the key is missing from both tiers.
+// An existing item is neither promoted nor moved between the tiers.
+func (t *tiered[K, V]) SetNX(key K, value V) bool {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	if _, ok := t.peek(key); ok {
+		return false
+	}
+
+	t.set(key, value, nil)
+
+	return true
+}
+
+// GetOrSet returns the existing value for the key if present in either tier, otherwise it stores the provided value.
+// The loaded result is true if the value was loaded, false if it was stored.
+func (t *tiered[K, V]) GetOrSet(key K, value V) (V, bool) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	if actual, ok := t.get(key); ok {
+		return actual, true
+	}
+
+	t.set(key, value, nil)
+
+	return value, false
+}
+
+// GetOrCompute returns the existing value for the key if present in either tier, otherwise it stores the result of fn.
+// The loaded result is true if the value was loaded, false if it was computed.
+// The tiered cache is locked while fn runs.
+func (t *tiered[K, V]) GetOrCompute(key K, fn func() V) (V, bool) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	if actual, ok := t.get(key); ok {
+		return actual, true
+	}
+
+	value := fn()
+	t.set(key, value, nil)
+
+	return value, false
+}
+
+// Get retrieves the value associated with the provided key from either tier.
+// A hit in the second tier promotes the item back into the first tier.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (t *tiered[K, V]) Get(key K) (V, bool) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	return t.get(key)
+}
+
+// GetE retrieves the value associated with the provided key from either tier like Get, reporting a miss as an error:
+// ErrExpired if the item expired in the first tier and is not found in the second one, or ErrNotFound otherwise.
+func (t *tiered[K, V]) GetE(key K) (V, error) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	value, err := t.hot.GetE(key)
+	if err == nil {
+		t.hits++
+		return value, nil
+	}
+
+	if cold, ok := t.cold.Peek(key); ok {
+		t.hits++
+		t.promote(key, cold)
+		return cold, nil
+	}
+
+	t.misses++
+	return value, err
+}
+
+// Peek retrieves the value associated with the provided key from either tier without promoting it.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (t *tiered[K, V]) Peek(key K) (V, bool) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	return t.peek(key)
+}
+
+// peek retrieves the value associated with the provided key from either tier without promoting it.
+func (t *tiered[K, V]) peek(key K) (V, bool) {
+	if value, ok := t.hot.Peek(key); ok {
+		return value, true
+	}
+
+	return t.cold.Peek(key)
+}
+
+// GetOldest returns the oldest key-value pair of the second tier, or of the first tier when the second one is empty,
+// without removing or promoting it.
+// If the cache is empty, empty values and boolean false are returned.
+func (t *tiered[K, V]) GetOldest() (K, V, bool) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	if key, value, ok := t.coldOldest(); ok {
+		return key, value, true
+	}
+
+	return t.hot.GetOldest()
+}
+
+// RemoveOldest removes the oldest key-value pair of the second tier, or of the first tier when the second one is empty,
+// and returns it. If the cache is empty, empty values and boolean false are returned.
+func (t *tiered[K, V]) RemoveOldest() (K, V, bool) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	if key, value, ok := t.coldOldest(); ok {
+		t.cold.Del(key)
+		return key, value, true
+	}
+
+	return t.hot.RemoveOldest()
+}
+
+// Del removes the key-value pair associated with the provided key from both tiers.
+// If the key is found and the removal is successful, the function returns true.
+// If the key is not found, it returns false.
+func (t *tiered[K, V]) Del(key K) bool {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	hot := t.hot.Del(key)
+	cold := t.cold.Del(key)
+
+	return hot || cold
+}
+
+// Len returns the number of items currently stored in both tiers.
+func (t *tiered[K, V]) Len() int {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	return t.hot.Len() + t.cold.Len()
+}
+
+// Cap returns the maximum number of items both tiers can hold,
+// or Unbounded if either tier is unbounded.
+func (t *tiered[K, V]) Cap() int {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	hot, cold := t.hot.Cap(), t.cold.Cap()
+	if hot == Unbounded || cold == Unbounded {
+		return Unbounded
+	}
+
+	return hot + cold
+}
+
+// Purge removes all key-value pairs from both tiers.
+func (t *tiered[K, V]) Purge() {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	t.hot.Purge()
+	t.cold.Purge()
+}
+
+// Resize changes the maximum number of items the first tier can hold.
+// If the new size is smaller than the number of items in the first tier,
+// its least recently used items are demoted into the second tier until it fits.
+// The second tier is resized separately, through the cache passed to NewTiered.
+func (t *tiered[K, V]) Resize(size int) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	for size != Unbounded && t.hot.Len() > size {
+		t.demote()
+	}
+
+	t.hot.Resize(size)
+}
+
+// Keys returns a snapshot of the keys in the tiered cache,
+// the keys of the first tier followed by the keys of the second tier.
+func (t *tiered[K, V]) Keys() []K {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	return append(t.hot.Keys(), t.cold.Keys()...)
+}
+
+// Values returns a snapshot of the values in the tiered cache, in the same order as Keys.
+func (t *tiered[K, V]) Values() []V {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	return append(t.hot.Values(), t.cold.Values()...)
+}
+
+// All returns an iterator over the key-value pairs in the tiered cache, in the same order as Keys.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (t *tiered[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		forward(t.items())(yield)
+	}
+}
+
+// Backward returns an iterator over the key-value pairs in the tiered cache, in the reverse order of Keys.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (t *tiered[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		backward(t.items())(yield)
+	}
+}
+
+// items returns a snapshot of the keys and values in the tiered cache, in the same order as Keys.
+func (t *tiered[K, V]) items() ([]K, []V) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	var keys []K
+	var values []V
+	for _, tier := range []Cache[K, V]{t.hot, t.cold} {
+		for key, value := range tier.All() {
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+	}
+
+	return keys, values
+}
+
+// Stats returns the usage counters of the tiered cache.
+// Hits and misses count lookups of the cache as a whole, evictions count items leaving the second tier,
+// expirations count items expiring in either tier and pinned items are those of the first tier.
+func (t *tiered[K, V]) Stats() Stats {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	hot, cold := t.hot.Stats(), t.cold.Stats()
+
+	return Stats{
+		Hits:        t.hits,
+		Misses:      t.misses,
+		Evictions:   cold.Evictions,
+		Expirations: hot.Expirations + cold.Expirations,
+		Pinned:      hot.Pinned,
+		Rejections:  hot.Rejections + cold.Rejections,
+	}
+}
+
+// Events returns nil, events are delivered by the tiers themselves when they were created with WithEvents.
+func (t *tiered[K, V]) Events() <-chan Event[K, V] {
+	return nil
+}
+
+// Close stops any background goroutine owned by either tier.
+func (t *tiered[K, V]) Close() {
+	t.hot.Close()
+	t.cold.Close()
+}
+
+// get retrieves the value associated with the provided key, promoting a hit in the second tier into the first one.
+func (t *tiered[K, V]) get(key K) (V, bool) {
+	if value, ok := t.hot.Get(key); ok {
+		t.hits++
+		return value, true
+	}
+
+	value, ok := t.cold.Peek(key)
+	if !ok {
+		t.misses++
+		return value, false
+	}
+
+	t.hits++
+	t.promote(key, value)
+
+	return value, true
+}
+
+// set stores the provided key-value pair with the provided tags in the first tier,
+// demoting its least recently used item when it is full.
+func (t *tiered[K, V]) set(key K, value V, tags []string) {
+	t.makeRoom(key)
+	t.hot.SetWithTags(key, value, tags...)
+}
+
+// makeRoom prepares the first tier to store the provided key, removing it from the second tier
+// and demoting the least recently used item of the first tier when it is full.
+func (t *tiered[K, V]) makeRoom(key K) {
+	t.cold.Del(key)
+
+	size := t.hot.Cap()
+	if size > 0 && !t.frozen && !t.hot.Contains(key) && t.hot.Len() >= size {
+		t.demote()
+	}
+}
+
+// demote moves the least recently used item of the first tier into the second tier, along with its tags
+// and its remaining TTL if the second tier supports them.
+func (t *tiered[K, V]) demote() {
+	key, _, ok := t.hot.GetOldest()
+	if !ok {
+		return
+	}
+
+	e, _ := t.hot.GetEntry(key)
+	t.hot.RemoveOldest()
+
+	// the second tier does not track dependencies, dependent items are dropped
+	if len(e.DependsOn) > 0 {
+		return
+	}
+
+	ttl := until(t.hot, e.ExpiresAt)
+	if !e.ExpiresAt.IsZero() && ttl <= 0 {
+		return
+	}
+
+	if cold, ok := t.cold.(tagged[K, V]); ok {
+		cold.SetWithTags(key, e.Value, e.Tags...)
+		if cold, ok := t.cold.(interface {
+			UpdateTTL(key K, ttl time.Duration) bool
+		}); ok && !e.ExpiresAt.IsZero() {
+			cold.UpdateTTL(key, ttl)
+		}
+		return
+	}
+
+	if cold, ok := t.cold.(interface {
+		SetWithTTL(key K, value V, ttl time.Duration)
+	}); ok && !e.ExpiresAt.IsZero() {
+		cold.SetWithTTL(key, e.Value, ttl)
+		return
+	}
+
+	t.cold.Set(key, e.Value)
+}
+
+// promote stores the provided value for the provided key in the first tier like set, along with the tags
+// and the remaining TTL of the item stored for the key in the second tier.
+func (t *tiered[K, V]) promote(key K, value V) {
+	tags, ttl := t.coldMeta(key)
+	t.store(key, value, tags, ttl)
+}
+
+// store stores the provided value for the provided key in the first tier like set, along with the provided tags,
+// expiring it after the provided TTL if it is positive and the first tier supports TTLs.
+func (t *tiered[K, V]) store(key K, value V, tags []string, ttl time.Duration) {
+	t.set(key, value, tags)
+
+	if hot, ok := t.hot.(interface {
+		UpdateTTL(key K, ttl time.Duration) bool
+	}); ok && ttl > 0 {
+		hot.UpdateTTL(key, ttl)
+	}
+}
+
+// coldMeta returns the tags and the remaining TTL of the item stored for the provided key in the second tier,
+// if it supports them. Items without a TTL report a zero one.
+func (t *tiered[K, V]) coldMeta(key K) ([]string, time.Duration) {
+	cold, ok := t.cold.(tagged[K, V])
+	if !ok {
+		return nil, 0
+	}
+
+	e, _ := cold.GetEntry(key)
+	if e.ExpiresAt.IsZero() {
+		return e.Tags, 0
+	}
+
+	return e.Tags, max(until(t.cold, e.ExpiresAt), time.Nanosecond)
+}
+
+// until returns the duration until the provided instant according to the clock of the provided tier,
+// or to the wall clock for tiers which do not expose their clock.
+func until(tier any, deadline time.Time) time.Duration {
+	if c, ok := tier.(interface{ now() time.Time }); ok {
+		return deadline.Sub(c.now())
+	}
+
+	return time.Until(deadline)
+}
+
+// coldOldest returns the oldest key-value pair of the second tier without removing or promoting it.
+// Second tiers which do not track their oldest item report the last item of their Backward iterator.
+func (t *tiered[K, V]) coldOldest() (K, V, bool) {
+	if o, ok := t.cold.(interface{ GetOldest() (K, V, bool) }); ok {
+		return o.GetOldest()
+	}
+
+	for key, value := range t.cold.Backward() {
+		return key, value, true
+	}
+
+	var emptyKey K
+	var emptyVal V
+	return emptyKey, emptyVal, false
+}
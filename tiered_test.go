@@ -0,0 +1,149 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTiered(t *testing.T) {
+	t.Run("should demote evicted items into the second tier", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](2), New[int, int](2))
+		defer l.Close()
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+
+		if !reflect.DeepEqual([]int{3, 2, 1}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3, 2, 1}, l.Keys())
+		}
+
+		l.Set(4, 4)
+		l.Set(5, 5)
+
+		if !reflect.DeepEqual([]int{5, 4, 3, 2}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{5, 4, 3, 2}, l.Keys())
+		}
+
+		if !reflect.DeepEqual(Stats{Evictions: 1}, l.Stats()) {
+			t.Errorf("Expected %v; Actual = %v", Stats{Evictions: 1}, l.Stats())
+		}
+	})
+
+	t.Run("should demote items with their remaining TTL", func(t *testing.T) {
+		cold := NewWithExpiry[int, int](2)
+		l := NewTiered[int, int](New[int, int](1, WithDefaultTTL[int, int](time.Minute)), cold)
+		defer l.Close()
+
+		l.SetWithTags(1, 1, "page")
+		l.Set(2, 2)
+
+		e, ok := cold.GetEntry(1)
+		if !ok || e.ExpiresAt.IsZero() || e.ExpiresAt.After(time.Now().Add(time.Minute)) {
+			t.Errorf("Expected an expiry within a minute; Actual = %v", e.ExpiresAt)
+		}
+		if !reflect.DeepEqual([]string{"page"}, e.Tags) {
+			t.Errorf("Expected %v; Actual = %v", []string{"page"}, e.Tags)
+		}
+	})
+
+	t.Run("should measure the remaining TTL of demoted items with the clock of the tiers", func(t *testing.T) {
+		clock := NewFakeClock(time.Unix(0, 0))
+		cold := NewWithExpiry[int, int](2, WithClock[int, int](clock))
+		l := NewTiered[int, int](New[int, int](1, WithClock[int, int](clock), WithDefaultTTL[int, int](time.Minute)), cold)
+		defer l.Close()
+
+		l.Set(1, 1)
+		clock.Advance(20 * time.Second)
+		l.Set(2, 2)
+
+		e, ok := cold.GetEntry(1)
+		if expected := clock.Now().Add(40 * time.Second); !ok || !e.ExpiresAt.Equal(expected) {
+			t.Errorf("Expected %v; Actual = %v", expected, e.ExpiresAt)
+		}
+	})
+
+	t.Run("should promote items with their remaining TTL", func(t *testing.T) {
+		clock := NewFakeClock(time.Unix(0, 0))
+		cold := NewWithExpiry[int, int](2, WithClock[int, int](clock))
+		l := NewTiered[int, int](New[int, int](1, WithClock[int, int](clock)), cold)
+		defer l.Close()
+
+		cold.SetWithTTL(1, 1, time.Minute)
+		clock.Advance(50 * time.Second)
+
+		if actual, ok := l.Get(1); !ok || actual != 1 {
+			t.Errorf("Expected %v; Actual = %v", 1, actual)
+		}
+
+		clock.Advance(11 * time.Second)
+
+		if _, ok := l.Get(1); ok {
+			t.Errorf("Expected %v; Actual = %v", false, ok)
+		}
+	})
+
+	t.Run("should promote items found in the second tier", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](2), New[int, int](2))
+		defer l.Close()
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+
+		actual, ok := l.Get(1)
+		if !ok || actual != 1 {
+			t.Errorf("Expected 1; Actual = %v", actual)
+		}
+
+		if !reflect.DeepEqual([]int{1, 3, 2}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{1, 3, 2}, l.Keys())
+		}
+
+		l.Get(4)
+		if !reflect.DeepEqual(Stats{Hits: 1, Misses: 1}, l.Stats()) {
+			t.Errorf("Expected %v; Actual = %v", Stats{Hits: 1, Misses: 1}, l.Stats())
+		}
+	})
+
+	t.Run("should remove the oldest item of the second tier first", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](1), NewLFU[int, int](2))
+		defer l.Close()
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+
+		key, _, _ := l.RemoveOldest()
+		if !reflect.DeepEqual(1, key) {
+			t.Errorf("Expected 1; Actual = %v", key)
+		}
+
+		if !reflect.DeepEqual(true, l.Del(2)) {
+			t.Errorf("Expected true; Actual = %v", false)
+		}
+
+		if !reflect.DeepEqual([]int{3}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{3}, l.Keys())
+		}
+	})
+
+	t.Run("should demote items when the first tier shrinks", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](3), New[int, int](3))
+		defer l.Close()
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+		l.Resize(1)
+
+		if !reflect.DeepEqual(3, l.Len()) {
+			t.Errorf("Expected 3; Actual = %v", l.Len())
+		}
+
+		if !reflect.DeepEqual(4, l.Cap()) {
+			t.Errorf("Expected 4; Actual = %v", l.Cap())
+		}
+	})
+}
@@ -0,0 +1,40 @@
+// Package tlscache adapts a cache to crypto/tls, so it can bound the TLS sessions kept for resumption.
+package tlscache
+
+import (
+	"crypto/tls"
+
+	"github.com/vhndaree/lru"
+)
+
+// SessionCache is a tls.ClientSessionCache storing TLS sessions in a cache.
+type SessionCache struct {
+	cache lru.Cache[string, *tls.ClientSessionState] // Cache holding the sessions by session key.
+}
+
+// New creates a SessionCache storing TLS sessions in the provided cache, which bounds the number of sessions kept.
+//
+// Example usage:
+//
+//	config := &tls.Config{
+//		ClientSessionCache: tlscache.New(lru.New[string, *tls.ClientSessionState](64)),
+//	}
+func New(cache lru.Cache[string, *tls.ClientSessionState]) *SessionCache {
+	return &SessionCache{cache: cache}
+}
+
+// Get returns the session associated with the provided session key, promoting it in the cache.
+func (s *SessionCache) Get(sessionKey string) (*tls.ClientSessionState, bool) {
+	return s.cache.Get(sessionKey)
+}
+
+// Put stores the provided session under the provided session key.
+// A nil session removes the session key from the cache, as required by tls.ClientSessionCache.
+func (s *SessionCache) Put(sessionKey string, cs *tls.ClientSessionState) {
+	if cs == nil {
+		s.cache.Del(sessionKey)
+		return
+	}
+
+	s.cache.Set(sessionKey, cs)
+}
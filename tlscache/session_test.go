@@ -0,0 +1,61 @@
+package tlscache
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/vhndaree/lru"
+)
+
+var _ tls.ClientSessionCache = (*SessionCache)(nil)
+
+func TestSessionCache(t *testing.T) {
+	t.Run("should store and remove sessions", func(t *testing.T) {
+		cache := lru.New[string, *tls.ClientSessionState](2)
+		s := New(cache)
+
+		cs := &tls.ClientSessionState{}
+		s.Put("a", cs)
+
+		actual, ok := s.Get("a")
+		if !ok || actual != cs {
+			t.Errorf("Expected %v; Actual = %v", cs, actual)
+		}
+
+		s.Put("a", nil)
+		if !reflect.DeepEqual(0, cache.Len()) {
+			t.Errorf("Expected 0; Actual = %v", cache.Len())
+		}
+	})
+
+	t.Run("should resume TLS sessions", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS.DidResume {
+				w.Header().Set("X-Resumed", "true")
+			}
+		}))
+		defer server.Close()
+
+		cache := lru.New[string, *tls.ClientSessionState](2)
+		transport := server.Client().Transport.(*http.Transport)
+		transport.TLSClientConfig.ClientSessionCache = New(cache)
+
+		var resumed string
+		for i := 0; i < 2; i++ {
+			resp, err := server.Client().Get(server.URL)
+			if err != nil {
+				t.Fatalf("Expected no error; Actual = %v", err)
+			}
+			resp.Body.Close()
+			resumed = resp.Header.Get("X-Resumed")
+			transport.CloseIdleConnections()
+		}
+
+		if !reflect.DeepEqual("true", resumed) {
+			t.Errorf("Expected true; Actual = %v", resumed)
+		}
+	})
+}
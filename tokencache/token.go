@@ -0,0 +1,184 @@
+// Package tokencache caches an access token until it expires, refreshing it ahead of its expiry,
+// so services fetching tokens from an identity provider neither hammer it nor hand out expired tokens.
+package tokencache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/vhndaree/lru"
+)
+
+// DefaultRefreshMargin is how long before its expiry a token is refreshed in the background,
+// unless another margin is configured with WithRefreshMargin.
+const DefaultRefreshMargin = time.Minute
+
+// Fetcher fetches a new token, typically from an identity provider.
+type Fetcher[T any] func(ctx context.Context) (T, error)
+
+// key is the key of the single token in the underlying cache.
+type key struct{}
+
+// token is a fetched token along with when it expires and when it should be refreshed.
+type token[T any] struct {
+	value   T         // Token returned by the fetcher.
+	expiry  time.Time // When the token expires, zero if it never does.
+	refresh time.Time // When the token is refreshed in the background, zero if it never is.
+	once    sync.Once // Guards applying the expiry of the token to the cached item once it is stored.
+}
+
+// Cache caches the token returned by a Fetcher. Concurrent callers missing the token share a single fetch.
+type Cache[T any] struct {
+	cache      lru.LoadingLRU[key, *token[T]] // Cache holding the current token.
+	fetch      Fetcher[T]                     // Fetcher of new tokens.
+	expiry     func(T) time.Time              // Returns when a token expires.
+	margin     time.Duration                  // How long before its expiry a token is refreshed.
+	now        func() time.Time               // Returns the current time of the clock.
+	clock      lru.Clock                      // Clock expiring the token, nil for the system clock.
+	refreshing atomic.Bool                    // Flag set while a background refresh is running.
+}
+
+// Option configures optional behaviour of a Cache at construction time.
+type Option func(*options)
+
+// options holds the optional behaviour of a Cache.
+type options struct {
+	margin time.Duration // How long before its expiry a token is refreshed.
+	clock  lru.Clock     // Clock expiring the token, nil for the system clock.
+}
+
+// WithRefreshMargin configures how long before its expiry a token is refreshed in the background.
+// The margin is capped to half the lifetime of every token, so short-lived tokens are not refreshed constantly.
+// A margin of zero only fetches a new token once the current one has expired.
+func WithRefreshMargin(margin time.Duration) Option {
+	return func(o *options) {
+		o.margin = max(margin, 0)
+	}
+}
+
+// WithClock configures the clock expiring the token, such as an lru.FakeClock in tests, instead of the system clock.
+func WithClock(clock lru.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// New creates a Cache fetching tokens with the provided fetcher, which expire at the time returned by expiry.
+// A token whose expiry is the zero time never expires.
+// Optional behaviour can be configured by passing one or more Option values.
+// Call Close once the cache is no longer needed to stop its expiry cleaner.
+//
+// Example usage:
+//
+//	tokens := tokencache.New(func(ctx context.Context) (*oauth2.Token, error) {
+//		return config.Token(ctx)
+//	}, func(t *oauth2.Token) time.Time {
+//		return t.Expiry
+//	})
+//	defer tokens.Close()
+//	tok, err := tokens.Get(ctx)
+func New[T any](fetch Fetcher[T], expiry func(T) time.Time, opts ...Option) *Cache[T] {
+	o := options{margin: DefaultRefreshMargin}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &Cache[T]{
+		fetch:  fetch,
+		expiry: expiry,
+		margin: o.margin,
+		now:    time.Now,
+	}
+
+	var cacheOpts []lru.Option[key, *token[T]]
+	if o.clock != nil {
+		c.now = o.clock.Now
+		cacheOpts = append(cacheOpts, lru.WithClock[key, *token[T]](o.clock))
+	}
+	c.cache = lru.NewLoading(1, c.load, cacheOpts...)
+
+	return c
+}
+
+// load fetches a new token and works out when it expires and when it should be refreshed.
+func (c *Cache[T]) load(ctx context.Context, _ key) (*token[T], error) {
+	value, err := c.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &token[T]{value: value, expiry: c.expiry(value)}
+	if !out.expiry.IsZero() && c.margin > 0 {
+		lifetime := out.expiry.Sub(c.now())
+		out.refresh = out.expiry.Add(-min(c.margin, lifetime/2))
+	}
+
+	return out, nil
+}
+
+// Get returns the current token, fetching a new one if there is none or it has expired.
+// Once the token is within the refresh margin of its expiry, it is still returned while a new one is fetched
+// in the background; if that fetch fails, the next Get retries it. Errors of the fetcher are returned as is.
+func (c *Cache[T]) Get(ctx context.Context) (T, error) {
+	tok, err := c.cache.Load(ctx, key{})
+	if err != nil {
+		var emptyVal T
+		return emptyVal, err
+	}
+
+	// the loading cache stores tokens without a TTL, the first caller makes it expire with the token
+	tok.once.Do(func() {
+		c.expire(tok)
+	})
+
+	if !tok.refresh.IsZero() && !c.now().Before(tok.refresh) && c.refreshing.CompareAndSwap(false, true) {
+		go c.refreshAhead()
+	}
+
+	return tok.value, nil
+}
+
+// expire makes the cached item of the provided token expire along with the token.
+func (c *Cache[T]) expire(tok *token[T]) {
+	if tok.expiry.IsZero() {
+		return
+	}
+
+	if ttl := tok.expiry.Sub(c.now()); ttl > 0 {
+		c.cache.UpdateTTL(key{}, ttl)
+	} else {
+		c.cache.Del(key{})
+	}
+}
+
+// refreshAhead fetches a new token in the background and stores it in place of the current one.
+func (c *Cache[T]) refreshAhead() {
+	defer c.refreshing.Store(false)
+
+	tok, err := c.load(context.Background(), key{})
+	if err != nil {
+		return
+	}
+
+	tok.once.Do(func() {})
+	if tok.expiry.IsZero() {
+		c.cache.Set(key{}, tok)
+		return
+	}
+
+	if ttl := tok.expiry.Sub(c.now()); ttl > 0 {
+		c.cache.SetWithTTL(key{}, tok, ttl)
+	}
+}
+
+// Invalidate drops the current token, such as after the server rejected it, so the next Get fetches a new one.
+func (c *Cache[T]) Invalidate() {
+	c.cache.Del(key{})
+}
+
+// Close stops the expiry cleaner of the cache. It is safe to call Close more than once.
+func (c *Cache[T]) Close() {
+	c.cache.Close()
+}
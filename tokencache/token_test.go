@@ -0,0 +1,132 @@
+package tokencache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vhndaree/lru"
+)
+
+// fakeToken is a token expiring at a fixed time.
+type fakeToken struct {
+	value  string
+	expiry time.Time
+}
+
+// provider issues numbered tokens valid for its lifetime.
+type provider struct {
+	clock    *lru.FakeClock
+	lifetime time.Duration
+	fetches  atomic.Int32
+	err      error
+}
+
+func (p *provider) fetch(context.Context) (fakeToken, error) {
+	n := p.fetches.Add(1)
+	if p.err != nil {
+		return fakeToken{}, p.err
+	}
+
+	return fakeToken{value: fmt.Sprintf("token-%d", n), expiry: p.clock.Now().Add(p.lifetime)}, nil
+}
+
+func expiryOf(t fakeToken) time.Time {
+	return t.expiry
+}
+
+func TestCache(t *testing.T) {
+	t.Run("should cache the token until it expires", func(t *testing.T) {
+		p := &provider{clock: lru.NewFakeClock(time.Now()), lifetime: time.Hour}
+		c := New(p.fetch, expiryOf, WithRefreshMargin(0), WithClock(p.clock))
+		defer c.Close()
+
+		for i := 0; i < 3; i++ {
+			tok, err := c.Get(context.Background())
+			if err != nil || !reflect.DeepEqual("token-1", tok.value) {
+				t.Errorf("Expected %v; Actual = %v %v", "token-1", tok.value, err)
+			}
+		}
+
+		p.clock.Advance(time.Hour + time.Second)
+		if tok, _ := c.Get(context.Background()); !reflect.DeepEqual("token-2", tok.value) {
+			t.Errorf("Expected %v; Actual = %v", "token-2", tok.value)
+		}
+	})
+
+	t.Run("should refresh the token ahead of its expiry", func(t *testing.T) {
+		p := &provider{clock: lru.NewFakeClock(time.Now()), lifetime: time.Hour}
+		c := New(p.fetch, expiryOf, WithRefreshMargin(5*time.Minute), WithClock(p.clock))
+		defer c.Close()
+
+		c.Get(context.Background())
+		p.clock.Advance(56 * time.Minute)
+
+		if tok, _ := c.Get(context.Background()); !reflect.DeepEqual("token-1", tok.value) {
+			t.Errorf("Expected the current token while refreshing; Actual = %v", tok.value)
+		}
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			tok, _ := c.Get(context.Background())
+			if tok.value == "token-2" {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected the token to be refreshed; Actual = %v", tok.value)
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		p.clock.Advance(10 * time.Minute)
+		if tok, _ := c.Get(context.Background()); !reflect.DeepEqual("token-2", tok.value) {
+			t.Errorf("Expected the refreshed token to outlive the first one; Actual = %v", tok.value)
+		}
+	})
+
+	t.Run("should share a single fetch between concurrent callers", func(t *testing.T) {
+		p := &provider{clock: lru.NewFakeClock(time.Now()), lifetime: time.Hour}
+		c := New(p.fetch, expiryOf, WithClock(p.clock))
+		defer c.Close()
+
+		var wg sync.WaitGroup
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.Get(context.Background())
+			}()
+		}
+		wg.Wait()
+
+		if !reflect.DeepEqual(int32(1), p.fetches.Load()) {
+			t.Errorf("Expected %v; Actual = %v", 1, p.fetches.Load())
+		}
+	})
+
+	t.Run("should return fetch errors and retry", func(t *testing.T) {
+		errDown := errors.New("identity provider down")
+		p := &provider{clock: lru.NewFakeClock(time.Now()), lifetime: time.Hour, err: errDown}
+		c := New(p.fetch, expiryOf, WithClock(p.clock))
+		defer c.Close()
+
+		if _, err := c.Get(context.Background()); !errors.Is(err, errDown) {
+			t.Errorf("Expected %v; Actual = %v", errDown, err)
+		}
+
+		p.err = nil
+		if tok, err := c.Get(context.Background()); err != nil || !reflect.DeepEqual("token-2", tok.value) {
+			t.Errorf("Expected %v; Actual = %v %v", "token-2", tok.value, err)
+		}
+
+		c.Invalidate()
+		if tok, _ := c.Get(context.Background()); !reflect.DeepEqual("token-3", tok.value) {
+			t.Errorf("Expected %v; Actual = %v", "token-3", tok.value)
+		}
+	})
+}
@@ -0,0 +1,297 @@
+package lru
+
+import (
+	"iter"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRecentRatio is the share of a 2Q cache reserved for recently added items.
+	DefaultRecentRatio = 0.25
+
+	// DefaultGhostRatio is the number of evicted keys a 2Q cache remembers, relative to its size.
+	DefaultGhostRatio = 0.50
+)
+
+// twoQueue represents a 2Q cache.
+// New items land in a probationary FIFO queue and are only promoted to the protected LRU queue
+// when they are accessed again, so a single scan over many keys cannot flush the frequently used items.
+// Keys recently evicted from the probationary queue are remembered in a ghost queue,
+// and are admitted straight into the protected queue when they are set again.
+type twoQueue[K comparable, V any] struct {
+	size        int               // Maximum number of items the cache can hold.
+	recentRatio float64           // Share of the size reserved for the probationary queue.
+	ghostRatio  float64           // Size of the ghost queue relative to the cache size.
+	recentSize  int               // Target number of items in the probationary queue.
+	recent      *lru[K, V]        // Probationary FIFO queue of items seen once.
+	frequent    *lru[K, V]        // Protected LRU queue of items seen more than once.
+	ghost       *lru[K, struct{}] // Keys recently evicted from the probationary queue.
+	stats       Stats             // Usage counters of the cache.
+	sync.Mutex                    // Mutex for concurrent access.
+}
+
+// Contains checks if the provided key is present in the 2Q cache.
+// The function does not affect the cache's state or modify any data.
+func (q *twoQueue[K, V]) Contains(key K) bool {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	return q.frequent.Contains(key) || q.recent.Contains(key)
+}
+
+// Set adds or updates a key-value pair in the 2Q cache.
+// Updating a key that is already cached promotes it to the protected queue.
+// New keys enter the probationary queue, unless they were recently evicted from it.
+func (q *twoQueue[K, V]) Set(key K, value V) {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	var expiry time.Time
+
+	if c, ok := q.frequent.cache[key]; ok {
+		c.value = value
+		q.frequent.moveToFront(c)
+		return
+	}
+
+	if q.recent.Contains(key) {
+		q.recent.del(key)
+		q.frequent.set(key, value, expiry)
+		return
+	}
+
+	if q.size <= 0 {
+		return
+	}
+
+	if q.ghost.Contains(key) {
+		q.ensureSpace(true)
+		q.ghost.del(key)
+		q.frequent.set(key, value, expiry)
+		return
+	}
+
+	q.ensureSpace(false)
+	q.recent.set(key, value, expiry)
+}
+
+// Get retrieves the value associated with the provided key from the 2Q cache.
+// A hit in the probationary queue promotes the item to the protected queue,
+// a hit in the protected queue moves it to the head of that queue.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (q *twoQueue[K, V]) Get(key K) (V, bool) {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	if c, ok := q.frequent.cache[key]; ok {
+		q.stats.Hits++
+		q.frequent.moveToFront(c)
+		return c.value, true
+	}
+
+	if c, ok := q.recent.cache[key]; ok {
+		var expiry time.Time
+		value := c.value
+		q.stats.Hits++
+		q.recent.del(key)
+		q.frequent.set(key, value, expiry)
+		return value, true
+	}
+
+	q.stats.Misses++
+
+	var emptyVal V
+	return emptyVal, false
+}
+
+// Peek retrieves the value associated with the provided key from the 2Q cache
+// without promoting it.
+// If the key is not found in the cache, an empty value and boolean false are returned.
+func (q *twoQueue[K, V]) Peek(key K) (V, bool) {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	if c, ok := q.frequent.cache[key]; ok {
+		return c.value, true
+	}
+
+	if c, ok := q.recent.cache[key]; ok {
+		return c.value, true
+	}
+
+	var emptyVal V
+	return emptyVal, false
+}
+
+// Del removes the key-value pair associated with the provided key from the 2Q cache.
+// If the key is found and the removal is successful, the function returns true.
+// If the key is not found, it returns false.
+func (q *twoQueue[K, V]) Del(key K) bool {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	q.ghost.del(key)
+	return q.frequent.del(key) || q.recent.del(key)
+}
+
+// Len returns the number of items currently stored in the 2Q cache.
+func (q *twoQueue[K, V]) Len() int {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	return q.recent.length + q.frequent.length
+}
+
+// Cap returns the maximum number of items the 2Q cache can hold.
+func (q *twoQueue[K, V]) Cap() int {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	return q.size
+}
+
+// Purge removes all key-value pairs from the 2Q cache, including the remembered ghost keys.
+func (q *twoQueue[K, V]) Purge() {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	q.recent.purge()
+	q.frequent.purge()
+	q.ghost.purge()
+}
+
+// Resize changes the maximum number of items the 2Q cache can hold,
+// keeping the configured queue ratios.
+// If the new size is smaller than the current number of items,
+// items are evicted following the 2Q policy until the cache fits.
+func (q *twoQueue[K, V]) Resize(size int) {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	q.resize(size)
+	for q.recent.length+q.frequent.length > q.size {
+		q.ensureSpace(false)
+	}
+}
+
+// Keys returns a snapshot of the keys in the 2Q cache.
+// Keys of the protected queue come first, followed by the keys of the probationary queue,
+// each ordered from the most recently used to the least recently used.
+func (q *twoQueue[K, V]) Keys() []K {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	out := make([]K, 0, q.recent.length+q.frequent.length)
+	for _, l := range []*lru[K, V]{q.frequent, q.recent} {
+		for h := l.front(); h != nil; h = l.next(h) {
+			out = append(out, h.key)
+		}
+	}
+
+	return out
+}
+
+// Values returns a snapshot of the values in the 2Q cache, in the same order as Keys.
+func (q *twoQueue[K, V]) Values() []V {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	out := make([]V, 0, q.recent.length+q.frequent.length)
+	for _, l := range []*lru[K, V]{q.frequent, q.recent} {
+		for h := l.front(); h != nil; h = l.next(h) {
+			out = append(out, h.value)
+		}
+	}
+
+	return out
+}
+
+// All returns an iterator over the key-value pairs in the 2Q cache, in the same order as Keys.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (q *twoQueue[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		forward(q.items())(yield)
+	}
+}
+
+// Backward returns an iterator over the key-value pairs in the 2Q cache, in the reverse order of Keys.
+// The iterator ranges over a snapshot taken under the lock when iteration starts.
+func (q *twoQueue[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		backward(q.items())(yield)
+	}
+}
+
+// items returns a snapshot of the keys and values in the 2Q cache, in the same order as Keys.
+func (q *twoQueue[K, V]) items() ([]K, []V) {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	keys := make([]K, 0, q.recent.length+q.frequent.length)
+	values := make([]V, 0, q.recent.length+q.frequent.length)
+	for _, l := range []*lru[K, V]{q.frequent, q.recent} {
+		for h := l.front(); h != nil; h = l.next(h) {
+			keys = append(keys, h.key)
+			values = append(values, h.value)
+		}
+	}
+
+	return keys, values
+}
+
+// Stats returns a snapshot of the usage counters of the 2Q cache.
+func (q *twoQueue[K, V]) Stats() Stats {
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	return q.stats
+}
+
+// Close is a no-op, the 2Q cache owns no background goroutines.
+func (q *twoQueue[K, V]) Close() {}
+
+// resize updates the total size and the derived queue sizes.
+func (q *twoQueue[K, V]) resize(size int) {
+	q.size = size
+	q.recentSize = int(float64(size) * q.recentRatio)
+
+	ghostSize := int(float64(size) * q.ghostRatio)
+	if ghostSize < 1 {
+		ghostSize = 1
+	}
+
+	q.recent.size = size
+	q.frequent.size = size
+	for q.ghost.length > ghostSize {
+		q.ghost.del(q.ghost.back().key)
+	}
+	q.ghost.size = ghostSize
+}
+
+// ensureSpace evicts one item when the cache is full.
+// The probationary queue gives up its oldest item while it is over its target size,
+// that key is remembered in the ghost queue. Otherwise the protected queue evicts its least recently used item.
+// The ghostHit flag signals that the incoming key comes from the ghost queue and will join the protected queue.
+func (q *twoQueue[K, V]) ensureSpace(ghostHit bool) {
+	if q.recent.length+q.frequent.length < q.size {
+		return
+	}
+
+	q.stats.Evictions++
+
+	if q.recent.length > 0 && (q.recent.length > q.recentSize || (q.recent.length == q.recentSize && !ghostHit)) {
+		var expiry time.Time
+		key := q.recent.back().key
+		q.recent.del(key)
+		q.ghost.set(key, struct{}{}, expiry)
+		return
+	}
+
+	if q.frequent.length > 0 {
+		q.frequent.del(q.frequent.back().key)
+		return
+	}
+
+	q.recent.del(q.recent.back().key)
+}
@@ -0,0 +1,96 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTwoQueue(t *testing.T) {
+	t.Run("should promote items accessed twice", func(t *testing.T) {
+		l := NewTwoQueue[int, int](4, DefaultRecentRatio, DefaultGhostRatio)
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Get(1)
+
+		expected := []int{1, 2}
+		if !reflect.DeepEqual(expected, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", expected, l.Keys())
+		}
+
+		q := l.(*twoQueue[int, int])
+		if !q.frequent.Contains(1) || !q.recent.Contains(2) {
+			t.Errorf("Expected key 1 to be protected and key 2 to be probationary")
+		}
+	})
+
+	t.Run("should keep the value of promoted items", func(t *testing.T) {
+		l := NewTwoQueue[int, string](4, DefaultRecentRatio, DefaultGhostRatio)
+		l.Set(1, "one")
+
+		for range 2 {
+			value, ok := l.Get(1)
+			if !ok || !reflect.DeepEqual("one", value) {
+				t.Errorf("Expected %v; Actual = %v", "one", value)
+			}
+		}
+	})
+
+	t.Run("should resist scans", func(t *testing.T) {
+		l := NewTwoQueue[int, int](4, DefaultRecentRatio, DefaultGhostRatio)
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Get(1)
+		l.Get(2)
+
+		for i := 10; i < 20; i++ {
+			l.Set(i, i)
+		}
+
+		if !l.Contains(1) || !l.Contains(2) {
+			t.Errorf("Expected frequently used keys to survive a scan")
+		}
+
+		if !reflect.DeepEqual(4, l.Len()) {
+			t.Errorf("Expected 4; Actual = %v", l.Len())
+		}
+	})
+
+	t.Run("should admit ghost keys into the protected queue", func(t *testing.T) {
+		l := NewTwoQueue[int, int](2, 0.5, 1)
+
+		l.Set(1, 1)
+		l.Set(2, 2)
+		l.Set(3, 3)
+
+		if l.Contains(1) {
+			t.Errorf("Expected key 1 to be evicted")
+		}
+
+		l.Set(1, 1)
+
+		q := l.(*twoQueue[int, int])
+		if !q.frequent.Contains(1) {
+			t.Errorf("Expected key 1 to be protected")
+		}
+	})
+
+	t.Run("should shrink on resize", func(t *testing.T) {
+		l := NewTwoQueue[int, int](4, DefaultRecentRatio, DefaultGhostRatio)
+
+		for i := 0; i < 4; i++ {
+			l.Set(i, i)
+		}
+		l.Resize(2)
+
+		if !reflect.DeepEqual(2, l.Len()) {
+			t.Errorf("Expected 2; Actual = %v", l.Len())
+		}
+
+		l.Purge()
+		if !reflect.DeepEqual(0, l.Len()) {
+			t.Errorf("Expected 0; Actual = %v", l.Len())
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package lru
+
+import "time"
+
+// GetWithVersion retrieves the value associated with the provided key like Get, along with its version.
+// Every write of a key gives its value a new version, greater than any version handed out before by the cache,
+// so a key which was deleted and stored again never reuses a version.
+// If the key is not found in the cache, or its TTL has passed, an empty value, zero and boolean false are returned.
+//
+// Example usage:
+//
+//	for {
+//		counter, version, _ := cache.GetWithVersion("visits")
+//		if cache.SetIfVersion("visits", counter+1, version) {
+//			break
+//		}
+//	}
+func (l *lru[K, V]) GetWithVersion(key K) (V, uint64, bool) {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.drainAccesses()
+
+	c, ok := l.lookup(key)
+	if !ok {
+		l.stats.Misses++
+
+		var emptyVal V
+		return emptyVal, 0, false
+	}
+
+	l.hit(c)
+
+	return c.value, c.version, true
+}
+
+// SetIfVersion stores the provided value like Set, provided the version of the value currently stored for the key
+// is the provided version, as returned by GetWithVersion, or the key is missing and the provided version is zero.
+// It reports whether it stored the value, so concurrent writers of a key can retry instead of losing updates.
+func (l *lru[K, V]) SetIfVersion(key K, value V, version uint64) bool {
+	key = l.canonical(key)
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	var current uint64
+	if c, ok := l.lookup(key); ok {
+		current = c.version
+	}
+	if current != version {
+		return false
+	}
+
+	var expiry time.Time
+	return l.set(key, value, expiry)
+}
+
+// GetWithVersion retrieves the value associated with the provided key from its shard, along with its version.
+func (s *sharded[K, V]) GetWithVersion(key K) (V, uint64, bool) {
+	return s.shard(key).GetWithVersion(key)
+}
+
+// SetIfVersion stores the provided value in the shard responsible for the key, provided the version of its value is the provided one.
+func (s *sharded[K, V]) SetIfVersion(key K, value V, version uint64) bool {
+	return s.shard(key).SetIfVersion(key, value, version)
+}
+
+// GetWithVersion retrieves the value associated with the provided key from either tier like Get, along with its version.
+// Versions are those of the first tier, a value promoted from the second tier gets a new one.
+func (t *tiered[K, V]) GetWithVersion(key K) (V, uint64, bool) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	value, ok := t.get(key)
+	if !ok {
+		return value, 0, false
+	}
+
+	e, _ := t.hot.GetEntry(key)
+	return value, e.Version, true
+}
+
+// SetIfVersion stores the provided value in the first tier, provided the version of the value stored for the key is the provided one.
+// A value found in the second tier is promoted into the first tier first, which gives it a new version.
+func (t *tiered[K, V]) SetIfVersion(key K, value V, version uint64) bool {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	if cold, ok := t.cold.Peek(key); ok && !t.hot.Contains(key) {
+		t.promote(key, cold)
+	}
+
+	e, _ := t.hot.GetEntry(key)
+	if e.Version != version {
+		return false
+	}
+
+	t.makeRoom(key)
+	return t.hot.SetIfVersion(key, value, version)
+}
@@ -0,0 +1,74 @@
+package lru
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	t.Run("should store values only at the expected version", func(t *testing.T) {
+		l := New[string, int](2)
+
+		if !l.SetIfVersion("a", 1, 0) || l.SetIfVersion("a", 2, 0) {
+			t.Errorf("Expected only the first insert to succeed")
+		}
+
+		value, version, ok := l.GetWithVersion("a")
+		if !ok || !reflect.DeepEqual(1, value) || version == 0 {
+			t.Errorf("Expected %v; Actual = %v %v %v", 1, value, version, ok)
+		}
+
+		l.Set("a", 3)
+		if l.SetIfVersion("a", 4, version) {
+			t.Errorf("Expected a stale version to be rejected")
+		}
+
+		_, latest, _ := l.GetWithVersion("a")
+		if latest <= version || !l.SetIfVersion("a", 4, latest) {
+			t.Errorf("Expected the latest version %v to be accepted", latest)
+		}
+	})
+
+	t.Run("should never reuse the version of a deleted key", func(t *testing.T) {
+		l := New[string, int](2)
+		l.Set("a", 1)
+		_, version, _ := l.GetWithVersion("a")
+
+		l.Del("a")
+		l.Set("a", 1)
+
+		if l.SetIfVersion("a", 2, version) {
+			t.Errorf("Expected the version of the deleted key to be rejected")
+		}
+		if _, _, ok := l.GetWithVersion("b"); ok {
+			t.Errorf("Expected %v to be missing", "b")
+		}
+	})
+
+	t.Run("should not lose concurrent updates", func(t *testing.T) {
+		for _, l := range []LRU[string, int]{New[string, int](2), NewSharded[string, int](4, 2), NewTiered[string, int](New[string, int](1), New[string, int](1))} {
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 100; j++ {
+						for {
+							counter, version, _ := l.GetWithVersion("visits")
+							if l.SetIfVersion("visits", counter+1, version) {
+								break
+							}
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			if value, _ := l.Get("visits"); !reflect.DeepEqual(800, value) {
+				t.Errorf("Expected %v; Actual = %v", 800, value)
+			}
+			l.Close()
+		}
+	})
+}
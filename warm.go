@@ -0,0 +1,94 @@
+package lru
+
+// Warm stores the provided entries in the LRU cache in a single lock acquisition, such as when seeding it at boot
+// from a database or restoring it from Snapshot. The entries are ordered like Snapshot, from the most recently used
+// to the least recently used, and keep that order in the cache; when they do not all fit, the first ones are kept.
+//
+// Each entry keeps its expiry time, tags, dependencies, creation and access times and access count when set.
+// Entries without an expiry time get the default TTL like Set, and entries which already expired are skipped.
+// Warming does not invoke the eviction callback, publish events nor demote items, whether for the entries stored,
+// the values they replace or the items they evict; the values are not written to a backing store either.
+//
+// Example usage:
+//
+//	cache.Warm(backup.Snapshot())
+func (l *lru[K, V]) Warm(entries []Entry[K, V]) {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	onEvict, events, demoter := l.onEvict, l.events, l.demoter
+	l.onEvict, l.events, l.demoter = nil, nil, nil
+	defer func() {
+		l.onEvict, l.events, l.demoter = onEvict, events, demoter
+	}()
+
+	now := l.now()
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if !e.ExpiresAt.IsZero() && e.ExpiresAt.Before(now) {
+			continue
+		}
+
+		key := l.canonical(e.Key)
+		l.set(key, e.Value, e.ExpiresAt)
+
+		c, ok := l.cache[key]
+		if !ok {
+			continue
+		}
+
+		l.tag(c, e.Tags)
+		l.depend(key, e.DependsOn)
+		if !e.CreatedAt.IsZero() {
+			c.created = e.CreatedAt.UnixNano()
+		}
+		if !e.LastAccessedAt.IsZero() {
+			c.accessed = e.LastAccessedAt.UnixNano()
+		}
+		c.hits = e.AccessCount
+	}
+}
+
+// Warm stores the provided entries in their shards, locking every shard once.
+// The relative order of the entries of each shard is preserved.
+func (s *sharded[K, V]) Warm(entries []Entry[K, V]) {
+	groups := make([][]Entry[K, V], len(s.shards))
+	for _, e := range entries {
+		i := s.index(e.Key)
+		groups[i] = append(groups[i], e)
+	}
+
+	for i, group := range groups {
+		if len(group) > 0 {
+			s.shards[i].Warm(group)
+		}
+	}
+}
+
+// Warm stores the provided entries in the tiered cache, ordered like Snapshot.
+// The first tier is warmed with as many of the first entries as it holds and the second tier with the rest,
+// which is stored with Set, oldest first, when the second tier cannot be warmed itself.
+// Items of the first tier evicted to make room for the entries are dropped rather than demoted.
+func (t *tiered[K, V]) Warm(entries []Entry[K, V]) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	n := len(entries)
+	if size := t.hot.Cap(); size > 0 {
+		n = min(n, size)
+	}
+	for _, e := range entries[:n] {
+		t.cold.Del(e.Key)
+	}
+	t.hot.Warm(entries[:n])
+
+	rest := entries[n:]
+	if cold, ok := t.cold.(interface{ Warm(entries []Entry[K, V]) }); ok {
+		cold.Warm(rest)
+		return
+	}
+
+	for i := len(rest) - 1; i >= 0; i-- {
+		t.cold.Set(rest[i].Key, rest[i].Value)
+	}
+}
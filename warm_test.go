@@ -0,0 +1,103 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWarm(t *testing.T) {
+	t.Run("should preserve the order of the entries", func(t *testing.T) {
+		src := New[int, int](3)
+		src.Set(1, 1)
+		src.Set(2, 2)
+		src.Set(3, 3)
+		src.Get(1)
+
+		l := New[int, int](3)
+		l.Warm(src.Snapshot())
+
+		if !reflect.DeepEqual([]int{1, 3, 2}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{1, 3, 2}, l.Keys())
+		}
+		if !reflect.DeepEqual(uint64(0), l.Stats().Hits) {
+			t.Errorf("Expected %v; Actual = %v", 0, l.Stats().Hits)
+		}
+	})
+
+	t.Run("should keep the first entries when they do not fit", func(t *testing.T) {
+		l := New[int, int](2)
+		l.Warm([]Entry[int, int]{{Key: 1, Value: 1}, {Key: 2, Value: 2}, {Key: 3, Value: 3}})
+
+		if !reflect.DeepEqual([]int{1, 2}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{1, 2}, l.Keys())
+		}
+	})
+
+	t.Run("should keep metadata and skip expired entries", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		l := NewWithExpiry[int, int](3, WithClock[int, int](clock))
+		defer l.Close()
+
+		created := clock.Now().Add(-time.Hour)
+		l.Warm([]Entry[int, int]{
+			{Key: 1, Value: 1, CreatedAt: created, AccessCount: 7, ExpiresAt: clock.Now().Add(time.Minute), Tags: []string{"a"}},
+			{Key: 2, Value: 2, ExpiresAt: clock.Now().Add(-time.Minute)},
+		})
+
+		e, ok := l.GetEntry(1)
+		if !ok || !e.CreatedAt.Equal(created) || e.AccessCount != 7 || !reflect.DeepEqual([]string{"a"}, e.Tags) {
+			t.Errorf("Expected the metadata to be kept; Actual = %v", e)
+		}
+		if ttl, _ := l.GetTTL(1); !reflect.DeepEqual(time.Minute, ttl) {
+			t.Errorf("Expected %v; Actual = %v", time.Minute, ttl)
+		}
+		if l.Contains(2) {
+			t.Errorf("Expected expired key 2 to be skipped")
+		}
+	})
+
+	t.Run("should not invoke callbacks nor publish events", func(t *testing.T) {
+		evictions := 0
+		l := NewWithExpiry[int, int](1, WithEvents[int, int](10), WithOnEvict(func(int, int, Reason) {
+			evictions++
+		}))
+		defer l.Close()
+
+		l.Set(1, 1)
+		l.Warm([]Entry[int, int]{{Key: 2, Value: 2}, {Key: 1, Value: 10}})
+
+		if !reflect.DeepEqual(0, evictions) || !reflect.DeepEqual(0, len(l.Events())) {
+			t.Errorf("Expected %v; Actual = %v %v", 0, evictions, len(l.Events()))
+		}
+
+		l.Set(3, 3)
+		if !reflect.DeepEqual(1, evictions) {
+			t.Errorf("Expected %v; Actual = %v", 1, evictions)
+		}
+	})
+
+	t.Run("should warm every shard", func(t *testing.T) {
+		l := NewSharded[int, int](100, 4)
+		entries := []Entry[int, int]{}
+		for i := range 20 {
+			entries = append(entries, Entry[int, int]{Key: i, Value: i})
+		}
+		l.Warm(entries)
+
+		if !reflect.DeepEqual(20, l.Len()) {
+			t.Errorf("Expected %v; Actual = %v", 20, l.Len())
+		}
+	})
+
+	t.Run("should spread the entries over both tiers", func(t *testing.T) {
+		l := NewTiered[int, int](New[int, int](1), New[int, int](2))
+		defer l.Close()
+
+		l.Warm([]Entry[int, int]{{Key: 1, Value: 1}, {Key: 2, Value: 2}, {Key: 3, Value: 3}})
+
+		if !reflect.DeepEqual([]int{1, 2, 3}, l.Keys()) {
+			t.Errorf("Expected %v; Actual = %v", []int{1, 2, 3}, l.Keys())
+		}
+	})
+}
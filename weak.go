@@ -0,0 +1,173 @@
+//go:build go1.24
+
+package lru
+
+import (
+	"context"
+	"runtime"
+	"weak"
+)
+
+// Weak represents a read-through Least Recently Used (LRU) cache holding its values through weak pointers,
+// so the garbage collector may reclaim them under memory pressure, as soon as nothing else references them.
+// A value which was collected is loaded again with the loader on the next access,
+// which suits large values that are cheap enough to recompute.
+// It is only available when building with Go 1.24 or later, which provides the weak package.
+type Weak[K comparable, T any] struct {
+	cache  *lru[K, weak.Pointer[T]] // Cache holding weak pointers to the values.
+	loader Loader[K, *T]            // Function loading missing and collected values.
+	flight flight[K, *T]            // De-duplicates concurrent loads of the same key.
+}
+
+// NewWeak creates a new instance of a read-through LRU cache with the specified size holding weak pointers
+// to its values, which loads missing and collected values with the provided loader. The loader must not be nil.
+// Items whose value was collected are removed in the background and reported as evictions.
+// Optional behaviour can be configured by passing one or more Option values.
+//
+// Example usage:
+//
+//	thumbnails := lru.NewWeak[string, image.RGBA](1000, func(ctx context.Context, path string) (*image.RGBA, error) {
+//		return render(ctx, path)
+//	})
+//	defer thumbnails.Close()
+func NewWeak[K comparable, T any](size int, loader Loader[K, *T], opts ...Option[K, weak.Pointer[T]]) *Weak[K, T] {
+	out := &Weak[K, T]{
+		cache:  newLRU(size, true, opts),
+		loader: loader,
+	}
+	out.cache.startCleaner()
+
+	return out
+}
+
+// Load returns the value associated with the provided key, promoting it like the Get of an LRU cache.
+// If the key is missing or its value was collected, the value is loaded with the loader, stored in the cache and returned.
+// Errors returned by the loader are returned as is and nothing is stored.
+// Concurrent loads of the same key share a single call of the loader.
+func (w *Weak[K, T]) Load(ctx context.Context, key K) (*T, error) {
+	key = w.cache.canonical(key)
+
+	if value, ok := w.get(key); ok {
+		return value, nil
+	}
+
+	return w.flight.do(key, func() (*T, error) {
+		if value, ok := w.peek(key); ok {
+			return value, nil
+		}
+
+		value, err := w.loader(ctx, key)
+		if err != nil || value == nil {
+			return value, err
+		}
+
+		w.Set(key, value)
+
+		return value, nil
+	})
+}
+
+// Get returns the value associated with the provided key like Load, with a background context.
+// It returns false if the loader fails or returns nil.
+func (w *Weak[K, T]) Get(key K) (*T, bool) {
+	value, err := w.Load(context.Background(), key)
+	return value, err == nil && value != nil
+}
+
+// Set stores a weak pointer to the provided value under the provided key.
+// The item is removed once the value is collected, unless another value was stored for the key in the meantime.
+func (w *Weak[K, T]) Set(key K, value *T) {
+	key = w.cache.canonical(key)
+	ptr := weak.Make(value)
+
+	w.cache.Set(key, ptr)
+
+	if value != nil {
+		runtime.AddCleanup(value, w.collected, collectedKey[K, T]{key: key, ptr: ptr})
+	}
+}
+
+// Contains checks if the provided key is present in the cache and its value was not collected.
+func (w *Weak[K, T]) Contains(key K) bool {
+	_, ok := w.peek(w.cache.canonical(key))
+	return ok
+}
+
+// Del removes the item associated with the provided key from the cache.
+// It returns false if the key is not found.
+func (w *Weak[K, T]) Del(key K) bool {
+	return w.cache.Del(key)
+}
+
+// Len returns the number of items currently stored in the cache,
+// including items whose value was collected but which were not removed yet.
+func (w *Weak[K, T]) Len() int {
+	return w.cache.Len()
+}
+
+// Stats returns a snapshot of the usage counters of the cache, where the lookups finding a collected value count as misses.
+func (w *Weak[K, T]) Stats() Stats {
+	return w.cache.Stats()
+}
+
+// Close stops any background goroutine owned by the cache, such as the expiry cleaner.
+// It is safe to call Close more than once; the cache must not be used after Close.
+func (w *Weak[K, T]) Close() {
+	w.cache.Close()
+}
+
+// get returns the value stored for the provided key, promoting it, unless the key is missing or its value was collected.
+func (w *Weak[K, T]) get(key K) (*T, bool) {
+	l := w.cache
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	c, ok := l.lookup(key)
+	if !ok {
+		l.stats.Misses++
+		return nil, false
+	}
+
+	value := c.value.Value()
+	if value == nil {
+		l.stats.Misses++
+		return nil, false
+	}
+
+	l.hit(c)
+
+	return value, true
+}
+
+// peek returns the value stored for the provided key without promoting it,
+// unless the key is missing or its value was collected.
+func (w *Weak[K, T]) peek(key K) (*T, bool) {
+	ptr, ok := w.cache.peek(key)
+	if !ok {
+		return nil, false
+	}
+
+	value := ptr.Value()
+	return value, value != nil
+}
+
+// collectedKey identifies the item of a value handed to runtime.AddCleanup,
+// without referencing the value itself so it can be collected.
+type collectedKey[K comparable, T any] struct {
+	key K               // Key the value was stored under.
+	ptr weak.Pointer[T] // Weak pointer to the value stored under the key.
+}
+
+// collected removes the item of a collected value, provided the key still holds the weak pointer to that value.
+func (w *Weak[K, T]) collected(k collectedKey[K, T]) {
+	l := w.cache
+
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	if c, ok := l.cache[k.key]; ok && c.value == k.ptr {
+		l.stats.Evictions++
+		l.remove(k.key, Evicted)
+	}
+}
@@ -0,0 +1,86 @@
+//go:build go1.24
+
+package lru
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type blob struct {
+	data [1 << 10]byte
+	key  int
+}
+
+func TestWeak(t *testing.T) {
+	newWeak := func(loads *atomic.Int32) *Weak[int, blob] {
+		return NewWeak[int, blob](10, func(_ context.Context, key int) (*blob, error) {
+			loads.Add(1)
+			if key < 0 {
+				return nil, errors.New("negative key")
+			}
+			return &blob{key: key}, nil
+		})
+	}
+
+	t.Run("should serve values which are still referenced", func(t *testing.T) {
+		var loads atomic.Int32
+		w := newWeak(&loads)
+		defer w.Close()
+
+		value, ok := w.Get(1)
+		runtime.GC()
+
+		again, _ := w.Get(1)
+		if !ok || again != value || !reflect.DeepEqual(int32(1), loads.Load()) {
+			t.Errorf("Expected %v load; Actual = %v", 1, loads.Load())
+		}
+		runtime.KeepAlive(value)
+
+		if _, err := w.Load(context.Background(), -1); err == nil || w.Contains(-1) {
+			t.Errorf("Expected the loader error; Actual = %v", err)
+		}
+	})
+
+	t.Run("should load collected values again", func(t *testing.T) {
+		var loads atomic.Int32
+		w := newWeak(&loads)
+		defer w.Close()
+
+		w.Set(1, &blob{key: 1})
+		runtime.GC()
+
+		if w.Contains(1) {
+			t.Errorf("Expected the value to be collected")
+		}
+
+		value, ok := w.Get(1)
+		if !ok || !reflect.DeepEqual(1, value.key) || !reflect.DeepEqual(int32(1), loads.Load()) {
+			t.Errorf("Expected %v load; Actual = %v", 1, loads.Load())
+		}
+		runtime.KeepAlive(value)
+	})
+
+	t.Run("should remove the items of collected values", func(t *testing.T) {
+		var loads atomic.Int32
+		w := newWeak(&loads)
+		defer w.Close()
+
+		w.Set(1, &blob{key: 1})
+
+		// cleanups run in the background once the value is collected
+		for deadline := time.Now().Add(time.Second); w.Len() > 0 && time.Now().Before(deadline); {
+			runtime.GC()
+			time.Sleep(time.Millisecond)
+		}
+
+		if !reflect.DeepEqual(0, w.Len()) || !reflect.DeepEqual(uint64(1), w.Stats().Evictions) {
+			t.Errorf("Expected %v; Actual = %v", 0, w.Len())
+		}
+	})
+}
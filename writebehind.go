@@ -0,0 +1,325 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWriteBehindWorkers is the number of goroutines writing to the store
+	// unless another number is configured in WriteBehind.
+	DefaultWriteBehindWorkers = 1
+
+	// DefaultWriteBehindQueueSize is the maximum number of pending writes
+	// unless another size is configured in WriteBehind.
+	DefaultWriteBehindQueueSize = 1024
+
+	// DefaultWriteBehindBatchSize is the maximum number of writes flushed together
+	// unless another size is configured in WriteBehind.
+	DefaultWriteBehindBatchSize = 64
+
+	// DefaultWriteBehindRetries is how many times a failed write is retried
+	// unless another number is configured in WriteBehind.
+	DefaultWriteBehindRetries = 3
+
+	// DefaultWriteBehindRetryDelay is the delay before the first retry of a failed write
+	// unless another delay is configured in WriteBehind. The delay doubles with every retry.
+	DefaultWriteBehindRetryDelay = 100 * time.Millisecond
+)
+
+// WriteBehind configures the write-behind mode of a cache created with NewBacked.
+// Zero fields are replaced by their defaults.
+type WriteBehind struct {
+	Workers    int           // Number of goroutines writing to the store.
+	QueueSize  int           // Maximum number of pending writes, Set and Delete block while the queue is full.
+	BatchSize  int           // Maximum number of writes a worker flushes together.
+	Retries    int           // How many times a failed write is retried before it is dropped, negative to never retry.
+	RetryDelay time.Duration // Delay before the first retry, doubling with every retry.
+}
+
+// BatchStore is a Store which can write several key-value pairs at once.
+// Write-behind caches flush the values of a batch with SetBatch when their store implements it.
+type BatchStore[K comparable, V any] interface {
+	Store[K, V]
+
+	// SetBatch writes the provided key-value pairs to the store, keys[i] being associated with values[i].
+	SetBatch(ctx context.Context, keys []K, values []V) error
+}
+
+// write is a pending write of a key to the store.
+type write[K comparable, V any] struct {
+	key     K      // Key to write.
+	value   V      // Value to write, unless the key is deleted.
+	deleted bool   // Whether the key is deleted from the store.
+	seq     uint64 // Number of times the key was written while pending.
+	queued  bool   // Whether the key waits in the queue rather than being written by the worker.
+}
+
+// writeQueue holds the pending writes of the keys handled by one worker.
+// Pending writes of the same key are coalesced, so only the latest value is written.
+type writeQueue[K comparable, V any] struct {
+	pending    map[K]*write[K, V] // Pending writes by key, until they are written or dropped.
+	order      []K                // Keys waiting to be written, in the order they were queued.
+	size       int                // Maximum number of pending writes.
+	closed     bool               // Whether the queue stopped accepting writes.
+	errs       []error            // Errors of dropped writes since the last flush.
+	cond       sync.Cond          // Signals changes of the queue to workers, writers and flushes.
+	sync.Mutex                    // Mutex for concurrent access.
+}
+
+// writer writes the changes of a write-behind cache to its store asynchronously.
+// Every key is hashed to exactly one queue, each drained by its own worker,
+// so the writes of a key reach the store in order.
+type writer[K comparable, V any] struct {
+	store  Store[K, V]         // Store the writes go to.
+	config WriteBehind         // Write-behind configuration, with defaults applied.
+	hasher Hasher[K]           // Hasher picking the queue of every key.
+	queues []*writeQueue[K, V] // Queues of pending writes, one per worker.
+	wg     sync.WaitGroup      // Tracks the running workers.
+}
+
+// newWriter creates a writer for the provided store and starts its workers.
+func newWriter[K comparable, V any](store Store[K, V], config WriteBehind, hasher Hasher[K]) *writer[K, V] {
+	if config.Workers <= 0 {
+		config.Workers = DefaultWriteBehindWorkers
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = DefaultWriteBehindQueueSize
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultWriteBehindBatchSize
+	}
+	if config.Retries == 0 {
+		config.Retries = DefaultWriteBehindRetries
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = DefaultWriteBehindRetryDelay
+	}
+
+	w := &writer[K, V]{
+		store:  store,
+		config: config,
+		hasher: hasher,
+		queues: make([]*writeQueue[K, V], config.Workers),
+	}
+	for i := range w.queues {
+		q := &writeQueue[K, V]{
+			pending: map[K]*write[K, V]{},
+			size:    (config.QueueSize + config.Workers - 1) / config.Workers,
+		}
+		q.cond.L = &q.Mutex
+		w.queues[i] = q
+
+		w.wg.Add(1)
+		go w.work(q)
+	}
+
+	return w
+}
+
+// queue returns the queue responsible for the provided key.
+func (w *writer[K, V]) queue(key K) *writeQueue[K, V] {
+	return w.queues[w.hasher.Hash(key)%uint64(len(w.queues))]
+}
+
+// enqueue queues a write of the provided key, coalescing it with a pending write of the same key.
+// It blocks while the queue is full and returns ErrClosed once the writer is closed.
+func (w *writer[K, V]) enqueue(key K, value V, deleted bool) error {
+	q := w.queue(key)
+
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	for !q.closed && len(q.pending) >= q.size {
+		if _, ok := q.pending[key]; ok {
+			break
+		}
+		q.cond.Wait()
+	}
+
+	if q.closed {
+		return ErrClosed
+	}
+
+	e, ok := q.pending[key]
+	if !ok {
+		e = &write[K, V]{key: key}
+		q.pending[key] = e
+	}
+
+	e.value = value
+	e.deleted = deleted
+	e.seq++
+	if !e.queued {
+		e.queued = true
+		q.order = append(q.order, key)
+	}
+	q.cond.Broadcast()
+
+	return nil
+}
+
+// lookup returns the pending write of the provided key, if any,
+// so reads observe writes which have not reached the store yet.
+func (w *writer[K, V]) lookup(key K) (value V, deleted bool, found bool) {
+	q := w.queue(key)
+
+	q.Mutex.Lock()
+	defer q.Mutex.Unlock()
+
+	e, ok := q.pending[key]
+	if !ok {
+		return value, false, false
+	}
+
+	return e.value, e.deleted, true
+}
+
+// flush waits until every write queued so far reached the store or was dropped,
+// or until the provided context is done.
+// It returns the errors of the writes dropped since the previous flush, joined with the context error, if any.
+func (w *writer[K, V]) flush(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		for _, q := range w.queues {
+			q.Mutex.Lock()
+			q.cond.Broadcast()
+			q.Mutex.Unlock()
+		}
+	})
+	defer stop()
+
+	var errs []error
+	for _, q := range w.queues {
+		q.Mutex.Lock()
+		for len(q.pending) > 0 && ctx.Err() == nil {
+			q.cond.Wait()
+		}
+		errs = append(errs, q.errs...)
+		q.errs = nil
+		q.Mutex.Unlock()
+	}
+
+	return errors.Join(append(errs, ctx.Err())...)
+}
+
+// close stops accepting writes and waits until the workers wrote every pending write.
+func (w *writer[K, V]) close() {
+	for _, q := range w.queues {
+		q.Mutex.Lock()
+		q.closed = true
+		q.cond.Broadcast()
+		q.Mutex.Unlock()
+	}
+
+	w.wg.Wait()
+}
+
+// work writes the pending writes of the provided queue in batches, until the queue is closed and drained.
+func (w *writer[K, V]) work(q *writeQueue[K, V]) {
+	defer w.wg.Done()
+
+	for {
+		q.Mutex.Lock()
+		for len(q.order) == 0 && !q.closed {
+			q.cond.Wait()
+		}
+
+		if len(q.order) == 0 {
+			q.Mutex.Unlock()
+			return
+		}
+
+		n := min(len(q.order), w.config.BatchSize)
+		batch := make([]write[K, V], n)
+		for i, key := range q.order[:n] {
+			e := q.pending[key]
+			e.queued = false
+			batch[i] = *e
+		}
+		q.order = q.order[n:]
+		q.Mutex.Unlock()
+
+		err := w.write(batch)
+
+		q.Mutex.Lock()
+		for _, b := range batch {
+			// keep the pending write if the key was written again in the meantime
+			if e, ok := q.pending[b.key]; ok && e.seq == b.seq {
+				delete(q.pending, b.key)
+			}
+		}
+		if err != nil {
+			q.errs = append(q.errs, err)
+		}
+		q.cond.Broadcast()
+		q.Mutex.Unlock()
+	}
+}
+
+// write writes the provided batch to the store, retrying the failed writes with an exponential backoff.
+// It returns the errors of the writes which still failed after the last retry.
+func (w *writer[K, V]) write(batch []write[K, V]) error {
+	delay := w.config.RetryDelay
+
+	for retry := 0; ; retry++ {
+		var err error
+		batch, err = w.attempt(batch)
+		if err == nil || retry >= w.config.Retries {
+			return err
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// attempt writes the provided batch to the store once and returns the writes which failed along with their errors.
+// Values are written together when the store implements BatchStore, deletions are written one by one.
+func (w *writer[K, V]) attempt(batch []write[K, V]) ([]write[K, V], error) {
+	ctx := context.Background()
+
+	var failed []write[K, V]
+	var errs []error
+
+	if bs, ok := w.store.(BatchStore[K, V]); ok {
+		var sets []write[K, V]
+		var keys []K
+		var values []V
+		var rest []write[K, V]
+		for _, b := range batch {
+			if b.deleted {
+				rest = append(rest, b)
+				continue
+			}
+			sets = append(sets, b)
+			keys = append(keys, b.key)
+			values = append(values, b.value)
+		}
+
+		if len(sets) > 0 {
+			if err := bs.SetBatch(ctx, keys, values); err != nil {
+				failed = append(failed, sets...)
+				errs = append(errs, err)
+			}
+		}
+		batch = rest
+	}
+
+	for _, b := range batch {
+		var err error
+		if b.deleted {
+			err = w.store.Delete(ctx, b.key)
+		} else {
+			err = w.store.Set(ctx, b.key, b.value)
+		}
+
+		if err != nil {
+			failed = append(failed, b)
+			errs = append(errs, err)
+		}
+	}
+
+	return failed, errors.Join(errs...)
+}